@@ -0,0 +1,141 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command metrics_observer shows how to bridge textual.MetricsObserver to a
+// metrics system such as Prometheus without making textual depend on it.
+//
+// The adapter only relies on two tiny interfaces, satisfied by
+// prometheus.Counter and prometheus.Observer (a Histogram). With the Prometheus
+// client, wire it like this:
+//
+//	items := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "textual_items_total"}, []string{"stage"})
+//	bytes := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "textual_bytes_total"}, []string{"stage"})
+//	latency := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "textual_send_wait_seconds"}, []string{"stage"})
+//	obs := &promObserver{
+//	    items:   func(stage string) counter { return items.WithLabelValues(stage) },
+//	    bytes:   func(stage string) counter { return bytes.WithLabelValues(stage) },
+//	    latency: func(stage string) observer { return latency.WithLabelValues(stage) },
+//	}
+//
+// This program uses in-memory stand-ins and prints what would be exported.
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	textual "github.com/benoit-pereira-da-silva/textual/pkg/textual"
+)
+
+// counter is the subset of prometheus.Counter used by the adapter.
+type counter interface {
+	Add(float64)
+}
+
+// observer is the subset of prometheus.Observer (Histogram, Summary) used by
+// the adapter.
+type observer interface {
+	Observe(float64)
+}
+
+// promObserver adapts textual.MetricsObserver to Prometheus-like vectors
+// labeled by stage.
+type promObserver struct {
+	items   func(stage string) counter
+	bytes   func(stage string) counter
+	latency func(stage string) observer
+}
+
+func (p *promObserver) IncItems(stage string) { p.items(stage).Add(1) }
+
+func (p *promObserver) ObserveBytes(stage string, n int) { p.bytes(stage).Add(float64(n)) }
+
+func (p *promObserver) ObserveLatency(stage string, d time.Duration) {
+	p.latency(stage).Observe(d.Seconds())
+}
+
+// memVec is an in-memory stand-in for a CounterVec / HistogramVec.
+type memVec struct {
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+type memSeries struct {
+	vec   *memVec
+	stage string
+}
+
+func (s memSeries) Add(v float64)     { s.vec.add(s.stage, v) }
+func (s memSeries) Observe(v float64) { s.vec.add(s.stage, v) }
+
+func (v *memVec) add(stage string, f float64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.values == nil {
+		v.values = map[string]float64{}
+	}
+	v.values[stage] += f
+}
+
+func (v *memVec) print(name string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	stages := make([]string, 0, len(v.values))
+	for stage := range v.values {
+		stages = append(stages, stage)
+	}
+	sort.Strings(stages)
+	for _, stage := range stages {
+		fmt.Printf("%s{stage=%q} %g\n", name, stage, v.values[stage])
+	}
+}
+
+func main() {
+	items, bytes, latency := &memVec{}, &memVec{}, &memVec{}
+	obs := &promObserver{
+		items:   func(stage string) counter { return memSeries{items, stage} },
+		bytes:   func(stage string) counter { return memSeries{bytes, stage} },
+		latency: func(stage string) observer { return memSeries{latency, stage} },
+	}
+
+	upper := textual.NewProcessorFunc(func(_ context.Context, s textual.StringCarrier) textual.StringCarrier {
+		return s.FromUTF8String(strings.ToUpper(s.Value)).WithIndex(s.Index)
+	})
+	chain := textual.NewChain[textual.StringCarrier](
+		textual.InstrumentObserver[textual.StringCarrier]("input", obs),
+		upper,
+		textual.InstrumentObserver[textual.StringCarrier]("upper", obs),
+	)
+
+	reader := strings.NewReader("Le Poète est semblable au prince des nuées\nQui hante la tempête et se rit de l'archer\n")
+	ioProc := textual.NewIOReaderProcessor[textual.StringCarrier](chain, reader)
+	ioProc.SetSplitFunc(bufio.ScanLines)
+
+	for item := range ioProc.Start() {
+		if err := item.GetError(); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(item.Value)
+	}
+
+	items.print("textual_items_total")
+	bytes.print("textual_bytes_total")
+	latency.print("textual_send_wait_seconds_sum")
+}
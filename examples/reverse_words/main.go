@@ -42,17 +42,6 @@ var (
 	maxDelayMS = 50 // maximum delay between batches in milliseconds
 )
 
-// caseKind models the original casing of a rune at a given position inside a
-// word. It allows the processor to restore a similar casing pattern once the
-// characters have been reversed.
-type caseKind int
-
-const (
-	caseOther caseKind = iota // non-letter or letter with no specific casing
-	caseUpper                 // uppercase letter in the original word
-	caseLower                 // lowercase letter in the original word
-)
-
 // main wires the reverse-words processor into an IOReaderProcessor that streams
 // text from disk or embedded fs, reverses every word,
 // waits a small random delay between each batch, and prints the transformed
@@ -225,52 +214,20 @@ func reverseWords(input textual.UTF8String) textual.UTF8String {
 		return unicode.IsLetter(r) || unicode.IsDigit(r)
 	}
 
-	// reverseSegment reverses the runes in [start,end) and reapplies a casing
-	// pattern based on the original runes at those positions.
+	// reverseSegment reverses the runes in [start,end) and reapplies the casing
+	// pattern of the original runes at those positions.
 	reverseSegment := func(start, end int) {
 		length := end - start
 		if length <= 1 {
 			return
 		}
 
-		// Snapshot original runes and their casing.
-		letters := make([]rune, length)
-		cases := make([]caseKind, length)
-
-		for i := 0; i < length; i++ {
-			r := runes[start+i]
-			letters[i] = r
-
-			switch {
-			case unicode.IsUpper(r):
-				cases[i] = caseUpper
-			case unicode.IsLower(r):
-				cases[i] = caseLower
-			default:
-				cases[i] = caseOther
-			}
-		}
-
-		// Reverse the letters slice in-place.
-		for i := 0; i < length/2; i++ {
-			j := length - 1 - i
-			letters[i], letters[j] = letters[j], letters[i]
-		}
-
-		// Write the reversed letters back, re-applying the original casing
-		// pattern by position.
+		original := string(runes[start:end])
+		reversed := make([]rune, length)
 		for i := 0; i < length; i++ {
-			r := letters[i]
-			switch cases[i] {
-			case caseUpper:
-				r = unicode.ToUpper(r)
-			case caseLower:
-				r = unicode.ToLower(r)
-			case caseOther:
-				// Leave r as-is (digits, symbols, etc.).
-			}
-			runes[start+i] = r
+			reversed[i] = runes[end-1-i]
 		}
+		copy(runes[start:end], []rune(textual.PreserveCase(original, string(reversed))))
 	}
 
 	// Scan the rune slice and reverse every contiguous run of "word" runes.
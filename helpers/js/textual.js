@@ -368,8 +368,9 @@ export class Fragment {
      * @param {number} [opts.len] - Length in code points in the original text.
      * @param {number} [opts.confidence] - Optional confidence value.
      * @param {number} [opts.variant] - Optional variant index for multiple candidates.
+     * @param {string} [opts.label] - Optional classification of the span (script, token type...).
      */
-    constructor({ transformed = "", pos = 0, len = 0, confidence = 0, variant = 0 } = {}) {
+    constructor({ transformed = "", pos = 0, len = 0, confidence = 0, variant = 0, label = "" } = {}) {
         /** @type {UTF8Text} */
         this.transformed = String(transformed);
         /** @type {number} */
@@ -380,6 +381,8 @@ export class Fragment {
         this.confidence = Number.isFinite(confidence) ? confidence : 0;
         /** @type {number} */
         this.variant = Number.isFinite(variant) ? Math.trunc(variant) : 0;
+        /** @type {string} */
+        this.label = label ? String(label) : "";
     }
 }
 
@@ -530,8 +533,8 @@ export class StringCarrier {
      *
      * Behaviour mirrors Go's textual.StringCarrier.Aggregate:
      *   - Items are copied and stably sorted by index.
-     *   - When indices are equal, value is used as a tie-breaker.
-     *   - The output index is reset to 0.
+     *   - When indices are equal, the original order is kept.
+     *   - The output index is the index of the first sorted item (0 when empty).
      *   - Errors are merged into a single portable string.
      *
      * @param {Array<StringCarrier|Object>} items
@@ -540,10 +543,8 @@ export class StringCarrier {
     aggregate(items) {
         const list = (items || []).map((it) => StringCarrier.fromJSON(it));
 
-        list.sort((a, b) => {
-            if (a.index !== b.index) return a.index - b.index;
-            return a.value < b.value ? -1 : a.value > b.value ? 1 : 0;
-        });
+        // Array.prototype.sort is stable: equal indices keep their order.
+        list.sort((a, b) => a.index - b.index);
 
         let out = "";
         let err = null;
@@ -551,7 +552,8 @@ export class StringCarrier {
             out += it.value;
             err = joinErrorStrings(err, it.error);
         }
-        return new StringCarrier({ value: out, index: 0, error: err });
+        const index = list.length > 0 ? list[0].index : 0;
+        return new StringCarrier({ value: out, index, error: err });
     }
 }
 
@@ -740,8 +742,8 @@ export class JsonCarrier {
      *
      * Behaviour mirrors Go's textual.JsonCarrier.Aggregate:
      *   - Items are copied and stably sorted by index.
-     *   - When indices are equal, value is used as a tie-breaker.
-     *   - The output index is reset to 0.
+     *   - When indices are equal, the original order is kept.
+     *   - The output index is the index of the first sorted item (0 when empty).
      *   - Errors are merged into a single portable string.
      *
      * Important: `value` strings are inserted as-is, no JSON validation.
@@ -753,11 +755,10 @@ export class JsonCarrier {
         const list = (items || []).map((it) => JsonCarrier.fromJSON(it));
 
         // Stable tie-breaker (arrival order) for deterministic output.
-        const decorated = list.map((it, order) => ({ it, order: Number.isFinite(order) ? order : 0 }));
+        const decorated = list.map((it, order) => ({ it, order }));
 
         decorated.sort((a, b) => {
             if (a.it.index !== b.it.index) return a.it.index - b.it.index;
-            if (a.it.value !== b.it.value) return a.it.value < b.it.value ? -1 : 1;
             return a.order - b.order;
         });
 
@@ -772,7 +773,8 @@ export class JsonCarrier {
         }
         out += "]";
 
-        return new JsonCarrier({ value: out, index: 0, error: err });
+        const index = decorated.length > 0 ? decorated[0].it.index : 0;
+        return new JsonCarrier({ value: out, index, error: err });
     }
 }
 
@@ -852,6 +854,7 @@ export class Parcel {
                 len: f.len,
                 confidence: f.confidence,
                 variant: f.variant,
+                ...(f.label ? { label: f.label } : {}),
             })),
             error: this.error,
         };
@@ -962,6 +965,7 @@ export class Parcel {
             len: f.len,
             confidence: f.confidence,
             variant: f.variant,
+            label: f.label,
         }));
 
         chosen.sort((a, b) => {
@@ -1188,6 +1192,7 @@ export class Parcel {
                         len: Number.isFinite(f.len) ? Math.trunc(f.len) : 0,
                         confidence: Number.isFinite(f.confidence) ? f.confidence : 0,
                         variant: Number.isFinite(f.variant) ? Math.trunc(f.variant) : 0,
+                        label: f.label,
                     }));
                 }
             }
@@ -201,7 +201,7 @@ public struct StringCarrier: Codable, Equatable, Carrier {
     ///
     /// Behaviour mirrors the Go `Aggregate` intent:
     ///   - Items are stably sorted by index.
-    ///   - Output index is reset to 0.
+    ///   - Output index is the index of the first sorted item (0 when empty).
     ///   - Errors are merged into a single portable string.
     public func aggregate(_ items: [StringCarrier]) -> StringCarrier {
         return StringCarrier.aggregate(items)
@@ -219,7 +219,7 @@ public struct StringCarrier: Codable, Equatable, Carrier {
             mergedError = joinErrors(mergedError, it.error)
         }
 
-        return StringCarrier(value: out, index: 0, error: mergedError)
+        return StringCarrier(value: out, index: sorted.first?.index ?? 0, error: mergedError)
     }
 }
 
@@ -233,11 +233,14 @@ public struct CsvCarrier: Codable, Equatable, Carrier {
     public var value: UTF8String
     public var index: Int
     public var error: String?
+    /// Optional column names of the record (mirrors Go `CsvCarrier.Header`).
+    public var header: [String]?
 
-    public init(value: UTF8String, index: Int = 0, error: String? = nil) {
+    public init(value: UTF8String, index: Int = 0, error: String? = nil, header: [String]? = nil) {
         self.value = value
         self.index = index
         self.error = normalizeError(error)
+        self.header = header
     }
 
     public func utf8String() -> UTF8String { value }
@@ -262,12 +265,19 @@ public struct CsvCarrier: Codable, Equatable, Carrier {
 
     public func getError() -> String? { error }
 
+    public func withHeader(_ header: [String]?) -> CsvCarrier {
+        var copy = self
+        copy.header = header
+        return copy
+    }
+
     /// Aggregates multiple CsvCarrier values into a multi-record CSV text.
     ///
     /// Behaviour mirrors the Go documentation:
     ///   - Items are stably sorted by index.
     ///   - Records are joined with "\n".
-    ///   - Output index is reset to 0.
+    ///   - Output index is the index of the first sorted item (0 when empty).
+    ///   - The header of the first item is kept.
     public func aggregate(_ items: [CsvCarrier]) -> CsvCarrier {
         return CsvCarrier.aggregate(items)
     }
@@ -285,7 +295,7 @@ public struct CsvCarrier: Codable, Equatable, Carrier {
             mergedError = joinErrors(mergedError, it.error)
         }
 
-        return CsvCarrier(value: out, index: 0, error: mergedError)
+        return CsvCarrier(value: out, index: sorted.first?.index ?? 0, error: mergedError, header: sorted.first?.header)
     }
 }
 
@@ -333,6 +343,11 @@ public struct XmlCarrier: Codable, Equatable, Carrier {
 
     public func getError() -> String? { error }
 
+    /// Aggregates multiple XmlCarrier values into an "<items>" container.
+    ///
+    /// Behaviour mirrors the Go documentation:
+    ///   - Items are stably sorted by index.
+    ///   - Output index is the index of the first sorted item (0 when empty).
     public func aggregate(_ items: [XmlCarrier]) -> XmlCarrier {
         return XmlCarrier.aggregate(items)
     }
@@ -350,7 +365,7 @@ public struct XmlCarrier: Codable, Equatable, Carrier {
         }
         out.append("</items>")
 
-        return XmlCarrier(value: out, index: 0, error: mergedError)
+        return XmlCarrier(value: out, index: sorted.first?.index ?? 0, error: mergedError)
     }
 }
 
@@ -537,7 +552,7 @@ public struct JsonCarrier: Codable, Equatable, Carrier {
     /// Behaviour mirrors the Go documentation intent:
     ///   - Items are stably sorted by index.
     ///   - The output `value` is a JSON array built by concatenating raw values.
-    ///   - Output index is reset to 0.
+    ///   - Output index is the index of the first sorted item (0 when empty).
     ///   - No JSON validation is performed when aggregating (values are inserted as-is).
     public func aggregate(_ items: [JsonCarrier]) -> JsonCarrier {
         return JsonCarrier.aggregate(items)
@@ -557,7 +572,7 @@ public struct JsonCarrier: Codable, Equatable, Carrier {
         }
         out.append("]")
 
-        return JsonCarrier(value: RawJSON(utf8String: out), index: 0, error: mergedError)
+        return JsonCarrier(value: RawJSON(utf8String: out), index: sorted.first?.index ?? 0, error: mergedError)
     }
 }
 
@@ -625,19 +640,22 @@ public struct Fragment: Codable, Equatable {
     public var len: Int
     public var confidence: Double
     public var variant: Int
+    public var label: String?
 
     public init(
         transformed: UTF8String,
         pos: Int,
         len: Int,
         confidence: Double = 0,
-        variant: Int = 0
+        variant: Int = 0,
+        label: String? = nil
     ) {
         self.transformed = transformed
         self.pos = pos
         self.len = len
         self.confidence = confidence
         self.variant = variant
+        self.label = label
     }
 }
 
@@ -825,7 +843,8 @@ public struct Parcel: Codable, Equatable, Carrier {
                         pos: f.pos + offset,
                         len: f.len,
                         confidence: f.confidence,
-                        variant: f.variant
+                        variant: f.variant,
+                        label: f.label
                     )
                 )
             }
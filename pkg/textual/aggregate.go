@@ -0,0 +1,89 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"sort"
+	"strings"
+)
+
+// Aggregatable is implemented by carriers that know how to merge several values
+// into a single one (the "fan-in" representation of the carrier).
+//
+// Like FromUTF8String, Aggregate treats its receiver as a prototype: it is
+// usually called on the zero value of S and must not rely on receiver state.
+//
+// Built-in carriers implement it:
+//
+//   - StringCarrier concatenates values,
+//   - Parcel concatenates texts and shifts fragment positions accordingly,
+//   - JsonCarrier builds a JSON array,
+//   - JsonLinesCarrier joins values with "\n" (JSON Lines),
+//   - JsonGenericCarrier builds a JSON array (kept in Raw, decoded into T when
+//     T can hold it),
+//   - CsvCarrier joins records with "\n",
+//   - XmlCarrier wraps elements into an "<items>" container.
+//
+// Implementations are expected to order items by Index (stable), to use the
+// first index as the index of the result, and to join per-item errors.
+type Aggregatable[S any] interface {
+	Aggregate(items []S) S
+}
+
+// Aggregate merges items into a single carrier.
+//
+// If S implements Aggregatable[S], its Aggregate method is used. Otherwise, the
+// items are stably sorted by index, their UTF8String renderings are
+// concatenated, and the result is rebuilt with FromUTF8String. The result
+// carries the first index and every per-item error.
+//
+// Aggregate of an empty slice returns the zero value of S.
+func Aggregate[S Carrier[S]](items []S) S {
+	proto := *new(S)
+	if len(items) == 0 {
+		return proto
+	}
+	if a, ok := any(proto).(Aggregatable[S]); ok {
+		return a.Aggregate(items)
+	}
+
+	sorted := sortedByIndex(items)
+	var b strings.Builder
+	for _, it := range sorted {
+		b.WriteString(it.UTF8String())
+	}
+	res := proto.FromUTF8String(UTF8String(b.String())).WithIndex(sorted[0].GetIndex())
+	return withJoinedErrors(res, sorted)
+}
+
+// sortedByIndex returns a copy of items stably sorted by GetIndex().
+func sortedByIndex[S Carrier[S]](items []S) []S {
+	sorted := make([]S, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].GetIndex() < sorted[j].GetIndex()
+	})
+	return sorted
+}
+
+// withJoinedErrors attaches the per-item errors of items to res.
+func withJoinedErrors[S Carrier[S]](res S, items []S) S {
+	for _, it := range items {
+		if err := it.GetError(); err != nil {
+			res = res.WithError(err)
+		}
+	}
+	return res
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAggregate_ParcelShiftsFragments(t *testing.T) {
+	p0 := Parcel{Index: 0, Text: "été ", Fragments: []Fragment{{Transformed: "summer", Pos: 0, Len: 3}}}
+	p1 := Parcel{Index: 1, Text: "chaud", Fragments: []Fragment{{Transformed: "hot", Pos: 0, Len: 5}}}
+
+	// Inputs are deliberately out of order.
+	res := Aggregate([]Parcel{p1, p0})
+
+	if got, want := res.Text, "été chaud"; got != want {
+		t.Fatalf("unexpected text: got %q want %q", got, want)
+	}
+	if got, want := res.UTF8String(), "summer hot"; got != want {
+		t.Fatalf("unexpected rendering: got %q want %q", got, want)
+	}
+	if got, want := res.Fragments[1].Pos, 4; got != want {
+		t.Fatalf("unexpected shifted position: got %d want %d", got, want)
+	}
+	if res.Index != 0 {
+		t.Fatalf("unexpected index: got %d want %d", res.Index, 0)
+	}
+}
+
+func TestAggregate_JoinsErrors(t *testing.T) {
+	e1 := errors.New("e1")
+	e2 := errors.New("e2")
+	res := Aggregate([]CsvCarrier{
+		{Value: "a,b", Index: 0, Error: e1},
+		{Value: "c,d", Index: 1, Error: e2},
+	})
+	if got, want := res.Value, "a,b\nc,d"; got != want {
+		t.Fatalf("unexpected csv aggregation: got %q want %q", got, want)
+	}
+	if !errors.Is(res.Error, e1) || !errors.Is(res.Error, e2) {
+		t.Fatalf("expected both errors to be joined, got %v", res.Error)
+	}
+}
+
+func TestAggregate_FallbackConcatenates(t *testing.T) {
+	res := Aggregate([]Tagged[StringCarrier]{
+		TagItem(StringCarrier{Value: "b", Index: 1}),
+		TagItem(StringCarrier{Value: "a", Index: 0}),
+	})
+	if got, want := res.UTF8String(), "ab"; got != want {
+		t.Fatalf("unexpected fallback aggregation: got %q want %q", got, want)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// NewApplyToType returns a Processor running inner only on the items whose
+// content type, as reported by DetectContentType, equals ct (for instance
+// ContentTypeJSON). Other items are forwarded unchanged.
+//
+// Comparison ignores case and parameters such as "; charset=utf-8". It is a
+// shorthand for If with a content-type predicate, so the same routing and
+// ordering rules apply: outputs of inner and forwarded items are merged
+// concurrently, and the index is preserved.
+func NewApplyToType[S Carrier[S]](ct string, inner Processor[S]) ProcessorFunc[S] {
+	isType := func(_ context.Context, item S) bool {
+		return sameContentType(DetectContentType(item.UTF8String()), ct)
+	}
+	return ProcessorFuncFrom[S](If(isType).Then(inner))
+}
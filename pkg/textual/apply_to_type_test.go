@@ -0,0 +1,50 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewApplyToType_JSONOnly(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	values := []string{`{"a":1}`, "plain text", `[1,2]`, "{not json"}
+	in := make(chan StringCarrier, len(values))
+	for i, v := range values {
+		in <- StringCarrier{Value: v, Index: i}
+	}
+	close(in)
+
+	wrap := MapString(func(s string) string { return `{"wrapped":` + s + `}` })
+	out, err := collectWithContext(ctx, NewApplyToType[StringCarrier]("application/json; charset=utf-8", wrap).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sortByIndex(out)
+
+	want := []string{`{"wrapped":{"a":1}}`, "plain text", `{"wrapped":[1,2]}`, "{not json"}
+	if len(out) != len(want) {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), len(want))
+	}
+	for i := range want {
+		if out[i].Value != want[i] {
+			t.Fatalf("unexpected item %d: got %q want %q", i, out[i].Value, want[i])
+		}
+	}
+}
@@ -80,6 +80,40 @@ func TestChain_NoProcessorsReturnsInputChannel(t *testing.T) {
 	close(in)
 }
 
+// namedSuffix is a procSuffix stage implementing Named.
+type namedSuffix struct {
+	Processor[StringCarrier]
+	name string
+}
+
+func (n namedSuffix) Name() string { return n.name }
+
+func TestChain_Introspection(t *testing.T) {
+	a := namedSuffix{Processor: procSuffix("A"), name: "suffix-a"}
+	inner := NewChainOf[StringCarrier](namedSuffix{Processor: procSuffix("B"), name: "suffix-b"})
+	chain := NewChainOf[StringCarrier](a, nil, inner, nil, procSuffix("C"))
+
+	if got, want := chain.Len(), 3; got != want {
+		t.Fatalf("unexpected Len: got %d want %d", got, want)
+	}
+	stages := chain.Stages()
+	if len(stages) != 3 {
+		t.Fatalf("unexpected stage count: got %d want %d", len(stages), 3)
+	}
+	stages[0] = nil
+	if chain.Stages()[0] == nil {
+		t.Fatalf("expected Stages to return a copy")
+	}
+
+	want := "suffix-a -> [suffix-b] -> textual.ProcessorFunc[github.com/benoit-pereira-da-silva/textual/pkg/textual.StringCarrier]"
+	if got := chain.Describe(); got != want {
+		t.Fatalf("unexpected Describe: got %q want %q", got, want)
+	}
+	if got, want := NewChainOf[StringCarrier](nil).Describe(), "(empty chain)"; got != want {
+		t.Fatalf("unexpected Describe: got %q want %q", got, want)
+	}
+}
+
 func TestRouter_PassThroughWhenNoRoutes(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
@@ -0,0 +1,119 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// NewAssert11 wraps inner and verifies that it never emits more outputs than
+// it received inputs, as promised by the Async contract (one output per input).
+//
+// It is a development aid to validate custom stages: items flow through
+// unchanged, while input and output counts are tracked. When the output count
+// exceeds the input count, a violation is stored in the context PanicStore
+// (the first violation only; see PanicStore.Store). The stream itself is not
+// interrupted.
+//
+// Dropped items (fewer outputs than inputs) are not reported, since stages are
+// allowed to stop early when ctx is canceled.
+//
+// If inner is nil, items are passed through unchanged.
+func NewAssert11[S Carrier[S]](inner Processor[S]) ProcessorFunc[S] {
+	if inner == nil {
+		return passThroughProcessor[S]()
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		ctx, ps := EnsurePanicStore(ctx)
+
+		var inputs atomic.Int64
+		counted := make(chan S)
+		go func() {
+			defer close(counted)
+			defer func() {
+				if r := recover(); r != nil {
+					ps.Store(r, debug.Stack())
+				}
+			}()
+			for {
+				var item S
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-in:
+					if !ok {
+						return
+					}
+					item = v
+				}
+				// Count before handing over, so the output count can never
+				// legitimately be ahead of the input count.
+				inputs.Add(1)
+				select {
+				case counted <- item:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		innerOut, ok := safeApplyProcessor(ctx, ps, inner, counted)
+		if !ok {
+			return innerOut
+		}
+
+		out := make(chan S)
+		go func() {
+			defer close(out)
+			defer func() {
+				if r := recover(); r != nil {
+					ps.Store(r, debug.Stack())
+				}
+			}()
+			var outputs int64
+			reported := false
+			check := func() {
+				if n := inputs.Load(); !reported && outputs > n {
+					ps.Store(fmt.Sprintf("textual: Assert11: inner processor emitted %d outputs for %d inputs", outputs, n), debug.Stack())
+					reported = true
+				}
+			}
+			for {
+				var item S
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-innerOut:
+					if !ok {
+						check()
+						return
+					}
+					item = v
+				}
+				outputs++
+				check()
+				select {
+				case out <- item:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return out
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAssert11_CompliantInnerIsNotReported(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ctx, ps := WithPanicStore(ctx)
+
+	upper := NewProcessorFunc(func(_ context.Context, s StringCarrier) StringCarrier {
+		s.Value = strings.ToUpper(s.Value)
+		return s
+	})
+
+	in := make(chan StringCarrier, 3)
+	for i, w := range []string{"a", "b", "c"} {
+		in <- StringCarrier{Value: w, Index: i}
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewAssert11[StringCarrier](upper).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 3)
+	}
+	if info, ok := ps.Load(); ok {
+		t.Fatalf("unexpected violation: %v", info.Value)
+	}
+}
+
+func TestNewAssert11_FanOutInnerIsReported(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ctx, ps := WithPanicStore(ctx)
+
+	twice := ProcessorFunc[StringCarrier](func(ctx context.Context, in <-chan StringCarrier) <-chan StringCarrier {
+		return AsyncEmitter(ctx, in, func(_ context.Context, s StringCarrier, emit func(StringCarrier)) {
+			emit(s)
+			emit(s)
+		})
+	})
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "a", Index: 0}
+	in <- StringCarrier{Value: "b", Index: 1}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewAssert11[StringCarrier](twice).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("items should flow unchanged: got %d want %d", len(items), 4)
+	}
+	info, ok := ps.Load()
+	if !ok {
+		t.Fatalf("expected a violation to be stored")
+	}
+	if msg, _ := info.Value.(string); !strings.Contains(msg, "Assert11") {
+		t.Fatalf("unexpected violation: %v", info.Value)
+	}
+}
+
+func TestNewAssert11_CanceledStopsWithOpenInput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// rangeInner only stops once its input is closed.
+	rangeInner := ProcessorFunc[StringCarrier](func(_ context.Context, in <-chan StringCarrier) <-chan StringCarrier {
+		out := make(chan StringCarrier)
+		go func() {
+			defer close(out)
+			for item := range in {
+				out <- item
+			}
+		}()
+		return out
+	})
+
+	in := make(chan StringCarrier) // never closed
+	out := NewAssert11[StringCarrier](rangeInner).Apply(ctx, in)
+	cancel()
+
+	select {
+	case item, ok := <-out:
+		if ok {
+			t.Fatalf("unexpected item after cancellation: %#v", item)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("output not closed after cancellation")
+	}
+}
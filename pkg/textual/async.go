@@ -17,6 +17,7 @@ package textual
 import (
 	"context"
 	"runtime/debug"
+	"time"
 )
 
 // Async starts a single-worker streaming "map" stage.
@@ -170,11 +171,15 @@ func Async[T1 any, T2 any](ctx context.Context, in <-chan T1, f func(ctx context
 	// Note: canceling this child context does NOT cancel the parent context; it
 	// only signals this stage (and any goroutines derived from it).
 	ctx, cancel := context.WithCancel(ctx)
+	log := LoggerFromContext(ctx)
 
 	out := make(chan T2)
 	go func() {
 		defer close(out)
 
+		// count is the number of items sent downstream (reported in debug logs).
+		count := 0
+
 		// Always cancel the child context so the context tree can be released
 		// promptly (best practice with context.WithCancel / WithTimeout).
 		defer cancel()
@@ -183,6 +188,7 @@ func Async[T1 any, T2 any](ctx context.Context, in <-chan T1, f func(ctx context
 		// The panic is swallowed: Async terminates the stream early by closing `out`.
 		defer func() {
 			if r := recover(); r != nil {
+				log.Warnf("textual: Async recovered a panic after %d item(s): %v", count, r)
 				if ps := PanicStoreFromContext(ctx); ps != nil {
 					ps.Store(r, debug.Stack())
 				}
@@ -195,15 +201,18 @@ func Async[T1 any, T2 any](ctx context.Context, in <-chan T1, f func(ctx context
 		for {
 			select {
 			case <-ctx.Done():
+				log.Debugf("textual: Async canceled after %d item(s)", count)
 				return
 			case s, ok := <-in:
 				if !ok {
+					log.Debugf("textual: Async input closed after %d item(s)", count)
 					return
 				}
 
 				// If cancellation raced with the receive, avoid doing any more work.
 				select {
 				case <-ctx.Done():
+					log.Debugf("textual: Async canceled after %d item(s)", count)
 					return
 				default:
 				}
@@ -213,8 +222,10 @@ func Async[T1 any, T2 any](ctx context.Context, in <-chan T1, f func(ctx context
 
 				select {
 				case <-ctx.Done():
+					log.Debugf("textual: Async canceled after %d item(s)", count)
 					return
 				case out <- res:
+					count++
 				}
 			}
 		}
@@ -243,6 +254,67 @@ func Async[T1 any, T2 any](ctx context.Context, in <-chan T1, f func(ctx context
 //
 // Like Async, AsyncEmitter never closes `in` and closes the returned channel exactly once.
 func AsyncEmitter[T1 any, T2 any](ctx context.Context, in <-chan T1, f func(ctx context.Context, t T1, emit func(T2))) <-chan T2 {
+	return asyncEmitter(ctx, in, f, nil)
+}
+
+// AsyncTimeout is like Async, but bounds the duration of every f invocation.
+//
+// Each call runs f with a per-item context derived from ctx with a perItem
+// timeout. When f does not return within perItem, the item is abandoned: nothing
+// is emitted for it and the stage moves on to the next input without waiting
+// for f. Results are never emitted out of order.
+//
+// Since Go cannot stop a goroutine from the outside, f must be
+// cancellation-aware (watch ctx.Done()) to actually stop working on an
+// abandoned item; otherwise its goroutine keeps running until f returns and its
+// result is discarded.
+//
+// A panic in f is recorded into the PanicStore and stops the stream, like Async.
+//
+// When perItem <= 0, AsyncTimeout behaves exactly like Async.
+func AsyncTimeout[T1 any, T2 any](ctx context.Context, perItem time.Duration, in <-chan T1, f func(ctx context.Context, t T1) T2) <-chan T2 {
+	if perItem <= 0 {
+		return Async(ctx, in, f)
+	}
+	return asyncEmitter(ctx, in, func(ctx context.Context, t T1, emit func(T2)) {
+		itemCtx, cancel := context.WithTimeout(ctx, perItem)
+		defer cancel()
+
+		done := make(chan T2, 1)
+		failed := make(chan any, 1)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					// Store here to keep the stack of f.
+					if ps := PanicStoreFromContext(ctx); ps != nil {
+						ps.Store(r, debug.Stack())
+					}
+					failed <- r
+				}
+			}()
+			done <- f(itemCtx, t)
+		}()
+
+		select {
+		case res := <-done:
+			emit(res)
+		case r := <-failed:
+			// Stop the stage, as Async does (the PanicStore keeps the first value).
+			panic(r)
+		case <-itemCtx.Done():
+			// Deadline exceeded (or stage canceled): abandon the item.
+		}
+	}, nil)
+}
+
+// asyncEmitter implements AsyncEmitter.
+//
+// When flush is non-nil, it is called once, with the same emit callback, after
+// `in` has been closed by upstream. It is NOT called when the stage stops
+// because ctx is canceled (or because f panicked). This is the hook used by
+// buffering stages (batchers, windows, group-by, ...) to emit what they still
+// hold at end of stream.
+func asyncEmitter[T1 any, T2 any](ctx context.Context, in <-chan T1, f func(ctx context.Context, t T1, emit func(T2)), flush func(ctx context.Context, emit func(T2))) <-chan T2 {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -256,11 +328,16 @@ func AsyncEmitter[T1 any, T2 any](ctx context.Context, in <-chan T1, f func(ctx
 	// Derive a cancellable child context so ctx.Done() is always non-nil and
 	// so we can always call cancel() on exit to release context resources.
 	ctx, cancel := context.WithCancel(ctx)
+	log := LoggerFromContext(ctx)
 
 	out := make(chan T2)
 	go func() {
 		defer close(out)
 
+		// count is the number of items received from upstream (reported in
+		// debug logs). emit may run on other goroutines, so it is not counted.
+		count := 0
+
 		// Cancel the child context before closing out, so any late emit calls
 		// can observe ctx.Done() and return quickly.
 		defer cancel()
@@ -269,6 +346,7 @@ func AsyncEmitter[T1 any, T2 any](ctx context.Context, in <-chan T1, f func(ctx
 		// The panic is swallowed: AsyncEmitter terminates the stream early by closing `out`.
 		defer func() {
 			if r := recover(); r != nil {
+				log.Warnf("textual: AsyncEmitter recovered a panic after %d item(s): %v", count, r)
 				if ps := PanicStoreFromContext(ctx); ps != nil {
 					ps.Store(r, debug.Stack())
 				}
@@ -298,18 +376,26 @@ func AsyncEmitter[T1 any, T2 any](ctx context.Context, in <-chan T1, f func(ctx
 		for {
 			select {
 			case <-ctx.Done():
+				log.Debugf("textual: AsyncEmitter canceled after %d item(s)", count)
 				return
 			case s, ok := <-in:
 				if !ok {
+					log.Debugf("textual: AsyncEmitter input closed after %d item(s)", count)
+					if flush != nil {
+						// Any panic in flush(ctx, emit) is recovered by the defer above.
+						flush(ctx, emit)
+					}
 					return
 				}
 
 				// If cancellation raced with the receive, avoid doing any more work.
 				select {
 				case <-ctx.Done():
+					log.Debugf("textual: AsyncEmitter canceled after %d item(s)", count)
 					return
 				default:
 				}
+				count++
 
 				// Any panic in f(ctx, s, emit) is recovered by the defer above.
 				// f may call emit zero, one, or many times.
@@ -212,3 +212,64 @@ func ExampleAsync_withPanicStore() {
 	fmt.Println(ok, info.Value)
 	// Output: true boom
 }
+
+func TestAsyncTimeout_AbandonsSlowItemsAndContinues(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan int, 3)
+	in <- 1
+	in <- 2
+	in <- 3
+	close(in)
+
+	out := AsyncTimeout(ctx, 50*time.Millisecond, in, func(ctx context.Context, v int) int {
+		if v == 2 {
+			// Hang past the deadline, but honor cancellation.
+			select {
+			case <-time.After(time.Second):
+			case <-ctx.Done():
+			}
+		}
+		return v * 10
+	})
+
+	start := time.Now()
+	items, err := collectWithContext(ctx, out)
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if got, want := items, []int{10, 30}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected values: got %#v want %#v", got, want)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("stage was blocked by the slow item: %v", elapsed)
+	}
+}
+
+func TestAsyncTimeout_RecoversPanic(t *testing.T) {
+	baseCtx, baseCancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer baseCancel()
+
+	ctx, ps := WithPanicStore(baseCtx)
+
+	in := make(chan int, 2)
+	in <- 1
+	in <- 2
+	close(in)
+
+	out := AsyncTimeout(ctx, time.Second, in, func(_ context.Context, v int) int {
+		panic("boom")
+	})
+
+	items, err := collectWithContext(baseCtx, out)
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("expected no values after panic, got %#v", items)
+	}
+	if info, ok := ps.Load(); !ok || info.Value != "boom" {
+		t.Fatalf("unexpected panic info: %#v ok=%v", info, ok)
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"strings"
+
+	"golang.org/x/text/unicode/bidi"
+)
+
+// NewBiDiDisplay returns a Processor that converts the logical-order text of
+// each item into its visual order (Unicode Bidirectional Algorithm, UAX #9),
+// so that mixed right-to-left (Hebrew, Arabic, ...) and left-to-right text can
+// be displayed by renderers that do not implement BiDi themselves.
+//
+// Each line ("\n"-separated) is a paragraph whose base direction is given by
+// its first strong character (left-to-right when there is none). The
+// implementation covers the implicit part of the algorithm:
+//
+//   - weak types resolution (numbers, separators, non-spacing marks),
+//   - neutral resolution from the surrounding strong types,
+//   - implicit levels, trailing whitespace reset and run reversal (L1, L2),
+//   - mirroring of brackets in right-to-left runs (L4).
+//
+// Explicit embeddings, overrides and isolates (LRE, RLO, LRI, ...) are treated
+// as neutrals, and non-spacing marks are not moved after their reversed base
+// (L3 is optional in UAX #9).
+//
+// The output is meant for display only: it must not be processed again as
+// logical text. Index and Error are preserved.
+func NewBiDiDisplay[S Carrier[S]]() ProcessorFunc[S] {
+	return NewProcessorFunc(func(_ context.Context, item S) S {
+		visual := bidiVisual(item.UTF8String())
+		res := item.FromUTF8String(visual).WithIndex(item.GetIndex())
+		return res.WithError(item.GetError())
+	})
+}
+
+// bidiVisual returns the visual order of s, line by line.
+func bidiVisual(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = bidiVisualLine([]rune(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func bidiVisualLine(runes []rune) string {
+	n := len(runes)
+	if n == 0 {
+		return ""
+	}
+
+	types := make([]bidi.Class, n)
+	original := make([]bidi.Class, n)
+	for i, r := range runes {
+		p, _ := bidi.LookupRune(r)
+		types[i] = p.Class()
+		original[i] = types[i]
+	}
+
+	// P2, P3: the paragraph level comes from the first strong character.
+	base := bidi.L
+	for _, t := range types {
+		if t == bidi.L {
+			break
+		}
+		if t == bidi.R || t == bidi.AL {
+			base = bidi.R
+			break
+		}
+	}
+	paragraphLevel := 0
+	if base == bidi.R {
+		paragraphLevel = 1
+	}
+
+	// W1: non-spacing marks take the type of the previous character.
+	for i, t := range types {
+		if t == bidi.NSM {
+			if i == 0 {
+				types[i] = base
+			} else {
+				types[i] = types[i-1]
+			}
+		}
+	}
+
+	// W2, W3: European numbers after an Arabic letter are Arabic numbers, then
+	// Arabic letters become R.
+	lastStrong := base
+	for i, t := range types {
+		switch t {
+		case bidi.L, bidi.R, bidi.AL:
+			lastStrong = t
+		case bidi.EN:
+			if lastStrong == bidi.AL {
+				types[i] = bidi.AN
+			}
+		}
+	}
+	for i, t := range types {
+		if t == bidi.AL {
+			types[i] = bidi.R
+		}
+	}
+
+	// W4: a single separator between two numbers of the same type joins them.
+	for i := 1; i < n-1; i++ {
+		prev, next := types[i-1], types[i+1]
+		switch {
+		case types[i] == bidi.ES && prev == bidi.EN && next == bidi.EN:
+			types[i] = bidi.EN
+		case types[i] == bidi.CS && prev == next && (prev == bidi.EN || prev == bidi.AN):
+			types[i] = prev
+		}
+	}
+
+	// W5: terminators adjacent to European numbers become European numbers.
+	for i := 0; i < n; {
+		if types[i] != bidi.ET {
+			i++
+			continue
+		}
+		start := i
+		for i < n && types[i] == bidi.ET {
+			i++
+		}
+		if (start > 0 && types[start-1] == bidi.EN) || (i < n && types[i] == bidi.EN) {
+			for j := start; j < i; j++ {
+				types[j] = bidi.EN
+			}
+		}
+	}
+
+	// W6, W7: remaining separators are neutrals; European numbers in a
+	// left-to-right context are L.
+	lastStrong = base
+	for i, t := range types {
+		switch t {
+		case bidi.ES, bidi.ET, bidi.CS:
+			types[i] = bidi.ON
+		case bidi.L, bidi.R:
+			lastStrong = t
+		case bidi.EN:
+			if lastStrong == bidi.L {
+				types[i] = bidi.L
+			}
+		}
+	}
+
+	// N1, N2: neutrals take the direction of the surrounding strong types when
+	// both sides agree (numbers count as R), the paragraph direction otherwise.
+	strongOf := func(t bidi.Class) (bidi.Class, bool) {
+		switch t {
+		case bidi.L:
+			return bidi.L, true
+		case bidi.R, bidi.EN, bidi.AN:
+			return bidi.R, true
+		}
+		return 0, false
+	}
+	for i := 0; i < n; {
+		if _, ok := strongOf(types[i]); ok {
+			i++
+			continue
+		}
+		start := i
+		for i < n {
+			if _, ok := strongOf(types[i]); ok {
+				break
+			}
+			i++
+		}
+		before, after := base, base
+		if start > 0 {
+			before, _ = strongOf(types[start-1])
+		}
+		if i < n {
+			after, _ = strongOf(types[i])
+		}
+		dir := base
+		if before == after {
+			dir = before
+		}
+		for j := start; j < i; j++ {
+			types[j] = dir
+		}
+	}
+
+	// I1, I2: implicit levels.
+	levels := make([]int, n)
+	maxLevel := paragraphLevel
+	for i, t := range types {
+		level := paragraphLevel
+		switch {
+		case paragraphLevel%2 == 0 && t == bidi.R:
+			level++
+		case paragraphLevel%2 == 0 && (t == bidi.AN || t == bidi.EN):
+			level += 2
+		case paragraphLevel%2 == 1 && (t == bidi.L || t == bidi.AN || t == bidi.EN):
+			level++
+		}
+		levels[i] = level
+		if level > maxLevel {
+			maxLevel = level
+		}
+	}
+
+	// L1: segment separators, and whitespace preceding them or the end of the
+	// line, are reset to the paragraph level.
+	trailing := true
+	for i := n - 1; i >= 0; i-- {
+		switch original[i] {
+		case bidi.S:
+			levels[i] = paragraphLevel
+			trailing = true
+		case bidi.WS, bidi.BN, bidi.Control:
+			if trailing {
+				levels[i] = paragraphLevel
+			}
+		default:
+			trailing = false
+		}
+	}
+
+	// L4: mirror brackets at odd (right-to-left) levels.
+	out := make([]rune, n)
+	copy(out, runes)
+	for i, r := range out {
+		if levels[i]%2 == 1 {
+			if p, _ := bidi.LookupRune(r); p.IsBracket() {
+				out[i] = []rune(bidi.ReverseString(string(r)))[0]
+			}
+		}
+	}
+
+	// L2: from the highest level down to the lowest odd level, reverse every
+	// run of characters at that level or higher.
+	lowestOdd := paragraphLevel
+	if lowestOdd%2 == 0 {
+		lowestOdd++
+	}
+	for level := maxLevel; level >= lowestOdd; level-- {
+		for i := 0; i < n; {
+			if levels[i] < level {
+				i++
+				continue
+			}
+			start := i
+			for i < n && levels[i] >= level {
+				i++
+			}
+			for a, b := start, i-1; a < b; a, b = a+1, b-1 {
+				out[a], out[b] = out[b], out[a]
+				levels[a], levels[b] = levels[b], levels[a]
+			}
+		}
+	}
+	return string(out)
+}
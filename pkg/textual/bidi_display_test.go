@@ -0,0 +1,61 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBidiVisual(t *testing.T) {
+	cases := []struct {
+		name    string
+		logical string
+		visual  string
+	}{
+		{"latin only", "hello world", "hello world"},
+		{"hebrew in latin", "abc אבג def", "abc גבא def"},
+		{"latin in hebrew", "שלום world", "world םולש"},
+		{"arabic with number", "مرحبا 123", "123 ابحرم"},
+		{"hebrew with number in latin", "abc אב 12 גד", "abc דג 12 בא"},
+		{"mirrored brackets", "אב (גד)", "(דג) בא"},
+		{"one paragraph per line", "אב\nab", "בא\nab"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := bidiVisual(tc.logical); got != tc.visual {
+				t.Fatalf("unexpected visual order: got %q want %q", got, tc.visual)
+			}
+		})
+	}
+}
+
+func TestNewBiDiDisplay_PreservesIndex(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 1)
+	in <- StringCarrier{Value: "abc אבג", Index: 5}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewBiDiDisplay[StringCarrier]().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Value != "abc גבא" || items[0].Index != 5 {
+		t.Fatalf("unexpected items: %#v", items)
+	}
+}
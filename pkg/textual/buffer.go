@@ -0,0 +1,62 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// Buffer returns a pass-through Processor whose output channel has a capacity
+// of size items, to decouple the timing of a producer and a consumer with
+// bursty latencies.
+//
+// Async and most stages return unbuffered channels, so each send waits for the
+// receiver. With a buffer, the upstream stage keeps producing while the
+// downstream one is busy, up to size items ahead. This trades memory (up to
+// size items held) for smoother throughput: it does not remove backpressure,
+// which resumes once the buffer is full. See NewMemoryGuard to bound the
+// buffer by bytes rather than by items.
+//
+// Items are forwarded unchanged and in order, and the output is closed when
+// the input is closed. When ctx is canceled, the stage stops reading its input
+// and closes the output at once: items already buffered can still be received,
+// nothing else is forwarded. If size <= 0, items are passed through.
+func Buffer[S Carrier[S]](size int) ProcessorFunc[S] {
+	if size <= 0 {
+		return passThroughProcessor[S]()
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		out := make(chan S, size)
+		go func() {
+			defer close(out)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-in:
+					if !ok {
+						return
+					}
+					select {
+					case <-ctx.Done():
+						return
+					case out <- item:
+					}
+				}
+			}
+		}()
+		return out
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBuffer_OrderAndCount(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	const n = 100
+	in := make(chan StringCarrier)
+	out := Buffer[StringCarrier](8).Apply(ctx, in)
+
+	// The producer gets ahead of a consumer that has not started yet.
+	sent := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 0; i < n; i++ {
+			in <- StringCarrier{Value: "x", Index: i}
+			if i == 7 {
+				sent <- i
+			}
+		}
+	}()
+	select {
+	case <-sent:
+	case <-ctx.Done():
+		t.Fatalf("producer blocked before filling the buffer")
+	}
+
+	items, err := collectWithContext(ctx, out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != n {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), n)
+	}
+	for i, item := range items {
+		if item.Index != i {
+			t.Fatalf("unexpected order at %d: got index %d", i, item.Index)
+		}
+	}
+}
+
+func TestBuffer_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan StringCarrier)
+	out := Buffer[StringCarrier](4).Apply(ctx, in)
+
+	in <- StringCarrier{Value: "a"}
+	cancel()
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-out:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("output not closed after cancellation")
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bytes"
+	"errors"
+)
+
+// BytesCarrier is a Carrier implementation transporting raw bytes, typically
+// text in a legacy encoding (Latin-1, Shift-JIS, ...) before it is decoded into
+// UTF-8, or fixed-width binary records (see ScanFixedWidth).
+//
+// UTF8String returns Value as is: it is only valid UTF-8 when the bytes are.
+// Use NewDecodeTranscoder to convert the bytes from their encoding.
+//
+// Aggregate concatenates the values after stably sorting them by Index.
+type BytesCarrier struct {
+	Value []byte `json:"value"`
+	Index int    `json:"index,omitempty"`
+	Error error  `json:"error,omitempty"`
+}
+
+func (s BytesCarrier) UTF8String() UTF8String {
+	return UTF8String(s.Value)
+}
+
+func (s BytesCarrier) FromUTF8String(str UTF8String) BytesCarrier {
+	return BytesCarrier{
+		Value: []byte(str),
+		Index: 0,
+	}
+}
+
+func (s BytesCarrier) WithIndex(idx int) BytesCarrier {
+	s.Index = idx
+	return s
+}
+
+func (s BytesCarrier) GetIndex() int {
+	return s.Index
+}
+
+func (s BytesCarrier) WithError(err error) BytesCarrier {
+	if err == nil {
+		return s
+	}
+	if s.Error == nil {
+		s.Error = err
+	} else {
+		s.Error = errors.Join(s.Error, err)
+	}
+	return s
+}
+
+func (s BytesCarrier) GetError() error {
+	return s.Error
+}
+
+// Aggregate concatenates the values of items after stably sorting them by Index.
+//
+// The result carries the first index and the joined per-item errors.
+func (s BytesCarrier) Aggregate(items []BytesCarrier) BytesCarrier {
+	if len(items) == 0 {
+		return BytesCarrier{}
+	}
+	sorted := sortedByIndex(items)
+	var b bytes.Buffer
+	for _, it := range sorted {
+		b.Write(it.Value)
+	}
+	res := BytesCarrier{Value: b.Bytes(), Index: sorted[0].Index}
+	return withJoinedErrors(res, sorted)
+}
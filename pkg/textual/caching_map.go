@@ -0,0 +1,110 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// NewCachingMap returns a Processor that applies f to every item (1:1) and
+// memoizes the results by key in a bounded LRU cache, so f is only called on
+// cache misses.
+//
+// It is meant for expensive, deterministic per-token transformations (e.g. an
+// API-based translation table) over repetitive streams.
+//
+//   - keyOf computes the cache key of an item. When nil, UTF8String() is used.
+//   - capacity bounds the number of cached entries; the least recently used
+//     entry is evicted first. When capacity <= 0, caching is disabled and f is
+//     called for every item.
+//
+// On a cache hit, the cached output is re-indexed with the index of the current
+// item, and the error of the current item, if any, is attached to it. Outputs carrying an error (GetError() != nil) are never cached, so a
+// transient failure is retried on the next occurrence of the key.
+//
+// The cache belongs to the returned Processor: it is shared by every Apply call
+// and is safe for concurrent use.
+func NewCachingMap[S Carrier[S]](f func(ctx context.Context, item S) S, keyOf func(S) string, capacity int) ProcessorFunc[S] {
+	if keyOf == nil {
+		keyOf = func(item S) string {
+			return item.UTF8String()
+		}
+	}
+	cache := newLRU[S](capacity)
+	return NewProcessorFunc(func(ctx context.Context, item S) S {
+		key := keyOf(item)
+		if res, ok := cache.get(key); ok {
+			return res.WithIndex(item.GetIndex()).WithError(item.GetError())
+		}
+		res := f(ctx, item)
+		if res.GetError() == nil {
+			cache.add(key, res)
+		}
+		return res
+	})
+}
+
+// lru is a minimal, mutex-protected least-recently-used cache.
+type lru[V any] struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front = most recently used
+	entries  map[string]*list.Element
+}
+
+type lruEntry[V any] struct {
+	key   string
+	value V
+}
+
+func newLRU[V any](capacity int) *lru[V] {
+	return &lru[V]{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (c *lru[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*lruEntry[V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *lru[V]) add(key string, value V) {
+	if c.capacity <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*lruEntry[V]).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{key: key, value: value})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[V]).key)
+	}
+}
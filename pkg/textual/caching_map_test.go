@@ -0,0 +1,118 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewCachingMap_CallsFOncePerDistinctKey(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	calls := map[string]int{}
+	translate := func(_ context.Context, s StringCarrier) StringCarrier {
+		calls[s.Value]++
+		s.Value = strings.ToUpper(s.Value)
+		return s
+	}
+
+	words := []string{"le", "chat", "le", "chien", "chat", "le"}
+	in := make(chan StringCarrier, len(words))
+	for i, w := range words {
+		in <- StringCarrier{Value: w, Index: i}
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewCachingMap[StringCarrier](translate, nil, 8).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != len(words) {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), len(words))
+	}
+	for i, it := range items {
+		if it.Value != strings.ToUpper(words[i]) || it.Index != i {
+			t.Fatalf("unexpected item %d: %#v", i, it)
+		}
+	}
+	for k, n := range calls {
+		if n != 1 {
+			t.Fatalf("expected f to be called once for %q, got %d", k, n)
+		}
+	}
+	if len(calls) != 3 {
+		t.Fatalf("unexpected distinct calls: %v", calls)
+	}
+}
+
+func TestNewCachingMap_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	calls := 0
+	f := func(_ context.Context, s StringCarrier) StringCarrier {
+		calls++
+		return s
+	}
+
+	// Capacity 1: "a" is evicted by "b", so the second "a" is a miss.
+	words := []string{"a", "b", "a"}
+	in := make(chan StringCarrier, len(words))
+	for i, w := range words {
+		in <- StringCarrier{Value: w, Index: i}
+	}
+	close(in)
+
+	if _, err := collectWithContext(ctx, NewCachingMap[StringCarrier](f, nil, 1).Apply(ctx, in)); err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("unexpected call count: got %d want %d", calls, 3)
+	}
+}
+
+func TestNewCachingMap_HitKeepsInputError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	upper := func(_ context.Context, s StringCarrier) StringCarrier {
+		s.Value = strings.ToUpper(s.Value)
+		return s
+	}
+	boom := errors.New("boom")
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "le", Index: 0}
+	in <- StringCarrier{Value: "le", Index: 1}.WithError(boom)
+	close(in)
+
+	items, err := collectWithContext(ctx, NewCachingMap[StringCarrier](upper, nil, 8).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 2)
+	}
+	if items[0].GetError() != nil {
+		t.Fatalf("unexpected error on the miss: %v", items[0].GetError())
+	}
+	if items[1].Value != "LE" || !errors.Is(items[1].GetError(), boom) {
+		t.Fatalf("expected the cached output with the input error, got %#v", items[1])
+	}
+}
@@ -138,6 +138,54 @@ func CastCsvRecord(c CsvCarrier) ([]string, error) {
 	return rec, nil
 }
 
+// ErrCsvFieldCount is returned by CastCsvMap when a record and its header do
+// not have the same number of fields.
+var ErrCsvFieldCount = errors.New("csv record and header field counts differ")
+
+// CastCsvMap parses a CsvCarrier.Value (see CastCsvRecord) and zips the record
+// with header, giving named-field access.
+//
+// If header is nil, the header carried by the carrier (CsvCarrier.WithHeader)
+// is used. A field count mismatch returns ErrCsvFieldCount; use
+// CastCsvMapLenient to accept it.
+//
+// If the carrier carries an upstream error, it is returned and parsing is skipped.
+func CastCsvMap(c CsvCarrier, header []string) (map[string]string, error) {
+	return castCsvMap(c, header, false)
+}
+
+// CastCsvMapLenient is like CastCsvMap, but tolerates field count mismatches:
+// missing fields map to "" and extra fields are ignored.
+func CastCsvMapLenient(c CsvCarrier, header []string) (map[string]string, error) {
+	return castCsvMap(c, header, true)
+}
+
+func castCsvMap(c CsvCarrier, header []string, lenient bool) (map[string]string, error) {
+	if header == nil {
+		header = c.Header
+	}
+	rec, err := CastCsvRecord(c)
+	if err != nil {
+		return nil, err
+	}
+	if !lenient && len(rec) != len(header) {
+		err = fmt.Errorf("%w: %d fields for %d columns", ErrCsvFieldCount, len(rec), len(header))
+		if c.Index != 0 {
+			return nil, fmt.Errorf("csv carrier (index %d): %w", c.Index, err)
+		}
+		return nil, err
+	}
+	m := make(map[string]string, len(header))
+	for i, name := range header {
+		if i < len(rec) {
+			m[name] = rec[i]
+		} else {
+			m[name] = ""
+		}
+	}
+	return m, nil
+}
+
 // CastXml attempts to convert an XmlCarrier's Value into a specified type T.
 // Returns the cast value or an error if unmarshaling fails.
 //
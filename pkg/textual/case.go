@@ -0,0 +1,109 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CaseTail tells PreserveCaseTail what to do with the runes of transformed
+// beyond the length of original.
+type CaseTail int
+
+const (
+	CaseTailKeep  CaseTail = iota // Leave the extra runes untouched.
+	CaseTailLower                 // Lowercase the extra runes.
+)
+
+// PreserveCase reapplies the casing pattern of original onto transformed, rune
+// by rune: the rune at position i of the result is uppercased when the rune at
+// position i of original is uppercase, lowercased when it is lowercase, and
+// left as is otherwise (digits, punctuation, letters without case).
+//
+// It is meant for transformations that move or replace letters, such as a
+// reversal:
+//
+//	PreserveCase("Ciel,", "leiC,") // "Leic,"
+//
+// When transformed is longer than original, the extra runes are left
+// untouched; see PreserveCaseTail to lowercase them instead.
+func PreserveCase(original, transformed UTF8String) UTF8String {
+	return PreserveCaseTail(original, transformed, CaseTailKeep)
+}
+
+// PreserveCaseTail is like PreserveCase, with tail deciding the casing of the
+// runes of transformed beyond the length of original.
+func PreserveCaseTail(original, transformed UTF8String, tail CaseTail) UTF8String {
+	pattern := []rune(original)
+	var b strings.Builder
+	b.Grow(len(transformed))
+	i := 0
+	for _, r := range transformed {
+		switch {
+		case i < len(pattern) && unicode.IsUpper(pattern[i]):
+			r = unicode.ToUpper(r)
+		case i < len(pattern) && unicode.IsLower(pattern[i]):
+			r = unicode.ToLower(r)
+		case i >= len(pattern) && tail == CaseTailLower:
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+		i++
+	}
+	return b.String()
+}
+
+// Transliterate returns a Processor mapping every rune found in table to its
+// replacement (which may be empty or hold several runes); other runes are kept.
+//
+// When preserveCase is true, a rune missing from table is also looked up in
+// lowercase, and the replacement takes its case: capitalized for an uppercase
+// rune ("Ж" -> "Zh" with 'ж' -> "zh"), fully uppercased inside an uppercase run
+// ("ЖУК" -> "ZHUK"). Exact table entries always win and are used as is.
+//
+// Index and error are preserved.
+func Transliterate[S Carrier[S]](table map[rune]string, preserveCase bool) ProcessorFunc[S] {
+	return Map(func(item S) S {
+		return item.FromUTF8String(transliterate(item.UTF8String(), table, preserveCase))
+	})
+}
+
+// transliterate implements Transliterate on one text.
+func transliterate(text UTF8String, table map[rune]string, preserveCase bool) UTF8String {
+	runes := []rune(text)
+	var b strings.Builder
+	b.Grow(len(text))
+	for i, r := range runes {
+		if repl, ok := table[r]; ok {
+			b.WriteString(repl)
+			continue
+		}
+		lower := unicode.ToLower(r)
+		repl, ok := table[lower]
+		if !preserveCase || lower == r || !ok {
+			b.WriteRune(r)
+			continue
+		}
+		upperRun := i+1 < len(runes) && unicode.IsUpper(runes[i+1]) ||
+			i > 0 && unicode.IsUpper(runes[i-1])
+		if upperRun {
+			b.WriteString(strings.ToUpper(repl))
+		} else {
+			b.WriteString(PreserveCase(string(r), repl))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPreserveCase(t *testing.T) {
+	cases := []struct {
+		original, transformed, want string
+	}{
+		{"Ciel,", "leiC,", "Leic,"},
+		{"Bonjour", "ruojnoB", "Ruojnob"},
+		{"WORLD!", "dlrow!", "DLROW!"},
+		{"a1B", "x2y", "x2Y"},
+		{"Ab", "xyzW", "XyzW"}, // Longer transformed: the tail is kept.
+		{"ÉTÉ", "été", "ÉTÉ"},
+		{"Abc", "x", "X"},
+	}
+	for _, c := range cases {
+		if got := PreserveCase(c.original, c.transformed); got != c.want {
+			t.Fatalf("unexpected PreserveCase(%q, %q): got %q want %q", c.original, c.transformed, got, c.want)
+		}
+	}
+	if got, want := PreserveCaseTail("Ab", "xyzW", CaseTailLower), "Xyzw"; got != want {
+		t.Fatalf("unexpected PreserveCaseTail: got %q want %q", got, want)
+	}
+}
+
+func TestTransliterate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	table := map[rune]string{'ж': "zh", 'у': "u", 'к': "k", 'Ц': "TS", 'ß': "ss"}
+	inputs := []string{"Жук", "ЖУК", "жук", "Ц", "Straße"}
+
+	for _, preserve := range []bool{true, false} {
+		in := make(chan StringCarrier, len(inputs))
+		for i, v := range inputs {
+			in <- StringCarrier{Value: v, Index: i}
+		}
+		close(in)
+
+		items, err := collectWithContext(ctx, Transliterate[StringCarrier](table, preserve).Apply(ctx, in))
+		if err != nil {
+			t.Fatalf("collect failed: %v", err)
+		}
+		want := []string{"Zhuk", "ZHUK", "zhuk", "TS", "Strasse"}
+		if !preserve {
+			want = []string{"Жuk", "ЖУК", "zhuk", "TS", "Strasse"}
+		}
+		for i, item := range items {
+			if item.Value != want[i] || item.Index != i {
+				t.Fatalf("unexpected item %d (preserveCase %v): got %q (index %d) want %q", i, preserve, item.Value, item.Index, want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// NewCheckpoint returns a Processor that makes batch jobs resumable: items
+// whose key is recorded in the checkpoint file at path are discarded, and the
+// key of every other item is appended to the file once the item has been
+// delivered downstream. A restarted job therefore skips the items a previous
+// run already completed.
+//
+// The file holds one SHA-256 hex digest of a key per line, so keys may contain
+// any byte. It is loaded when NewCheckpoint is called (a missing file is an
+// empty checkpoint). Recovery from a crash mid-write is automatic: malformed
+// lines, such as a truncated last line, are ignored, and a newline is written
+// before appending to a file that does not end with one. NewCheckpoint only
+// returns an error when the file exists but cannot be read.
+//
+// Items carrying an error are forwarded but not recorded, so they are retried
+// on the next run. Keys recorded during the run are skipped too. Appends are
+// serialized and written with O_APPEND, so several Apply calls may share the
+// processor; writes are not fsynced. When the file cannot be opened for
+// appending, items are forwarded with the error attached and nothing is
+// recorded.
+//
+// A panic in keyOf is recorded into the PanicStore and stops the stream, like
+// Async.
+//
+// Place the stage where an item counts as done, typically at the end of the
+// pipeline.
+func NewCheckpoint[S Carrier[S]](path string, keyOf func(S) string) (ProcessorFunc[S], error) {
+	cp := &checkpoint{path: path, done: make(map[string]struct{})}
+	if err := cp.load(); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		var w *os.File
+		var openErr error
+		opened := false
+		return AsyncEmitter(ctx, in, func(ctx context.Context, item S, emit func(S)) {
+			digest := checkpointDigest(keyOf(item))
+			if cp.has(digest) {
+				return
+			}
+			if !opened {
+				opened = true
+				w, openErr = cp.open()
+				if w != nil {
+					// ctx is canceled when the stage stops, whatever the reason.
+					go func(w *os.File) {
+						<-ctx.Done()
+						cp.close(w)
+					}(w)
+				}
+			}
+			if openErr != nil {
+				item = item.WithError(openErr)
+			}
+			emit(item)
+			if ctx.Err() != nil {
+				// Not delivered: the stage is stopping.
+				return
+			}
+			if openErr == nil && item.GetError() == nil {
+				if err := cp.record(w, digest); err != nil {
+					// The item is already delivered; the next run will redo it.
+					openErr = err
+				}
+			}
+		})
+	}, nil
+}
+
+// checkpoint is the state shared by the Apply calls of a NewCheckpoint stage.
+type checkpoint struct {
+	path string
+	mu   sync.Mutex
+	done map[string]struct{} // digests of the completed keys
+}
+
+// checkpointDigest returns the line recorded for key.
+func checkpointDigest(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// load reads the digests recorded at cp.path, ignoring malformed lines.
+func (cp *checkpoint) load() error {
+	f, err := os.Open(cp.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("textual: checkpoint: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if _, err := hex.DecodeString(line); err != nil || len(line) != 2*sha256.Size {
+			continue
+		}
+		cp.done[line] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("textual: checkpoint: %w", err)
+	}
+	return nil
+}
+
+// open opens cp.path for appending, creating it if needed, and makes sure that
+// the next line starts on a fresh line.
+func (cp *checkpoint) open() (*os.File, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	f, err := os.OpenFile(cp.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("textual: checkpoint: %w", err)
+	}
+	info, err := f.Stat()
+	if err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err = f.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			_, err = f.Write([]byte("\n"))
+		}
+	}
+	if err != nil && err != io.EOF {
+		f.Close()
+		return nil, fmt.Errorf("textual: checkpoint: %w", err)
+	}
+	return f, nil
+}
+
+// close closes w, once no append is in progress.
+func (cp *checkpoint) close(w *os.File) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	ignoreErr(w.Close())
+}
+
+// has reports whether digest is recorded.
+func (cp *checkpoint) has(digest string) bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	_, ok := cp.done[digest]
+	return ok
+}
+
+// record appends digest to w and to the in-memory set.
+func (cp *checkpoint) record(w io.Writer, digest string) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if _, ok := cp.done[digest]; ok {
+		return nil
+	}
+	if _, err := io.WriteString(w, digest+"\n"); err != nil {
+		return fmt.Errorf("textual: checkpoint: %w", err)
+	}
+	cp.done[digest] = struct{}{}
+	return nil
+}
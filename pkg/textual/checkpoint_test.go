@@ -0,0 +1,133 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func checkpointRun(t *testing.T, path string, values ...string) []string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	p, err := NewCheckpoint(path, func(s StringCarrier) string { return s.Value })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	in := make(chan StringCarrier, len(values))
+	for i, v := range values {
+		item := StringCarrier{Value: v, Index: i}
+		if strings.HasPrefix(v, "err:") {
+			item = item.WithError(errors.New(v))
+		}
+		in <- item
+	}
+	close(in)
+	out, err := collectWithContext(ctx, p.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make([]string, len(out))
+	for i, item := range out {
+		got[i] = item.Value
+	}
+	return got
+}
+
+func TestNewCheckpoint_RestartSkipsCompletedItems(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "job.checkpoint")
+
+	// First run stops after three items (and fails one of them).
+	if got := checkpointRun(t, path, "a", "err:b", "c"); strings.Join(got, ",") != "a,err:b,c" {
+		t.Fatalf("unexpected first run: got %q", got)
+	}
+
+	// Restart with the full input: only the failed and remaining items run.
+	got := checkpointRun(t, path, "a", "err:b", "c", "d", "d", "e")
+	if strings.Join(got, ",") != "err:b,d,e" {
+		t.Fatalf("unexpected restart: got %q want %q", got, "err:b,d,e")
+	}
+	if got := checkpointRun(t, path, "a", "c", "d", "e"); len(got) != 0 {
+		t.Fatalf("unexpected third run: got %q", got)
+	}
+}
+
+func TestNewCheckpoint_RecoversCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "job.checkpoint")
+	checkpointRun(t, path, "a")
+
+	// Simulate a crash in the middle of a write.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.WriteString("not a digest\n3f2a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f.Close()
+
+	if got := checkpointRun(t, path, "a", "b"); strings.Join(got, ",") != "b" {
+		t.Fatalf("unexpected run after corruption: got %q want %q", got, "b")
+	}
+	if got := checkpointRun(t, path, "a", "b", "c"); strings.Join(got, ",") != "c" {
+		t.Fatalf("unexpected run after recovery: got %q want %q", got, "c")
+	}
+}
+
+func TestNewCheckpoint_UnreadableFile(t *testing.T) {
+	// A directory cannot be read as a checkpoint file.
+	if _, err := NewCheckpoint(t.TempDir(), func(s StringCarrier) string { return s.Value }); err == nil {
+		t.Fatalf("unexpected nil error for a directory path")
+	}
+}
+
+func TestNewCheckpoint_KeyPanicIsRecorded(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ctx, ps := WithPanicStore(ctx)
+
+	cp, err := NewCheckpoint[StringCarrier](filepath.Join(t.TempDir(), "done"), func(s StringCarrier) string {
+		if s.Value == "boom" {
+			panic("bad key")
+		}
+		return s.Value
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "a", Index: 0}
+	in <- StringCarrier{Value: "boom", Index: 1}
+	close(in)
+
+	items, err := collectWithContext(ctx, cp.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Value != "a" {
+		t.Fatalf("unexpected output: got %+v", items)
+	}
+	if info, ok := ps.Load(); !ok || info.Value != "bad key" {
+		t.Fatalf("expected the keyOf panic in the PanicStore, got %+v (ok %v)", info, ok)
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is attached by CircuitBreaker to the items it fast-fails while
+// the circuit is open.
+var ErrCircuitOpen = errors.New("textual: circuit open")
+
+// BreakerOptions configures CircuitBreaker. Zero fields take their default.
+type BreakerOptions struct {
+	// Window is the number of most recent outcomes of inner considered to
+	// decide whether to trip (default 20). The breaker only trips once the
+	// window is full.
+	Window int
+	// FailureRatio trips the breaker when the share of failed outcomes in the
+	// window reaches it (default 0.5).
+	FailureRatio float64
+	// Cooldown is how long the circuit stays open before a probe is let
+	// through (default 5s).
+	Cooldown time.Duration
+	// Clock provides the current time (default time.Now).
+	Clock func() time.Time
+}
+
+// withDefaults returns o with the zero fields replaced by their default.
+func (o BreakerOptions) withDefaults() BreakerOptions {
+	if o.Window <= 0 {
+		o.Window = 20
+	}
+	if o.FailureRatio <= 0 {
+		o.FailureRatio = 0.5
+	}
+	if o.Cooldown <= 0 {
+		o.Cooldown = 5 * time.Second
+	}
+	if o.Clock == nil {
+		o.Clock = time.Now
+	}
+	return o
+}
+
+// CircuitBreaker returns a Processor protecting a failing downstream: it runs
+// items through inner, and stops calling inner while it fails consistently,
+// instead of letting every item time out.
+//
+// An output of inner carrying an error (GetError) counts as a failure. While
+// the circuit is closed, items go through inner. When at least
+// opts.FailureRatio of the last opts.Window outcomes failed, the circuit opens:
+// for opts.Cooldown, items bypass inner and are forwarded with an
+// ErrCircuitOpen error. After the cooldown, the circuit half-opens and the next
+// item goes through inner as a probe (the following ones keep being
+// fast-failed until its outcome is known): a success closes the circuit, a
+// failure opens it for another cooldown.
+//
+// While half-open, only the output of the probe (matched by index) decides the
+// state; late outputs of items dispatched before the trip are ignored. If the
+// probe produces no output within another cooldown, the next item becomes the
+// new probe, so a dropped probe never leaves the circuit half-open for good.
+//
+// The breaker assumes inner emits at most one output per input, like a Map,
+// and input indices are expected to be unique. Errors already carried by items
+// entering inner count as failures too, so filter them out upstream when they
+// are not inner's doing. A nil inner passes items through.
+func CircuitBreaker[S Carrier[S]](inner Processor[S], opts BreakerOptions) ProcessorFunc[S] {
+	opts = opts.withDefaults()
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		ctx, ps := EnsurePanicStore(ctx)
+		ctx, cancel := context.WithCancel(ctx)
+
+		b := &circuitBreaker{opts: opts, outcomes: make([]bool, opts.Window)}
+		innerIn := make(chan S)
+		innerOut, ok := safeApplyProcessor(ctx, ps, inner, innerIn)
+		if !ok {
+			// inner panicked or violated the contract: abort promptly.
+			cancel()
+		}
+
+		out := make(chan S)
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		// Dispatch: route each item to inner or fast-fail it.
+		go func() {
+			defer wg.Done()
+			defer safeCloseChan(ps, innerIn)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-in:
+					if !ok {
+						return
+					}
+					if b.allow(item.GetIndex()) {
+						select {
+						case <-ctx.Done():
+							return
+						case innerIn <- item:
+						}
+						continue
+					}
+					item = item.WithError(fmt.Errorf("%w (index %d)", ErrCircuitOpen, item.GetIndex()))
+					select {
+					case <-ctx.Done():
+						return
+					case out <- item:
+					}
+				}
+			}
+		}()
+
+		// Observe: record the outcome of inner, then forward its outputs.
+		go func() {
+			defer wg.Done()
+			for item := range innerOut {
+				b.record(item.GetIndex(), item.GetError() != nil)
+				select {
+				case out <- item:
+				case <-ctx.Done():
+					// Drain so that inner is never blocked on send.
+					for range innerOut {
+					}
+					return
+				}
+			}
+		}()
+
+		go func() {
+			wg.Wait()
+			close(out)
+			cancel()
+		}()
+		return out
+	}
+}
+
+// circuitState is the state of a circuitBreaker.
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker is the state machine of CircuitBreaker. It is shared by the
+// dispatch and observe goroutines.
+type circuitBreaker struct {
+	opts BreakerOptions
+
+	mu       sync.Mutex
+	state    circuitState
+	openedAt time.Time
+	probe    int       // index of the probe, while half-open
+	probeAt  time.Time // when the probe was dispatched
+	outcomes []bool    // ring buffer of the last outcomes (true = failure)
+	next     int       // next slot of outcomes
+	filled   int       // number of outcomes recorded, up to len(outcomes)
+	failures int       // failures among the recorded outcomes
+}
+
+// allow reports whether the item with the given index may go through inner.
+func (b *circuitBreaker) allow(index int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.opts.Clock()
+	switch b.state {
+	case circuitClosed:
+		return true
+	case circuitOpen:
+		if now.Sub(b.openedAt) < b.opts.Cooldown {
+			return false
+		}
+		b.state = circuitHalfOpen
+	case circuitHalfOpen:
+		if now.Sub(b.probeAt) < b.opts.Cooldown {
+			return false
+		}
+		// The probe produced no output in time: re-arm it.
+	}
+	b.probe, b.probeAt = index, now
+	return true
+}
+
+// record updates the state with the outcome of the output of inner with the
+// given index.
+func (b *circuitBreaker) record(index int, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case circuitHalfOpen:
+		if index != b.probe {
+			// A late output of an item dispatched before the trip.
+			return
+		}
+		if failed {
+			b.trip()
+		} else {
+			b.state = circuitClosed
+		}
+	case circuitClosed:
+		if b.filled == len(b.outcomes) && b.outcomes[b.next] {
+			b.failures--
+		}
+		b.outcomes[b.next] = failed
+		b.next = (b.next + 1) % len(b.outcomes)
+		if b.filled < len(b.outcomes) {
+			b.filled++
+		}
+		if failed {
+			b.failures++
+		}
+		if b.filled == len(b.outcomes) && float64(b.failures) >= b.opts.FailureRatio*float64(b.filled) {
+			b.trip()
+		}
+	}
+	// While open, late outputs of items dispatched before tripping are ignored.
+}
+
+// trip opens the circuit and resets the window. b.mu must be held.
+func (b *circuitBreaker) trip() {
+	b.state = circuitOpen
+	b.openedAt = b.opts.Clock()
+	b.next, b.filled, b.failures = 0, 0, 0
+	clear(b.outcomes)
+}
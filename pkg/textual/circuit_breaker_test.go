@@ -0,0 +1,209 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_TripsThenRecovers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	errDown := errors.New("downstream unavailable")
+	var down atomic.Bool
+	var calls atomic.Int64
+	inner := MapErr(func(_ context.Context, item StringCarrier) (StringCarrier, error) {
+		calls.Add(1)
+		if down.Load() {
+			return item, errDown
+		}
+		return item, nil
+	})
+
+	var now atomic.Int64
+	clock := func() time.Time { return time.Unix(0, now.Load()) }
+
+	in := make(chan StringCarrier)
+	out := CircuitBreaker[StringCarrier](inner, BreakerOptions{
+		Window:       4,
+		FailureRatio: 0.5,
+		Cooldown:     time.Minute,
+		Clock:        clock,
+	}).Apply(ctx, in)
+
+	index := 0
+	// send feeds one item and waits for its output, so that the breaker has
+	// recorded the outcome before the next item is dispatched.
+	send := func() StringCarrier {
+		t.Helper()
+		select {
+		case in <- StringCarrier{Value: "x", Index: index}:
+		case <-ctx.Done():
+			t.Fatalf("timeout sending item %d", index)
+		}
+		index++
+		select {
+		case item := <-out:
+			return item
+		case <-ctx.Done():
+			t.Fatalf("timeout receiving item %d", index-1)
+		}
+		return StringCarrier{}
+	}
+
+	// Four failures fill the window and trip the breaker.
+	down.Store(true)
+	for i := 0; i < 4; i++ {
+		if item := send(); !errors.Is(item.Error, errDown) {
+			t.Fatalf("unexpected error for item %d: got %v want %v", i, item.Error, errDown)
+		}
+	}
+
+	// Open: inner is bypassed.
+	for i := 0; i < 3; i++ {
+		if item := send(); !errors.Is(item.Error, ErrCircuitOpen) {
+			t.Fatalf("unexpected error while open: got %v want %v", item.Error, ErrCircuitOpen)
+		}
+	}
+	if got := calls.Load(); got != 4 {
+		t.Fatalf("unexpected inner calls while open: got %d want %d", got, 4)
+	}
+
+	// A failed probe after the cooldown reopens the circuit.
+	now.Add(int64(time.Minute))
+	if item := send(); !errors.Is(item.Error, errDown) {
+		t.Fatalf("unexpected probe error: got %v want %v", item.Error, errDown)
+	}
+	if item := send(); !errors.Is(item.Error, ErrCircuitOpen) {
+		t.Fatalf("unexpected error after failed probe: got %v want %v", item.Error, ErrCircuitOpen)
+	}
+
+	// The downstream recovers: a successful probe closes the circuit.
+	down.Store(false)
+	now.Add(int64(time.Minute))
+	for i := 0; i < 3; i++ {
+		if item := send(); item.Error != nil {
+			t.Fatalf("unexpected error after recovery: %v", item.Error)
+		}
+	}
+	if got := calls.Load(); got != 8 {
+		t.Fatalf("unexpected inner calls: got %d want %d", got, 8)
+	}
+
+	close(in)
+	if rest, err := collectWithContext(ctx, out); err != nil || len(rest) != 0 {
+		t.Fatalf("unexpected tail: got %d items, err %v", len(rest), err)
+	}
+}
+
+func TestCircuitBreaker_DroppedProbeIsRearmed(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	errDown := errors.New("downstream unavailable")
+	// inner fails the first two items, drops the third (the first probe),
+	// and succeeds afterwards.
+	var calls atomic.Int64
+	inner := ProcessorFunc[StringCarrier](func(ctx context.Context, in <-chan StringCarrier) <-chan StringCarrier {
+		return AsyncEmitter(ctx, in, func(_ context.Context, item StringCarrier, emit func(StringCarrier)) {
+			switch calls.Add(1) {
+			case 1, 2:
+				emit(item.WithError(errDown))
+			case 3:
+			default:
+				emit(item)
+			}
+		})
+	})
+
+	var now atomic.Int64
+	clock := func() time.Time { return time.Unix(0, now.Load()) }
+
+	in := make(chan StringCarrier)
+	out := CircuitBreaker[StringCarrier](inner, BreakerOptions{
+		Window:   2,
+		Cooldown: time.Minute,
+		Clock:    clock,
+	}).Apply(ctx, in)
+
+	send := func(index int) {
+		t.Helper()
+		select {
+		case in <- StringCarrier{Value: "x", Index: index}:
+		case <-ctx.Done():
+			t.Fatalf("timeout sending item %d", index)
+		}
+	}
+	receive := func() StringCarrier {
+		t.Helper()
+		select {
+		case item := <-out:
+			return item
+		case <-ctx.Done():
+			t.Fatalf("timeout receiving")
+		}
+		return StringCarrier{}
+	}
+
+	// Two failures trip the breaker.
+	for i := 0; i < 2; i++ {
+		send(i)
+		if item := receive(); !errors.Is(item.Error, errDown) {
+			t.Fatalf("unexpected error for item %d: got %v want %v", i, item.Error, errDown)
+		}
+	}
+
+	// The first probe is dropped by inner: the next item is fast-failed.
+	now.Add(int64(time.Minute))
+	send(2)
+	send(3)
+	if item := receive(); item.Index != 3 || !errors.Is(item.Error, ErrCircuitOpen) {
+		t.Fatalf("expected item 3 to be fast-failed, got %+v", item)
+	}
+
+	// After another cooldown, a new probe goes through and closes the circuit.
+	now.Add(int64(time.Minute))
+	for i := 4; i < 6; i++ {
+		send(i)
+		if item := receive(); item.Index != i || item.Error != nil {
+			t.Fatalf("unexpected item after the new probe: %+v", item)
+		}
+	}
+}
+
+func TestCircuitBreaker_IgnoresLateOutputsWhileHalfOpen(t *testing.T) {
+	b := &circuitBreaker{opts: BreakerOptions{Window: 1, Cooldown: time.Minute}.withDefaults(), outcomes: make([]bool, 1)}
+	var now time.Time
+	b.opts.Clock = func() time.Time { return now }
+
+	b.record(0, true) // trips
+	now = now.Add(time.Minute)
+	if !b.allow(7) {
+		t.Fatalf("expected the probe to be allowed after the cooldown")
+	}
+	b.record(5, false) // late output of an item dispatched before the trip
+	if b.state != circuitHalfOpen {
+		t.Fatalf("a late output must not decide the probe outcome")
+	}
+	b.record(7, false)
+	if b.state != circuitClosed {
+		t.Fatalf("expected the probe success to close the circuit")
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"strings"
+)
+
+// Content types reported by DetectContentType.
+const (
+	ContentTypeJSON = "application/json"
+	ContentTypeXML  = "application/xml"
+	ContentTypeText = "text/plain"
+)
+
+// DetectContentType sniffs the content type of a piece of text:
+//
+//   - ContentTypeJSON for a valid JSON object or array,
+//   - ContentTypeXML for well-formed XML starting with a tag, a prolog or a
+//     comment,
+//   - ContentTypeText otherwise.
+//
+// Leading and trailing whitespace is ignored. Detection looks at the text only,
+// never at the carrier type, so a StringCarrier holding JSON is reported as
+// JSON.
+func DetectContentType(text UTF8String) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return ContentTypeText
+	}
+	switch trimmed[0] {
+	case '{', '[':
+		if json.Valid([]byte(trimmed)) {
+			return ContentTypeJSON
+		}
+	case '<':
+		if isWellFormedXML(trimmed) {
+			return ContentTypeXML
+		}
+	}
+	return ContentTypeText
+}
+
+// isWellFormedXML reports whether s decodes as XML with at least one element.
+func isWellFormedXML(s string) bool {
+	d := xml.NewDecoder(strings.NewReader(s))
+	elements := 0
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			return elements > 0
+		}
+		if err != nil {
+			return false
+		}
+		if _, ok := tok.(xml.StartElement); ok {
+			elements++
+		}
+	}
+}
+
+// sameContentType compares two content types, ignoring case and parameters
+// (e.g. "; charset=utf-8").
+func sameContentType(a, b string) bool {
+	return mediaType(a) == mediaType(b)
+}
+
+func mediaType(ct string) string {
+	if mt, _, err := mime.ParseMediaType(ct); err == nil {
+		return mt
+	}
+	return strings.ToLower(strings.TrimSpace(ct))
+}
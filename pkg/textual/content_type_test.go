@@ -0,0 +1,37 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"testing"
+)
+
+func TestDetectContentType(t *testing.T) {
+	cases := map[string]string{
+		` {"a":1} `:                 ContentTypeJSON,
+		`[1,2]`:                     ContentTypeJSON,
+		`{"a":`:                     ContentTypeText,
+		`<?xml version="1.0"?><a/>`: ContentTypeXML,
+		`<a><b>x</b></a>`:           ContentTypeXML,
+		`<a><b>x</a>`:               ContentTypeText,
+		`hello`:                     ContentTypeText,
+		``:                          ContentTypeText,
+	}
+	for in, want := range cases {
+		if got := DetectContentType(in); got != want {
+			t.Fatalf("unexpected content type for %q: got %q want %q", in, got, want)
+		}
+	}
+}
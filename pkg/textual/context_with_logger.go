@@ -0,0 +1,70 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// Logger is the minimal logging interface used by the pipeline infrastructure
+// (Async, AsyncEmitter, Router, IOReaderProcessor, IOReaderTranscoder) to report
+// what happens inside its goroutines: item counts, cancellation, recovered
+// panics.
+//
+// It is deliberately small so that any logging library can be adapted with a
+// few lines (slog, log, zap, a test recorder, ...).
+//
+// Implementations must be safe for concurrent use: stages run in their own
+// goroutines and share the logger carried by the pipeline context.
+type Logger interface {
+	Debugf(format string, args ...any)
+	Warnf(format string, args ...any)
+}
+
+// noopLogger is the Logger used when the context carries none.
+type noopLogger struct{}
+
+func (noopLogger) Debugf(string, ...any) {}
+func (noopLogger) Warnf(string, ...any)  {}
+
+type loggerKey struct{}
+
+// WithLogger returns a derived context carrying l.
+//
+// Like WithPanicStore, the logger travels with the pipeline context: every
+// stage receiving the context (or a context derived from it) logs through l.
+//
+// WithLogger never returns a nil context. If parent is nil, it falls back to
+// context.Background(). A nil l removes logging for the derived context.
+func WithLogger(parent context.Context, l Logger) context.Context {
+	if parent == nil {
+		parent = context.Background()
+	}
+	return context.WithValue(parent, loggerKey{}, l)
+}
+
+// LoggerFromContext retrieves the Logger carried by ctx.
+//
+// It never returns nil: when ctx is nil or carries no logger, a no-op Logger is
+// returned, so callers can log unconditionally.
+func LoggerFromContext(ctx context.Context) Logger {
+	if ctx == nil {
+		return noopLogger{}
+	}
+	if l, _ := ctx.Value(loggerKey{}).(Logger); l != nil {
+		return l
+	}
+	return noopLogger{}
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingLogger is a concurrency-safe Logger that keeps every message.
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) Debugf(format string, args ...any) {
+	l.record("DEBUG " + fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) Warnf(format string, args ...any) {
+	l.record("WARN " + fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) record(line string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, line)
+}
+
+// contains reports whether a recorded line contains substr.
+func (l *recordingLogger) contains(substr string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, line := range l.lines {
+		if strings.Contains(line, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLoggerFromContext_DefaultsToNoop(t *testing.T) {
+	if LoggerFromContext(nil) == nil {
+		t.Fatalf("unexpected nil logger for a nil context")
+	}
+	if LoggerFromContext(context.Background()) == nil {
+		t.Fatalf("unexpected nil logger for a context without logger")
+	}
+	if LoggerFromContext(WithLogger(context.Background(), nil)) == nil {
+		t.Fatalf("unexpected nil logger after WithLogger(nil)")
+	}
+
+	l := &recordingLogger{}
+	if got := LoggerFromContext(WithLogger(nil, l)); got != Logger(l) {
+		t.Fatalf("unexpected logger: got %T", got)
+	}
+}
+
+func TestAsync_LogsItemCountAndPanic(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	l := &recordingLogger{}
+	ctx = WithLogger(ctx, l)
+
+	in := make(chan StringCarrier)
+	go func() {
+		defer close(in)
+		for i, s := range []string{"a", "b", "c"} {
+			in <- StringCarrier{Value: s, Index: i}
+		}
+	}()
+	if _, err := collectWithContext(ctx, Async(ctx, in, func(ctx context.Context, s StringCarrier) StringCarrier { return s })); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !l.contains("DEBUG textual: Async input closed after 3 item(s)") {
+		t.Fatalf("unexpected log lines: %q", l.lines)
+	}
+
+	in2 := make(chan StringCarrier, 1)
+	in2 <- StringCarrier{Value: "boom"}
+	close(in2)
+	if _, err := collectWithContext(ctx, Async(ctx, in2, func(ctx context.Context, s StringCarrier) StringCarrier { panic("boom") })); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !l.contains("WARN textual: Async recovered a panic after 0 item(s): boom") {
+		t.Fatalf("unexpected log lines: %q", l.lines)
+	}
+}
+
+func TestIOReaderProcessor_LogsEndOfInput(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	l := &recordingLogger{}
+	p := NewIOReaderProcessor[StringCarrier](passThroughProcessor[StringCarrier](), strings.NewReader("a\nb\n"))
+	p.SetContext(WithLogger(ctx, l))
+
+	if _, err := collectWithContext(ctx, p.Start()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The scanning goroutine may log right after the output is closed.
+	deadline := time.Now().Add(time.Second)
+	for !l.contains("IOReaderProcessor reached end of input after 2 token(s)") {
+		if time.Now().After(deadline) {
+			t.Fatalf("unexpected log lines: %q", l.lines)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRouter_LogsCancellation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	l := &recordingLogger{}
+	stageCtx, stop := context.WithCancel(WithLogger(ctx, l))
+
+	in := make(chan StringCarrier)
+	r := NewRouter[StringCarrier](RoutingStrategyFirstMatch, passThroughProcessor[StringCarrier]())
+	out := r.Apply(stageCtx, in)
+
+	in <- StringCarrier{Value: "a"}
+	<-out
+	stop()
+	if _, err := collectWithContext(ctx, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !l.contains("DEBUG textual: Router canceled after 1 item(s) over 1 route(s)") {
+		t.Fatalf("unexpected log lines: %q", l.lines)
+	}
+}
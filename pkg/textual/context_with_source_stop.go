@@ -0,0 +1,59 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// A pipeline source (e.g. IOReaderProcessor) feeds the first stage and closes
+// its channel at end of input. Stages downstream cannot reach it: canceling
+// their own context only stops themselves, and canceling the pipeline context
+// would also drop the items that are still flowing downstream.
+//
+// The "source stop" is a context-carried hook that lets a stage ask the source
+// to stop reading *gracefully*: the source stops producing and closes its
+// channel, so every stage finishes its in-flight work and the pipeline drains
+// normally. IOReaderProcessor and IOReaderTranscoder attach such a hook to the
+// context they pass to their processor / transcoder.
+type sourceStopKey struct{}
+
+// WithSourceStop returns a derived context carrying stop, a function that makes
+// the pipeline source stop producing and close its output.
+//
+// stop must be safe to call several times and from any goroutine (a
+// context.CancelFunc is a good fit). If parent is nil, it falls back to
+// context.Background().
+func WithSourceStop(parent context.Context, stop func()) context.Context {
+	if parent == nil {
+		parent = context.Background()
+	}
+	return context.WithValue(parent, sourceStopKey{}, stop)
+}
+
+// StopSource calls the source stop hook carried by ctx, if any.
+//
+// It reports whether a hook was found.
+func StopSource(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	stop, _ := ctx.Value(sourceStopKey{}).(func())
+	if stop == nil {
+		return false
+	}
+	stop()
+	return true
+}
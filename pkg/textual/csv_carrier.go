@@ -16,6 +16,7 @@ package textual
 
 import (
 	"errors"
+	"strings"
 )
 
 // CsvCarrier is a minimal Carrier implementation that transports an
@@ -37,10 +38,18 @@ import (
 // Note: This aggregation strategy intentionally does not add an XML-like wrapper
 // or a JSON-like array: CSV’s natural “fan-in” representation is simply a
 // multi-record CSV stream.
+//
+// Header optionally holds the column names of the record (see WithHeader and
+// CastCsvMap). It is shared between carriers and must not be mutated. Being a
+// slice, it makes CsvCarrier non-comparable (no == and no map keys). The
+// CSV-aware stages (NewCsvHeaderFromFirstRecord, NewCSVSchema, Aggregate) keep
+// it, but generic stages that rebuild items with FromUTF8String (Map,
+// Transliterate, SanitizeUTF8, ...) drop it: attach the header after them.
 type CsvCarrier struct {
-	Value UTF8String `json:"value"`
-	Index int        `json:"index,omitempty"`
-	Error error      `json:"error,omitempty"`
+	Value  UTF8String `json:"value"`
+	Index  int        `json:"index,omitempty"`
+	Error  error      `json:"error,omitempty"`
+	Header []string   `json:"header,omitempty"`
 }
 
 func (s CsvCarrier) UTF8String() UTF8String {
@@ -65,6 +74,14 @@ func (s CsvCarrier) GetIndex() int {
 	return s.Index
 }
 
+// WithHeader returns a copy of s carrying the column names of its record, so
+// that CastCsvMap can give named-field access without passing the header
+// around.
+func (s CsvCarrier) WithHeader(header []string) CsvCarrier {
+	s.Header = header
+	return s
+}
+
 func (s CsvCarrier) WithError(err error) CsvCarrier {
 	if err == nil {
 		return s
@@ -80,3 +97,19 @@ func (s CsvCarrier) WithError(err error) CsvCarrier {
 func (s CsvCarrier) GetError() error {
 	return s.Error
 }
+
+// Aggregate joins the records of items with "\n" after stably sorting them by
+// Index. The result carries the first index, the header of the first item and
+// the joined per-item errors.
+func (s CsvCarrier) Aggregate(items []CsvCarrier) CsvCarrier {
+	if len(items) == 0 {
+		return CsvCarrier{}
+	}
+	sorted := sortedByIndex(items)
+	records := make([]string, len(sorted))
+	for i, it := range sorted {
+		records[i] = it.Value
+	}
+	res := CsvCarrier{Value: UTF8String(strings.Join(records, "\n")), Index: sorted[0].Index, Header: sorted[0].Header}
+	return withJoinedErrors(res, sorted)
+}
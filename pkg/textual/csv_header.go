@@ -0,0 +1,57 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// NewCsvHeaderFromFirstRecord returns a Processor for ScanCSV streams whose
+// first record is a header: the first record is consumed (not forwarded), and
+// every following record is forwarded with the header attached through
+// CsvCarrier.WithHeader, ready for CastCsvMap.
+//
+// "First" means the first record received, so the stage must run before any
+// stage that reorders items. Indices are preserved (the data records keep
+// starting at 1 when the source numbers records from 0).
+//
+// If the header record cannot be parsed, it is forwarded with its error and
+// the following records are forwarded without header.
+func NewCsvHeaderFromFirstRecord() ProcessorFunc[CsvCarrier] {
+	return func(ctx context.Context, in <-chan CsvCarrier) <-chan CsvCarrier {
+		var header []string
+		first := true
+		return AsyncEmitter(ctx, in, func(ctx context.Context, c CsvCarrier, emit func(CsvCarrier)) {
+			if first {
+				first = false
+				if c.Error != nil {
+					emit(c)
+					return
+				}
+				rec, err := CastCsvRecord(c)
+				if err != nil {
+					emit(c.WithError(err))
+					return
+				}
+				header = rec
+				return
+			}
+			if header != nil {
+				c = c.WithHeader(header)
+			}
+			emit(c)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewCsvHeaderFromFirstRecord_CastCsvMap(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	records := scanAll(t, "name,city\nada,\"London, UK\"\nalan\n", ScanCSV)
+	in := make(chan CsvCarrier, len(records))
+	for i, r := range records {
+		in <- CSVFrom(r).WithIndex(i)
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewCsvHeaderFromFirstRecord().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("the header must not be forwarded: got %d items want %d", len(items), 2)
+	}
+
+	m, err := CastCsvMap(items[0], nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m["name"] != "ada" || m["city"] != "London, UK" {
+		t.Fatalf("unexpected map: %#v", m)
+	}
+
+	// Mismatched-length row.
+	if _, err := CastCsvMap(items[1], nil); !errors.Is(err, ErrCsvFieldCount) {
+		t.Fatalf("expected ErrCsvFieldCount, got %v", err)
+	}
+	m, err = CastCsvMapLenient(items[1], nil)
+	if err != nil {
+		t.Fatalf("unexpected lenient error: %v", err)
+	}
+	if m["name"] != "alan" || m["city"] != "" {
+		t.Fatalf("unexpected lenient map: %#v", m)
+	}
+}
+
+func TestCastCsvMap_ExplicitHeader(t *testing.T) {
+	m, err := CastCsvMap(CSVFrom("1,2"), []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m["a"] != "1" || m["b"] != "2" {
+		t.Fatalf("unexpected map: %#v", m)
+	}
+}
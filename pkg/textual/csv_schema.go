@@ -0,0 +1,138 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrMissingField is attached by NewCSVSchema to records lacking a required
+// field.
+var ErrMissingField = errors.New("textual: missing required field")
+
+// CSVColumn describes one column of a CSV schema.
+type CSVColumn struct {
+	// Name is the key of the field in the JSON object, and the header name.
+	Name string
+	// Default is used when the field is missing or null.
+	Default string
+	// Required reports an ErrMissingField error when the field is missing or
+	// null (Default is then ignored and the cell is left empty).
+	Required bool
+	// Format optionally overrides the default coercion of the JSON value
+	// (decoded with json.Number for numbers) into the cell text.
+	Format func(v any) (string, error)
+}
+
+// CSVHeader returns the header record of schema, encoded like the records
+// produced by NewCSVSchema.
+func CSVHeader(schema []CSVColumn) UTF8String {
+	return csvEncodeRecord(csvColumnNames(schema))
+}
+
+// csvColumnNames returns the column names of schema, in order.
+func csvColumnNames(schema []CSVColumn) []string {
+	names := make([]string, len(schema))
+	for i, c := range schema {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// NewCSVSchema returns a Transcoder mapping each JSON object to a CSV record
+// whose fields follow schema: one cell per column, in schema order, whatever
+// the field order or extra fields of the object.
+//
+// Values are coerced into strings per column: Format when set, otherwise
+// strings are used as is, numbers keep their JSON representation, booleans are
+// "true"/"false", and objects and arrays are compact JSON. Missing or null
+// fields take the column Default, or report ErrMissingField when the column is
+// Required. Cells are quoted following encoding/csv rules, and the record has
+// no trailing newline (like ScanCSV tokens).
+//
+// Every record carries the column names as its Header (see
+// CsvCarrier.WithHeader), ready for CastCsvMap. A value that is not a JSON
+// object yields an empty record with an error. Index and upstream errors are
+// preserved.
+func NewCSVSchema(schema []CSVColumn) TranscoderFunc[JsonCarrier, CsvCarrier] {
+	header := csvColumnNames(schema)
+	return NewTranscoderFunc(func(_ context.Context, j JsonCarrier) CsvCarrier {
+		res := CSVFrom("").WithIndex(j.Index).WithError(j.Error).WithHeader(header)
+
+		var object map[string]any
+		dec := json.NewDecoder(bytes.NewReader(j.Value))
+		dec.UseNumber()
+		if err := dec.Decode(&object); err != nil || object == nil {
+			if err == nil {
+				err = errors.New("not a JSON object")
+			}
+			return res.WithError(transcodeErrorf("csv schema (index %d): %w", j.Index, err))
+		}
+
+		cells := make([]string, len(schema))
+		for i, c := range schema {
+			v, ok := object[c.Name]
+			if !ok || v == nil {
+				if c.Required {
+					res = res.WithError(transcodeErrorf("%w: %q (index %d)", ErrMissingField, c.Name, j.Index))
+				} else {
+					cells[i] = c.Default
+				}
+				continue
+			}
+			cell, err := csvCoerce(c, v)
+			if err != nil {
+				res = res.WithError(transcodeErrorf("csv schema field %q (index %d): %w", c.Name, j.Index, err))
+			}
+			cells[i] = cell
+		}
+		res.Value = csvEncodeRecord(cells)
+		return res
+	})
+}
+
+// csvCoerce converts a decoded JSON value into the cell text of column c.
+func csvCoerce(c CSVColumn, v any) (string, error) {
+	if c.Format != nil {
+		return c.Format(v)
+	}
+	switch t := v.(type) {
+	case string:
+		return t, nil
+	case json.Number:
+		return t.String(), nil
+	case bool:
+		if t {
+			return "true", nil
+		}
+		return "false", nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// csvEncodeRecord encodes one CSV record without its trailing newline.
+func csvEncodeRecord(fields []string) UTF8String {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	ignoreErr(w.Write(fields))
+	w.Flush()
+	return UTF8String(strings.TrimSuffix(b.String(), "\n"))
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var testCSVSchema = []CSVColumn{
+	{Name: "id", Required: true},
+	{Name: "name"},
+	{Name: "country", Default: "FR"},
+	{Name: "score"},
+}
+
+func applyCSVSchema(t *testing.T, values ...string) []CsvCarrier {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan JsonCarrier, len(values))
+	for i, v := range values {
+		in <- JSONFrom(v).WithIndex(i)
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewCSVSchema(testCSVSchema).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != len(values) {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), len(values))
+	}
+	sortByIndex(items)
+	return items
+}
+
+func TestNewCSVSchema_OrderAndDefaults(t *testing.T) {
+	items := applyCSVSchema(t,
+		`{"score": 12.50, "name": "Doe, Jane", "id": 7, "extra": true}`,
+		`{"id": "b2", "name": null, "country": "IT", "score": [1, 2]}`,
+	)
+
+	want := []string{
+		`7,"Doe, Jane",FR,12.50`,
+		`b2,,IT,"[1,2]"`,
+	}
+	for i, w := range want {
+		if items[i].GetError() != nil {
+			t.Fatalf("unexpected error on record %d: %v", i, items[i].GetError())
+		}
+		if items[i].Value != w {
+			t.Fatalf("unexpected record %d: got %q want %q", i, items[i].Value, w)
+		}
+	}
+	if got := CSVHeader(testCSVSchema); got != "id,name,country,score" {
+		t.Fatalf("unexpected header: %q", got)
+	}
+}
+
+func TestNewCSVSchema_RequiredMissing(t *testing.T) {
+	items := applyCSVSchema(t, `{"name": "x"}`, `[1, 2]`)
+
+	if !errors.Is(items[0].GetError(), ErrMissingField) {
+		t.Fatalf("expected ErrMissingField, got %v", items[0].GetError())
+	}
+	if items[0].Value != ",x,FR," {
+		t.Fatalf("unexpected record: got %q want %q", items[0].Value, ",x,FR,")
+	}
+	if items[1].GetError() == nil || items[1].Value != "" {
+		t.Fatalf("expected an error for a non-object value, got %#v", items[1])
+	}
+}
+
+func TestNewCSVSchema_AttachesHeader(t *testing.T) {
+	items := applyCSVSchema(t, `{"id": 7, "name": "Jane"}`)
+
+	fields, err := CastCsvMap(items[0], nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fields["id"] != "7" || fields["name"] != "Jane" || fields["country"] != "FR" {
+		t.Fatalf("unexpected fields: %v", fields)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+)
+
+// Debounce returns a Processor that coalesces bursts of items and only emits
+// the most recent one once the input has been quiet for `quiet`.
+//
+// Semantics (trailing edge):
+//
+//   - Each incoming item replaces the held item and restarts the quiet timer.
+//   - When `quiet` elapses without new input, the held item is emitted.
+//   - When `in` is closed, a pending item is flushed immediately.
+//   - When ctx is canceled, a pending item is dropped.
+//
+// Debounce is about *which* items are emitted (the last of each burst), not
+// about throughput: every burst yields exactly one output.
+//
+// If quiet <= 0, Debounce behaves as a pass-through.
+func Debounce[S Carrier[S]](quiet time.Duration) ProcessorFunc[S] {
+	if quiet <= 0 {
+		return passThroughProcessor[S]()
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		ctx, ps := EnsurePanicStore(ctx)
+
+		out := make(chan S)
+		go func() {
+			defer close(out)
+
+			defer func() {
+				if r := recover(); r != nil {
+					ps.Store(r, debug.Stack())
+				}
+			}()
+
+			timer := time.NewTimer(quiet)
+			timer.Stop()
+			defer timer.Stop()
+
+			var (
+				pending    S
+				hasPending bool
+				fire       <-chan time.Time // nil while nothing is pending
+			)
+
+			send := func(item S) bool {
+				select {
+				case <-ctx.Done():
+					return false
+				case out <- item:
+					return true
+				}
+			}
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-in:
+					if !ok {
+						if hasPending {
+							send(pending)
+						}
+						return
+					}
+					pending, hasPending = item, true
+					timer.Reset(quiet)
+					fire = timer.C
+				case <-fire:
+					fire = nil
+					hasPending = false
+					if !send(pending) {
+						return
+					}
+				}
+			}
+		}()
+		return out
+	}
+}
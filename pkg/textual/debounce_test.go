@@ -0,0 +1,68 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDebounce_EmitsOnlyLastOfBurst(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier)
+	out := Debounce[StringCarrier](50*time.Millisecond).Apply(ctx, in)
+
+	go func() {
+		in <- StringCarrier{Value: "a", Index: 0}
+		in <- StringCarrier{Value: "b", Index: 1}
+		in <- StringCarrier{Value: "c", Index: 2}
+		// Stay open past the quiet period so the emission is timer driven.
+		time.Sleep(150 * time.Millisecond)
+		close(in)
+	}()
+
+	items, err := collectWithContext(ctx, out)
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("unexpected output count: got %d want %d items=%#v", len(items), 1, items)
+	}
+	if items[0].Value != "c" || items[0].Index != 2 {
+		t.Fatalf("unexpected debounced item: %#v", items[0])
+	}
+}
+
+func TestDebounce_FlushesPendingOnClose(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "a", Index: 0}
+	in <- StringCarrier{Value: "b", Index: 1}
+	close(in)
+
+	// A long quiet period proves the flush is triggered by the close.
+	items, err := collectWithContext(ctx, Debounce[StringCarrier](time.Hour).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Value != "b" {
+		t.Fatalf("unexpected flushed items: %#v", items)
+	}
+}
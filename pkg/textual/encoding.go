@@ -181,6 +181,9 @@ func (e EncodingID) EncodingName() EncodingName {
 	case EUCKR:
 		return "EUC-KR"
 	}
+	if c, ok := lookupCustomEncoding(e); ok {
+		return c.name
+	}
 	return "Unknown"
 }
 
@@ -239,16 +242,21 @@ var nameToEncoding = map[EncodingName]EncodingID{
 	"euc-kr": EUCKR,
 }
 
-// ParseEncoding returns the EncodingID for a given name (case-insensitive).
+// ParseEncoding returns the EncodingID for a given name (case-insensitive),
+// including the encodings added with RegisterEncoding.
 func ParseEncoding(name EncodingName) (EncodingID, error) {
 	key := strings.ToLower(strings.TrimSpace(name))
 	if enc, ok := nameToEncoding[key]; ok {
 		return enc, nil
 	}
+	if enc, ok := lookupCustomEncodingName(key); ok {
+		return enc, nil
+	}
 	return 0, fmt.Errorf("unknown encoding: %s", name)
 }
 
-// GetEncoding returns the encoding.Encoding instance.
+// GetEncoding returns the encoding.Encoding instance, including the codecs
+// added with RegisterEncoding.
 func GetEncoding(e EncodingID) (encoding.Encoding, error) {
 	switch e {
 	case UTF8:
@@ -341,6 +349,10 @@ func GetEncoding(e EncodingID) (encoding.Encoding, error) {
 		return korean.EUCKR, nil
 	}
 
+	if c, ok := lookupCustomEncoding(e); ok {
+		return c.codec, nil
+	}
+
 	return nil, errors.New("unsupported encoding id")
 }
 
@@ -0,0 +1,96 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/text/encoding"
+)
+
+// EncodingCodec is the codec of a custom encoding: any golang.org/x/text
+// encoding.Encoding, i.e. a type providing NewDecoder (to UTF-8) and
+// NewEncoder (from UTF-8).
+type EncodingCodec = encoding.Encoding
+
+// firstCustomEncoding is the EncodingID given to the first registered
+// encoding. It leaves room for future built-in encodings.
+const firstCustomEncoding EncodingID = 1 << 16
+
+// customEncoding is a registered encoding.
+type customEncoding struct {
+	name  EncodingName
+	codec EncodingCodec
+}
+
+var encodingRegistry = struct {
+	mu     sync.RWMutex
+	byID   map[EncodingID]customEncoding
+	byName map[EncodingName]EncodingID // lower-case names
+}{
+	byID:   make(map[EncodingID]customEncoding),
+	byName: make(map[EncodingName]EncodingID),
+}
+
+// RegisterEncoding makes a custom encoding available to the whole package
+// under name, and returns its EncodingID. The ID can be used everywhere a
+// built-in one can: Nature, NewUTF8Reader, ToUTF8, FromUTF8, the encoding
+// transcoders, ... EncodingName returns name, and ParseEncoding finds it
+// case-insensitively.
+//
+// Like sql.Register, it is meant to be called during initialization, and it
+// panics when name is empty, already used (by a built-in or a registered
+// encoding), or when enc is nil. IDs are assigned in registration order, so
+// do not persist them across processes: persist the name instead.
+func RegisterEncoding(name string, enc EncodingCodec) EncodingID {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if key == "" {
+		panic("textual: RegisterEncoding with an empty name")
+	}
+	if enc == nil {
+		panic("textual: RegisterEncoding " + name + " with a nil codec")
+	}
+	if _, ok := nameToEncoding[key]; ok {
+		panic("textual: RegisterEncoding " + name + " shadows a built-in encoding")
+	}
+
+	encodingRegistry.mu.Lock()
+	defer encodingRegistry.mu.Unlock()
+	if _, ok := encodingRegistry.byName[key]; ok {
+		panic("textual: RegisterEncoding called twice for " + name)
+	}
+	id := firstCustomEncoding + EncodingID(len(encodingRegistry.byID))
+	encodingRegistry.byID[id] = customEncoding{name: name, codec: enc}
+	encodingRegistry.byName[key] = id
+	return id
+}
+
+// lookupCustomEncoding returns the registered encoding with the given ID.
+func lookupCustomEncoding(e EncodingID) (customEncoding, bool) {
+	encodingRegistry.mu.RLock()
+	defer encodingRegistry.mu.RUnlock()
+	c, ok := encodingRegistry.byID[e]
+	return c, ok
+}
+
+// lookupCustomEncodingName returns the ID of the encoding registered under key
+// (lower-case).
+func lookupCustomEncodingName(key EncodingName) (EncodingID, bool) {
+	encodingRegistry.mu.RLock()
+	defer encodingRegistry.mu.RUnlock()
+	id, ok := encodingRegistry.byName[key]
+	return id, ok
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// rot13 is a toy codec: ASCII letters are rotated by 13 both ways.
+type rot13 struct{ transform.NopResetter }
+
+func (rot13) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		if nDst >= len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		b := src[nSrc]
+		switch {
+		case b >= 'a' && b <= 'z':
+			b = 'a' + (b-'a'+13)%26
+		case b >= 'A' && b <= 'Z':
+			b = 'A' + (b-'A'+13)%26
+		}
+		dst[nDst] = b
+		nDst++
+		nSrc++
+	}
+	return nDst, nSrc, nil
+}
+
+type rot13Encoding struct{}
+
+func (rot13Encoding) NewDecoder() *encoding.Decoder { return &encoding.Decoder{Transformer: rot13{}} }
+func (rot13Encoding) NewEncoder() *encoding.Encoder { return &encoding.Encoder{Transformer: rot13{}} }
+
+var rot13ID = RegisterEncoding("x-rot13", rot13Encoding{})
+
+func TestRegisterEncoding_Lookup(t *testing.T) {
+	if got, want := rot13ID.EncodingName(), "x-rot13"; got != want {
+		t.Fatalf("unexpected name: got %q want %q", got, want)
+	}
+	id, err := ParseEncoding("X-ROT13")
+	if err != nil || id != rot13ID {
+		t.Fatalf("unexpected ParseEncoding: got %v, %v want %v", id, err, rot13ID)
+	}
+	if got, err := ToUTF8([]byte("Uryyb"), rot13ID); err != nil || got != "Hello" {
+		t.Fatalf("unexpected ToUTF8: got %q, %v want %q", got, err, "Hello")
+	}
+
+	for _, name := range []string{"", "UTF-8", "x-rot13"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("unexpected: no panic registering %q", name)
+				}
+			}()
+			RegisterEncoding(name, rot13Encoding{})
+		}()
+	}
+}
+
+func TestRegisterEncoding_TransformationRoundTrip(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	tr := NewTransformation[StringCarrier](
+		"rot13 round trip",
+		MapString(strings.ToUpper),
+		Nature{Dialect: "plain", EncodingID: rot13ID},
+		Nature{Dialect: "plain", EncodingID: rot13ID},
+	)
+	var w closingBuffer
+	// "uryyb jbeyq" is rot13 for "hello world".
+	if err := tr.Process(ctx, io.NopCloser(strings.NewReader("uryyb jbeyq")), &w); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := w.String(), "URYYB JBEYQ"; got != want {
+		t.Fatalf("unexpected output: got %q want %q", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// NewDecodeTranscoder returns a Transcoder decoding each BytesCarrier from the
+// from encoding into a UTF-8 StringCarrier (see ToUTF8).
+//
+// Unlike NewUTF8Reader, which decodes at the io.Reader boundary, this is an
+// explicit pipeline stage: it can be placed anywhere, e.g. composed with
+// ChainTranscoders. Each item is decoded independently, so items must not
+// split multi-byte characters (frame them accordingly upstream).
+//
+// Index and error are preserved. A decoding failure yields an empty value with
+// an error attached.
+func NewDecodeTranscoder(from EncodingID) TranscoderFunc[BytesCarrier, StringCarrier] {
+	return NewTranscoderFunc(func(_ context.Context, b BytesCarrier) StringCarrier {
+		res := StringCarrier{Index: b.Index}.WithError(b.Error)
+		s, err := ToUTF8(b.Value, from)
+		if err != nil {
+			return res.WithError(encodingErrorf("decode %s (index %d): %w", from.EncodingName(), b.Index, err))
+		}
+		res.Value = s
+		return res
+	})
+}
+
+// NewEncodeTranscoder returns a Transcoder encoding each UTF-8 StringCarrier
+// into a BytesCarrier in the to encoding (see FromUTF8).
+//
+// Index and error are preserved. An encoding failure (e.g. a character the
+// target encoding cannot represent) yields an empty value with an error
+// attached.
+func NewEncodeTranscoder(to EncodingID) TranscoderFunc[StringCarrier, BytesCarrier] {
+	return NewTranscoderFunc(func(_ context.Context, s StringCarrier) BytesCarrier {
+		res := BytesCarrier{Index: s.Index}.WithError(s.Error)
+		b, err := FromUTF8(s.Value, to)
+		if err != nil {
+			return res.WithError(encodingErrorf("encode %s (index %d): %w", to.EncodingName(), s.Index, err))
+		}
+		res.Value = b
+		return res
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewDecodeTranscoder_Latin1(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan BytesCarrier, 2)
+	in <- BytesCarrier{Value: []byte("caf\xe9"), Index: 0}
+	in <- BytesCarrier{Value: []byte("na\xefve"), Index: 1}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewDecodeTranscoder(ISO8859_1).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	sortByIndex(items)
+	if len(items) != 2 || items[0].Value != "café" || items[1].Value != "naïve" || items[1].Index != 1 {
+		t.Fatalf("unexpected items: %#v", items)
+	}
+}
+
+func TestNewEncodeTranscoder_Latin1(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "café", Index: 0}
+	in <- StringCarrier{Value: "5 €", Index: 1}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewEncodeTranscoder(ISO8859_1).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	sortByIndex(items)
+	if !bytes.Equal(items[0].Value, []byte("caf\xe9")) || items[0].GetError() != nil {
+		t.Fatalf("unexpected encoded item: %#v", items[0])
+	}
+	if items[1].GetError() == nil {
+		t.Fatalf("expected an error for a character Latin-1 cannot represent, got %#v", items[1])
+	}
+}
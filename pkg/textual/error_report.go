@@ -0,0 +1,70 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NewErrorReport returns a Processor that forwards every item unchanged while
+// collecting per-item errors, and emits a final synthetic item summarizing
+// them when the input is closed.
+//
+// The report item is built with FromUTF8String, is indexed after the highest
+// index seen, carries no error itself, and reads:
+//
+//	2 error(s):
+//	[index 3] textual: invalid contact email: "a@@b"
+//	[index 7] timeout
+//
+// Errors are listed by index (stable for equal indexes). No report is emitted
+// when no item carried an error, nor when ctx is canceled.
+func NewErrorReport[S Carrier[S]]() ProcessorFunc[S] {
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		type entry struct {
+			index int
+			err   error
+		}
+		var entries []entry
+		maxIndex, seen := 0, false
+
+		return asyncEmitter(ctx, in, func(ctx context.Context, item S, emit func(S)) {
+			if idx := item.GetIndex(); !seen || idx > maxIndex {
+				maxIndex, seen = idx, true
+			}
+			if err := item.GetError(); err != nil {
+				entries = append(entries, entry{index: item.GetIndex(), err: err})
+			}
+			emit(item)
+		}, func(ctx context.Context, emit func(S)) {
+			if len(entries) == 0 {
+				return
+			}
+			sort.SliceStable(entries, func(i, j int) bool {
+				return entries[i].index < entries[j].index
+			})
+			var b strings.Builder
+			fmt.Fprintf(&b, "%d error(s):", len(entries))
+			for _, e := range entries {
+				fmt.Fprintf(&b, "\n[index %d] %v", e.index, e.err)
+			}
+			proto := *new(S)
+			emit(proto.FromUTF8String(UTF8String(b.String())).WithIndex(maxIndex + 1))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewErrorReport_SummarizesErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 4)
+	in <- StringCarrier{Value: "a", Index: 0}
+	in <- StringCarrier{Value: "b", Index: 2}.WithError(errors.New("bad b"))
+	in <- StringCarrier{Value: "c", Index: 1}.WithError(errors.New("bad c"))
+	in <- StringCarrier{Value: "d", Index: 3}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewErrorReport[StringCarrier]().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 5 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 5)
+	}
+	for i, v := range []string{"a", "b", "c", "d"} {
+		if items[i].Value != v {
+			t.Fatalf("items must be forwarded unchanged: got %#v", items[i])
+		}
+	}
+
+	report := items[4]
+	want := "2 error(s):\n[index 1] bad c\n[index 2] bad b"
+	if report.Value != want || report.Index != 4 || report.GetError() != nil {
+		t.Fatalf("unexpected report:\n got: %#v\nwant: %q at index 4", report, want)
+	}
+}
+
+func TestNewErrorReport_NoReportWithoutErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "a", Index: 0}
+	in <- StringCarrier{Value: "b", Index: 1}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewErrorReport[StringCarrier]().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 2)
+	}
+}
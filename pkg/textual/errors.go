@@ -14,6 +14,105 @@
 
 package textual
 
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Error categories. They are matched with errors.Is, whatever the wrapping:
+//
+//	if errors.Is(item.GetError(), textual.ErrFraming) { ... }
+var (
+	// ErrFraming reports a malformed or truncated input stream (split funcs).
+	ErrFraming = errors.New("textual: framing error")
+	// ErrTranscode reports a failure to convert an item to another carrier.
+	ErrTranscode = errors.New("textual: transcode error")
+	// ErrEncoding reports a failure to decode or encode a character encoding.
+	ErrEncoding = errors.New("textual: encoding error")
+)
+
+// ErrorKind is the category of a TextualError.
+type ErrorKind int
+
+const (
+	ErrorKindUnknown ErrorKind = iota
+	ErrorKindFraming
+	ErrorKindTranscode
+	ErrorKindEncoding
+)
+
+// String returns the name of the kind.
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindFraming:
+		return "framing"
+	case ErrorKindTranscode:
+		return "transcode"
+	case ErrorKindEncoding:
+		return "encoding"
+	default:
+		return "unknown"
+	}
+}
+
+// sentinel returns the category error matching k, or nil.
+func (k ErrorKind) sentinel() error {
+	switch k {
+	case ErrorKindFraming:
+		return ErrFraming
+	case ErrorKindTranscode:
+		return ErrTranscode
+	case ErrorKindEncoding:
+		return ErrEncoding
+	default:
+		return nil
+	}
+}
+
+// TextualError is an error tagged with its category.
+//
+// Its message is the one of Err, so tagging an error does not change how it
+// reads. errors.Is matches both the sentinel of Kind (ErrFraming, ...) and
+// anything Err wraps (e.g. io.ErrUnexpectedEOF).
+type TextualError struct {
+	Kind ErrorKind
+	Err  error
+}
+
+func (e *TextualError) Error() string {
+	if e.Err == nil {
+		return "textual: " + e.Kind.String() + " error"
+	}
+	return e.Err.Error()
+}
+
+func (e *TextualError) Unwrap() error { return e.Err }
+
+// Is reports whether target is the sentinel of e.Kind.
+func (e *TextualError) Is(target error) bool {
+	s := e.Kind.sentinel()
+	return s != nil && target == s
+}
+
+// errFramingEOF is returned by split funcs when the input ends inside a token.
+var errFramingEOF error = &TextualError{Kind: ErrorKindFraming, Err: io.ErrUnexpectedEOF}
+
+// framingErrorf formats a framing error (see ErrFraming).
+func framingErrorf(format string, args ...any) error {
+	return &TextualError{Kind: ErrorKindFraming, Err: fmt.Errorf(format, args...)}
+}
+
+// transcodeErrorf formats a conversion error of a transcoder (see ErrTranscode).
+func transcodeErrorf(format string, args ...any) error {
+	return &TextualError{Kind: ErrorKindTranscode, Err: fmt.Errorf(format, args...)}
+}
+
+// encodingErrorf formats a character encoding error (see ErrEncoding).
+func encodingErrorf(format string, args ...any) error {
+	return &TextualError{Kind: ErrorKindEncoding, Err: fmt.Errorf(format, args...)}
+}
+
 // ignoreErr is a way to explicitly mark we ignore an error.
 // e.g. "defer ignoreErr(w.Close())" when io errors are not handled.
 var ignoreErr = func(e error) {}
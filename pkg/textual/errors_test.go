@@ -0,0 +1,143 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func scanErr(input string, split bufio.SplitFunc) error {
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	scanner.Split(split)
+	for scanner.Scan() {
+	}
+	return scanner.Err()
+}
+
+func TestScanners_FramingErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		split   bufio.SplitFunc
+		wantEOF bool
+	}{
+		{name: "json truncated", input: `{"a":[1,2`, split: ScanJSON, wantEOF: true},
+		{name: "json mismatched", input: `{"a":1]`, split: ScanJSON},
+		{name: "json array truncated", input: `[1,{"a":`, split: ScanJSONArrayElements(), wantEOF: true},
+		{name: "xml truncated", input: `<a><b>x</b>`, split: ScanXML, wantEOF: true},
+		{name: "xml mismatched", input: `<a></b>`, split: ScanXML},
+		{name: "csv open quote", input: "a,\"b\nc", split: ScanCSV, wantEOF: true},
+	}
+	for _, c := range cases {
+		err := scanErr(c.input, c.split)
+		if !errors.Is(err, ErrFraming) {
+			t.Fatalf("%s: unexpected error: got %v want %v", c.name, err, ErrFraming)
+		}
+		if got := errors.Is(err, io.ErrUnexpectedEOF); got != c.wantEOF {
+			t.Fatalf("%s: unexpected io.ErrUnexpectedEOF match: got %v want %v", c.name, got, c.wantEOF)
+		}
+		if errors.Is(err, ErrTranscode) || errors.Is(err, ErrEncoding) {
+			t.Fatalf("%s: unexpected category for %v", c.name, err)
+		}
+	}
+}
+
+func TestTextualError_KindAndWrapping(t *testing.T) {
+	cause := errors.New("bad byte")
+	err := fmt.Errorf("stage: %w", &TextualError{Kind: ErrorKindEncoding, Err: cause})
+
+	if !errors.Is(err, ErrEncoding) || !errors.Is(err, cause) {
+		t.Fatalf("unexpected errors.Is results for %v", err)
+	}
+	var te *TextualError
+	if !errors.As(err, &te) || te.Kind != ErrorKindEncoding {
+		t.Fatalf("unexpected errors.As result for %v", err)
+	}
+	if got, want := err.Error(), "stage: bad byte"; got != want {
+		t.Fatalf("unexpected message: got %q want %q", got, want)
+	}
+	if errors.Is(&TextualError{Err: cause}, ErrFraming) {
+		t.Fatalf("unknown kind must not match a category")
+	}
+}
+
+func TestEncodingStages_EncodingErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	strs := make(chan StringCarrier, 1)
+	strs <- StringCarrier{Value: "日本"}
+	close(strs)
+	encoded, err := collectWithContext(ctx, NewEncodeTranscoder(ISO8859_1).Apply(ctx, strs))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(encoded) != 1 || !errors.Is(encoded[0].GetError(), ErrEncoding) {
+		t.Fatalf("unexpected encode output: got %+v want an error matching %v", encoded, ErrEncoding)
+	}
+
+	raw := make(chan BytesCarrier, 1)
+	raw <- BytesCarrier{Value: []byte("x")}
+	close(raw)
+	decoded, err := collectWithContext(ctx, NewDecodeTranscoder(EncodingID(1<<20)).Apply(ctx, raw))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(decoded) != 1 || !errors.Is(decoded[0].GetError(), ErrEncoding) {
+		t.Fatalf("unexpected decode output: got %+v want an error matching %v", decoded, ErrEncoding)
+	}
+}
+
+func TestTranscoders_TranscodeErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	feed := func(values ...string) <-chan JsonCarrier {
+		in := make(chan JsonCarrier, len(values))
+		for i, v := range values {
+			in <- JsonCarrier{Value: json.RawMessage(v), Index: i}
+		}
+		close(in)
+		return in
+	}
+
+	xmls, err := collectWithContext(ctx, NewJSONToXML("").Apply(ctx, feed(`{"a":`)))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(xmls) != 1 || !errors.Is(xmls[0].GetError(), ErrTranscode) {
+		t.Fatalf("unexpected json to xml output: got %+v want an error matching %v", xmls, ErrTranscode)
+	}
+
+	schema := NewCSVSchema([]CSVColumn{{Name: "id", Required: true}})
+	rows, err := collectWithContext(ctx, schema.Apply(ctx, feed(`{"name":"x"}`)))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(rows) != 1 || !errors.Is(rows[0].GetError(), ErrTranscode) || !errors.Is(rows[0].GetError(), ErrMissingField) {
+		t.Fatalf("unexpected csv schema output: got %+v want an error matching %v and %v", rows, ErrTranscode, ErrMissingField)
+	}
+	if errors.Is(rows[0].GetError(), ErrEncoding) || errors.Is(rows[0].GetError(), ErrFraming) {
+		t.Fatalf("unexpected category for %v", rows[0].GetError())
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// NewExec returns a Processor piping each item through an external command:
+// the command is started once per item with the item text (UTF8String) on its
+// standard input, and the item is rebuilt with FromUTF8String from its
+// standard output, preserving index and error.
+//
+// The process is killed when ctx is canceled (exec.CommandContext). When the
+// command cannot be started or exits with a non-zero status, the item keeps
+// its original text and carries an error including the trimmed standard error
+// output.
+//
+// Security caveats:
+//
+//   - name is resolved through PATH (exec.Command rules): prefer absolute paths
+//     when PATH is not trusted.
+//   - Item text is only written to stdin, never interpolated into the command
+//     line, and no shell is involved. Passing untrusted data as args, or using
+//     "sh -c" with a command built from item data, re-introduces injection
+//     risks.
+//   - The command inherits the environment of the current process.
+//
+// Starting one process per item is expensive: batch items upstream (Aggregate,
+// NewTokenBudgetBatcher, ...) when throughput matters.
+func NewExec[S Carrier[S]](name string, args ...string) ProcessorFunc[S] {
+	return NewProcessorFunc(func(ctx context.Context, item S) S {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stdin = strings.NewReader(item.UTF8String())
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+			return item.WithError(fmt.Errorf("exec %s (index %d): %w", name, item.GetIndex(), err))
+		}
+		res := item.FromUTF8String(UTF8String(stdout.String())).WithIndex(item.GetIndex())
+		return res.WithError(item.GetError())
+	})
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// ErrExecStreamUnmatched is attached to outputs of NewExecStream that have no
+// corresponding input (the command produced more tokens than it received).
+var ErrExecStreamUnmatched = errors.New("textual: exec stream output without input")
+
+// NewExecStream returns a Processor streaming every item through a single
+// long-running external command, which amortizes the process startup that
+// NewExec pays per item.
+//
+// The command is started on Apply. Each item text is written to its standard
+// input followed by "\n", and its standard output is framed with split
+// (bufio.ScanLines when nil). Outputs are correlated to inputs by order: the
+// n-th token takes the index and error of the n-th item. Extra tokens carry
+// ErrExecStreamUnmatched. The command must therefore produce exactly one token
+// per input line, in order (cat, tr, sed, jq -c, ...).
+//
+// Process death: when the command exits (non-zero status, crash, or ctx
+// cancellation, which kills it), the items that did not get an output are
+// forwarded with their original text and an error including the trimmed
+// standard error output, so nothing is silently lost. The standard input is
+// closed once in is closed or ctx is canceled, and the output is closed when
+// the command exits. A panic while reading or writing is recorded in the
+// context PanicStore.
+//
+// The security caveats of NewExec apply.
+func NewExecStream[S Carrier[S]](split bufio.SplitFunc, name string, args ...string) ProcessorFunc[S] {
+	if split == nil {
+		split = bufio.ScanLines
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		ctx, ps := EnsurePanicStore(ctx)
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stderr = &stderr
+		stdin, err := cmd.StdinPipe()
+		var stdout io.ReadCloser
+		if err == nil {
+			stdout, err = cmd.StdoutPipe()
+		}
+		if err == nil {
+			err = cmd.Start()
+		}
+		if err != nil {
+			err = fmt.Errorf("exec %s: %w", name, err)
+			return NewProcessorFunc(func(_ context.Context, item S) S {
+				return item.WithError(err)
+			}).Apply(ctx, in)
+		}
+
+		out := make(chan S)
+		send := func(item S) bool {
+			select {
+			case out <- item:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var (
+			mu      sync.Mutex
+			pending []S   // items written to stdin, waiting for their output
+			exitErr error // set once the command has exited
+		)
+		pop := func() (S, bool) {
+			mu.Lock()
+			defer mu.Unlock()
+			if len(pending) == 0 {
+				return *new(S), false
+			}
+			item := pending[0]
+			pending = pending[1:]
+			return item, true
+		}
+		failed := func(item S, err error) S {
+			return item.WithError(fmt.Errorf("exec %s (index %d): %w", name, item.GetIndex(), err))
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		// Writer: feeds stdin. Once the command has exited, the remaining
+		// items are forwarded with the exit error. Stdin is closed when in is
+		// closed or ctx is canceled.
+		go func() {
+			defer wg.Done()
+			defer func() { ignoreErr(stdin.Close()) }()
+			defer func() {
+				if r := recover(); r != nil {
+					ps.Store(r, debug.Stack())
+				}
+			}()
+			for {
+				var item S
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-in:
+					if !ok {
+						return
+					}
+					item = v
+				}
+				mu.Lock()
+				if err := exitErr; err != nil {
+					mu.Unlock()
+					send(failed(item, err))
+					continue
+				}
+				pending = append(pending, item)
+				mu.Unlock()
+				// A write failure means the command is exiting: the reader
+				// forwards the item with the exit error.
+				_, _ = io.WriteString(stdin, item.UTF8String()+"\n")
+			}
+		}()
+
+		// Reader: frames stdout and correlates tokens to pending items.
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					ps.Store(r, debug.Stack())
+				}
+			}()
+			scanner := bufio.NewScanner(stdout)
+			scanner.Split(split)
+			for scanner.Scan() {
+				token := UTF8String(scanner.Text())
+				var res S
+				if item, ok := pop(); ok {
+					res = item.FromUTF8String(token).WithIndex(item.GetIndex()).WithError(item.GetError())
+				} else {
+					res = (*new(S)).FromUTF8String(token).WithError(ErrExecStreamUnmatched)
+				}
+				if !send(res) {
+					break
+				}
+			}
+			// Stop reading (the command gets EPIPE if still writing), then reap it.
+			ignoreErr(stdout.Close())
+			err := errors.Join(scanner.Err(), cmd.Wait())
+			if err == nil {
+				err = errors.New("exited before producing every output")
+			}
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+
+			mu.Lock()
+			exitErr = err
+			orphans := pending
+			pending = nil
+			mu.Unlock()
+			for _, item := range orphans {
+				if !send(failed(item, err)) {
+					return
+				}
+			}
+		}()
+
+		go func() {
+			wg.Wait()
+			close(out)
+		}()
+		return out
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewExecStream_CorrelatesByOrder(t *testing.T) {
+	requireCommands(t, "cat", "tr")
+
+	items := execAll(t, NewExecStream[StringCarrier](nil, "cat"), "alpha", "beta", "gamma")
+	if len(items) != 3 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 3)
+	}
+	for i, want := range []string{"alpha", "beta", "gamma"} {
+		if items[i].Value != want || items[i].GetError() != nil {
+			t.Fatalf("unexpected item %d: %#v", i, items[i])
+		}
+	}
+
+	items = execAll(t, NewExecStream[StringCarrier](nil, "tr", "a-z", "A-Z"), "one", "two")
+	if len(items) != 2 || items[0].Value != "ONE" || items[1].Value != "TWO" || items[1].Index != 1 {
+		t.Fatalf("unexpected items: %#v", items)
+	}
+}
+
+func TestNewExecStream_ProcessDeath(t *testing.T) {
+	requireCommands(t, "sh")
+
+	// Answers the first line, then dies.
+	p := NewExecStream[StringCarrier](nil, "sh", "-c", `read x; echo "got $x"; echo dying >&2; exit 2`)
+	items := execAll(t, p, "a", "b", "c")
+	if len(items) != 3 {
+		t.Fatalf("every item must be forwarded: got %d want %d", len(items), 3)
+	}
+	if items[0].Value != "got a" || items[0].GetError() != nil {
+		t.Fatalf("unexpected first item: %#v", items[0])
+	}
+	for _, it := range items[1:] {
+		if it.GetError() == nil || !strings.Contains(it.GetError().Error(), "dying") {
+			t.Fatalf("expected the exit error on %q, got %v", it.Value, it.GetError())
+		}
+	}
+}
+
+func TestNewExecStream_CanceledClosesWithOpenInput(t *testing.T) {
+	requireCommands(t, "cat")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan StringCarrier) // never closed
+	out := NewExecStream[StringCarrier](nil, "cat").Apply(ctx, in)
+	cancel()
+
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-out:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatalf("output not closed after cancellation")
+		}
+	}
+}
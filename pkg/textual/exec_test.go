@@ -0,0 +1,81 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requireCommands(t *testing.T, names ...string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX commands are not available on windows")
+	}
+	for _, name := range names {
+		if _, err := exec.LookPath(name); err != nil {
+			t.Skipf("%s not found in PATH", name)
+		}
+	}
+}
+
+func execAll(t *testing.T, p Processor[StringCarrier], values ...string) []StringCarrier {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, len(values))
+	for i, v := range values {
+		in <- StringCarrier{Value: v, Index: i}
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, p.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	sortByIndex(items)
+	return items
+}
+
+func TestNewExec_PipesThroughCommand(t *testing.T) {
+	requireCommands(t, "tr", "cat")
+
+	items := execAll(t, NewExec[StringCarrier]("tr", "a-z", "A-Z"), "hello", "world")
+	if len(items) != 2 || items[0].Value != "HELLO" || items[1].Value != "WORLD" || items[1].Index != 1 {
+		t.Fatalf("unexpected items: %#v", items)
+	}
+
+	items = execAll(t, NewExec[StringCarrier]("cat"), "café\n")
+	if items[0].Value != "café\n" || items[0].GetError() != nil {
+		t.Fatalf("unexpected item: %#v", items[0])
+	}
+}
+
+func TestNewExec_NonZeroExitCarriesStderr(t *testing.T) {
+	requireCommands(t, "sh")
+
+	items := execAll(t, NewExec[StringCarrier]("sh", "-c", "echo oops >&2; exit 3"), "input")
+	if items[0].Value != "input" {
+		t.Fatalf("the original text must be kept: got %q", items[0].Value)
+	}
+	if err := items[0].GetError(); err == nil || !strings.Contains(err.Error(), "oops") {
+		t.Fatalf("expected stderr in the error, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"unicode/utf8"
+)
+
+// ExpandMode selects the unit Expand splits carriers into.
+type ExpandMode int
+
+const (
+	// ByRune emits one carrier per Unicode code point.
+	ByRune ExpandMode = iota
+	// ByGrapheme emits one carrier per user-perceived character (extended
+	// grapheme cluster, see ScanGrapheme).
+	ByGrapheme
+)
+
+// Expand returns a Processor splitting each carrier into one carrier per rune
+// or per grapheme, for per-character rendering (typewriter effects, ...) of a
+// stream that was framed differently upstream.
+//
+// Each unit is built with FromUTF8String on the zero value of S, so
+// carrier-specific state (Parcel fragments, ...) is not carried over. It is
+// indexed like FlatMap: the unit at position pos of the item with index parent
+// gets the index parent*FlatMapStride + pos (see FlatMapIndex), and the error
+// of the item, if any, is attached to each of its units. Empty items produce no
+// output, unless they carry an error: FlatMap then emits a single empty item
+// carrying it.
+//
+// An unknown mode falls back to ByRune.
+func Expand[S Carrier[S]](by ExpandMode) ProcessorFunc[S] {
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		return FlatMap(ctx, in, func(ctx context.Context, item S) []S {
+			prototype := *new(S)
+			units := expandUnits(item.UTF8String(), by)
+			out := make([]S, len(units))
+			for i, unit := range units {
+				out[i] = prototype.FromUTF8String(unit)
+			}
+			return out
+		})
+	}
+}
+
+// expandUnits splits s into runes or grapheme clusters.
+func expandUnits(s UTF8String, by ExpandMode) []UTF8String {
+	units := make([]UTF8String, 0, utf8.RuneCountInString(s))
+	if by != ByGrapheme {
+		for len(s) > 0 {
+			_, size := utf8.DecodeRuneInString(s)
+			units = append(units, s[:size])
+			s = s[size:]
+		}
+		return units
+	}
+	data := []byte(s)
+	for len(data) > 0 {
+		advance, token, err := ScanGrapheme(data, true)
+		if err != nil || advance <= 0 {
+			// Defensive: never loop forever on a misbehaving split.
+			units = append(units, UTF8String(data))
+			break
+		}
+		units = append(units, UTF8String(token))
+		data = data[advance:]
+	}
+	return units
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func expandAll(t *testing.T, by ExpandMode, items ...StringCarrier) []StringCarrier {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, len(items))
+	for _, item := range items {
+		in <- item
+	}
+	close(in)
+	out, err := collectWithContext(ctx, Expand[StringCarrier](by).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return out
+}
+
+func TestExpand_ByRune(t *testing.T) {
+	out := expandAll(t, ByRune, StringCarrier{Value: "ab", Index: 3})
+	if len(out) != 2 {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), 2)
+	}
+	for i, want := range []string{"a", "b"} {
+		if out[i].Value != want {
+			t.Fatalf("unexpected unit %d: got %q want %q", i, out[i].Value, want)
+		}
+		if parent, pos := FlatMapIndex(out[i].Index); parent != 3 || pos != i {
+			t.Fatalf("unexpected index %d: got (%d, %d) want (%d, %d)", i, parent, pos, 3, i)
+		}
+	}
+}
+
+func TestExpand_ByGrapheme(t *testing.T) {
+	// "e" + combining acute accent, then a flag made of two regional indicators.
+	out := expandAll(t, ByGrapheme, StringCarrier{Value: "e\u0301\U0001F1EB\U0001F1F7!"})
+	want := []string{"e\u0301", "\U0001F1EB\U0001F1F7", "!"}
+	if len(out) != len(want) {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), len(want))
+	}
+	for i := range want {
+		if out[i].Value != want[i] {
+			t.Fatalf("unexpected unit %d: got %q want %q", i, out[i].Value, want[i])
+		}
+	}
+
+	runes := expandAll(t, ByRune, StringCarrier{Value: "e\u0301"})
+	if len(runes) != 2 {
+		t.Fatalf("unexpected rune count: got %d want %d", len(runes), 2)
+	}
+}
+
+func TestExpand_EmptyItemKeepsError(t *testing.T) {
+	boom := errors.New("boom")
+	out := expandAll(t, ByRune,
+		StringCarrier{Value: "", Index: 0},
+		StringCarrier{Value: "", Index: 1}.WithError(boom),
+	)
+	if len(out) != 1 {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), 1)
+	}
+	if out[0].Value != "" || !errors.Is(out[0].GetError(), boom) {
+		t.Fatalf("expected an empty unit carrying the error, got %#v", out[0])
+	}
+	if parent, pos := FlatMapIndex(out[0].Index); parent != 1 || pos != 0 {
+		t.Fatalf("unexpected index: got (%d, %d) want (%d, %d)", parent, pos, 1, 0)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// Filter returns a Processor that forwards the items for which keep returns
+// true and drops the others.
+//
+// Forwarded items are not modified: their indices are preserved, which means
+// the output stream has gaps where items were dropped. Downstream stages that
+// rely on indices must therefore be gap-tolerant (Aggregate is: it only sorts
+// by index).
+//
+// The stage is cancellation-aware: it stops when ctx is canceled. If keep is
+// nil, every item is forwarded.
+func Filter[S Carrier[S]](keep Predicate[S]) ProcessorFunc[S] {
+	if keep == nil {
+		return passThroughProcessor[S]()
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		return AsyncEmitter(ctx, in, func(ctx context.Context, item S, emit func(S)) {
+			if keep(ctx, item) {
+				emit(item)
+			}
+		})
+	}
+}
+
+// FilterOut is the inverse of Filter: it drops the items for which drop
+// returns true and forwards the others. If drop is nil, every item is
+// forwarded.
+func FilterOut[S Carrier[S]](drop Predicate[S]) ProcessorFunc[S] {
+	if drop == nil {
+		return passThroughProcessor[S]()
+	}
+	return Filter(func(ctx context.Context, item S) bool {
+		return !drop(ctx, item)
+	})
+}
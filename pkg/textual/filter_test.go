@@ -0,0 +1,66 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func hasPrefixPredicate(prefix string) Predicate[StringCarrier] {
+	return func(_ context.Context, s StringCarrier) bool {
+		return strings.HasPrefix(s.Value, prefix)
+	}
+}
+
+func filterInput() chan StringCarrier {
+	in := make(chan StringCarrier, 4)
+	for i, w := range []string{"go", "rust", "gopher", "zig"} {
+		in <- StringCarrier{Value: w, Index: i}
+	}
+	close(in)
+	return in
+}
+
+func TestFilter_KeepsPrefix(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	items, err := collectWithContext(ctx, Filter(hasPrefixPredicate("go")).Apply(ctx, filterInput()))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 2)
+	}
+	if items[0].Value != "go" || items[0].Index != 0 || items[1].Value != "gopher" || items[1].Index != 2 {
+		t.Fatalf("unexpected items (indices must be preserved): %#v", items)
+	}
+}
+
+func TestFilterOut_DropsPrefix(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	items, err := collectWithContext(ctx, FilterOut(hasPrefixPredicate("go")).Apply(ctx, filterInput()))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 2 || items[0].Value != "rust" || items[1].Value != "zig" || items[1].Index != 3 {
+		t.Fatalf("unexpected items: %#v", items)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// FlatMapStride is the number of sub-indices reserved for the outputs of each
+// input item by FlatMap.
+const FlatMapStride = 1 << 20
+
+// ErrFlatMapOverflow is attached to the outputs of FlatMap beyond FlatMapStride
+// for a single input item.
+var ErrFlatMapOverflow = errors.New("textual: FlatMap produced too many outputs for one input")
+
+// FlatMap splits each input item into zero, one or many outputs: every element
+// of the slice returned by f is emitted as a separate item, in order.
+//
+// It is built on AsyncEmitter, so inputs are processed sequentially and the
+// stage stops when ctx is canceled.
+//
+// Indexing scheme: the output at position pos of the input with index parent
+// gets the index parent*FlatMapStride + pos. Output indices therefore sort in
+// the original order (by parent, then by position), so Aggregate can still
+// reassemble the stream, and FlatMapIndex recovers (parent, pos). Parent
+// indices are expected to be non-negative. Outputs beyond FlatMapStride for a
+// single input would collide with the next parent: they are emitted with an
+// ErrFlatMapOverflow error attached.
+//
+// The error of an input item, if any, is attached to each of its outputs. When
+// f returns no output for an input carrying an error, a single zero-valued item
+// carrying that error is emitted at index parent*FlatMapStride, so errors are
+// never silently dropped.
+func FlatMap[S1 Carrier[S1], S2 Carrier[S2]](ctx context.Context, in <-chan S1, f func(context.Context, S1) []S2) <-chan S2 {
+	return AsyncEmitter(ctx, in, func(ctx context.Context, item S1, emit func(S2)) {
+		base := item.GetIndex() * FlatMapStride
+		outs := f(ctx, item)
+		if len(outs) == 0 && item.GetError() != nil {
+			// Keep the error flowing downstream.
+			emit((*new(S2)).WithIndex(base).WithError(item.GetError()))
+			return
+		}
+		for pos, out := range outs {
+			out = out.WithIndex(base + pos).WithError(item.GetError())
+			if pos >= FlatMapStride {
+				out = out.WithError(fmt.Errorf("%w: output %d of item %d", ErrFlatMapOverflow, pos, item.GetIndex()))
+			}
+			emit(out)
+		}
+	})
+}
+
+// FlatMapIndex splits an index assigned by FlatMap into the index of the
+// parent item and the position of the output within that parent.
+func FlatMapIndex(index int) (parent, pos int) {
+	return index / FlatMapStride, index % FlatMapStride
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func splitWords(_ context.Context, s StringCarrier) []StringCarrier {
+	words := strings.Fields(s.Value)
+	out := make([]StringCarrier, len(words))
+	for i, w := range words {
+		out[i] = StringCarrier{Value: w}
+	}
+	return out
+}
+
+func TestFlatMap_SplitsWithRecoverableOrder(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "d e", Index: 1}
+	in <- StringCarrier{Value: "a b c", Index: 0}
+	close(in)
+
+	items, err := collectWithContext(ctx, FlatMap(ctx, in, splitWords))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 5 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 5)
+	}
+
+	sortByIndex(items)
+	for i, want := range []string{"a", "b", "c", "d", "e"} {
+		if items[i].Value != want {
+			t.Fatalf("unexpected order at %d: got %q want %q", i, items[i].Value, want)
+		}
+	}
+	if parent, pos := FlatMapIndex(items[2].Index); parent != 0 || pos != 2 {
+		t.Fatalf("unexpected index decomposition: got (%d, %d) want (0, 2)", parent, pos)
+	}
+	if parent, pos := FlatMapIndex(items[4].Index); parent != 1 || pos != 1 {
+		t.Fatalf("unexpected index decomposition: got (%d, %d) want (1, 1)", parent, pos)
+	}
+
+	if got := Aggregate(items[:3]).Value; got != "abc" {
+		t.Fatalf("unexpected aggregate: got %q want %q", got, "abc")
+	}
+}
+
+func TestFlatMap_EmptyOutputKeepsError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	boom := errors.New("boom")
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "", Index: 0}
+	in <- StringCarrier{Value: "", Index: 2}.WithError(boom)
+	close(in)
+
+	out, err := collectWithContext(ctx, FlatMap(ctx, in, splitWords))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(out) != 1 {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), 1)
+	}
+	if out[0].Index != 2*FlatMapStride || !errors.Is(out[0].GetError(), boom) {
+		t.Fatalf("expected an item carrying the error at the parent base index, got %#v", out[0])
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// ErrForkJoinDropped marks, in the results passed to the combine function of
+// ForkJoin, the slot of a processor that dropped the item.
+var ErrForkJoinDropped = errors.New("textual: fork/join processor dropped the item")
+
+// ForkJoin returns a Processor that sends each input item to every processor
+// of procs and combines their outputs for that item into a single carrier.
+//
+// Outputs are correlated with their input by Index, so input indices are
+// expected to be unique. For each input, combine receives one result per
+// processor, in the order of procs: results[i] is the output of procs[i]. If
+// procs[i] dropped the item, results[i] is the input item carrying an
+// ErrForkJoinDropped error instead, so combine can tell the cases apart with
+// errors.Is. The combined carrier gets the index of the input.
+//
+// Processors are expected to emit at most one output per input and to
+// preserve the order, as Map or NewProcessorFunc do: when a processor emits
+// the output of an item, the earlier items it has not emitted are considered
+// dropped by it. Extra, late or unknown outputs are ignored. Combined items
+// are emitted in input order, as soon as every processor produced or dropped
+// its result.
+//
+// A panic in a processor's Apply (or a nil output channel) is recorded in the
+// context PanicStore, and that processor drops every item: more generally, once
+// a processor closes its output, it gets no more input and its slot carries
+// ErrForkJoinDropped for every pending and later item. A panic in combine is
+// recorded too, and the combined item is replaced by the input item carrying
+// an error. If procs is empty or combine is nil, items are passed through.
+func ForkJoin[S Carrier[S]](combine func(results []S) S, procs ...Processor[S]) ProcessorFunc[S] {
+	if combine == nil || len(procs) == 0 {
+		return passThroughProcessor[S]()
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		ctx, ps := EnsurePanicStore(ctx)
+
+		inputs := make([]chan S, len(procs))
+		outputs := make([]<-chan S, len(procs))
+		failed := make([]bool, len(procs))
+		gone := make([]chan struct{}, len(procs)) // closed once outputs[i] is closed
+		for i, p := range procs {
+			inputs[i] = make(chan S)
+			gone[i] = make(chan struct{})
+			var ok bool
+			outputs[i], ok = safeApplyProcessor(ctx, ps, p, inputs[i])
+			failed[i] = !ok
+		}
+
+		out := make(chan S)
+		fj := &forkJoin[S]{
+			ctx:     ctx,
+			ps:      ps,
+			out:     out,
+			combine: combine,
+			n:       len(procs),
+			failed:  failed,
+			entries: make(map[int]*forkJoinEntry[S]),
+		}
+		fj.turn = sync.NewCond(&fj.sendMu)
+
+		// The fork and every collector must be done before out is closed.
+		var wg sync.WaitGroup
+		wg.Add(len(procs) + 1)
+
+		// Fork: register each item, then send it to every processor.
+		go func() {
+			defer wg.Done()
+			defer func() {
+				for _, ch := range inputs {
+					safeCloseChan(ps, ch)
+				}
+			}()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-in:
+					if !ok {
+						return
+					}
+					fj.emit(fj.register(item))
+					for i, ch := range inputs {
+						// A processor whose output is closed may never read
+						// its input again (e.g. its Apply panicked): skip it.
+						select {
+						case <-ctx.Done():
+							return
+						case ch <- item:
+						case <-gone[i]:
+						}
+					}
+				}
+			}
+		}()
+
+		// Join: collect the outputs of every processor.
+		for i, ch := range outputs {
+			go func(i int, ch <-chan S) {
+				defer wg.Done()
+				for item := range ch {
+					fj.collect(i, item)
+				}
+				fj.retire(i)
+				close(gone[i])
+			}(i, ch)
+		}
+
+		go func() {
+			wg.Wait()
+			fj.finish()
+			close(out)
+		}()
+		return out
+	}
+}
+
+// forkJoinEntry holds the results gathered for one input item of ForkJoin.
+type forkJoinEntry[S Carrier[S]] struct {
+	input     S
+	results   []S
+	resolved  []bool
+	remaining int // processors that neither produced nor dropped the item
+	seq       int // emission rank, assigned when the entry is complete
+}
+
+// forkJoin is the join state of ForkJoin, shared by the fork goroutine and
+// the collectors.
+//
+// mu guards the pending entries. Complete entries are taken from the queue
+// under mu, then combined and sent after releasing it, one at a time in seq
+// order (sendMu, turn), so that a slow downstream never blocks the bookkeeping
+// of the other collectors.
+type forkJoin[S Carrier[S]] struct {
+	ctx     context.Context
+	ps      *PanicStore
+	out     chan<- S
+	combine func([]S) S
+	n       int
+	failed  []bool // processors whose output is closed: they drop every item
+
+	mu      sync.Mutex
+	entries map[int]*forkJoinEntry[S]
+	queue   []*forkJoinEntry[S] // pending entries, in input order
+	nextSeq int
+
+	sendMu  sync.Mutex
+	turn    *sync.Cond
+	sent    int  // entries sent or discarded so far
+	aborted bool // ctx was canceled during a send: discard the rest
+}
+
+// register adds a pending entry for item, with the slots of retired processors
+// already dropped, and returns the entries that are complete (when every
+// processor is retired).
+func (fj *forkJoin[S]) register(item S) []*forkJoinEntry[S] {
+	fj.mu.Lock()
+	defer fj.mu.Unlock()
+	e := &forkJoinEntry[S]{
+		input:     item,
+		results:   make([]S, fj.n),
+		resolved:  make([]bool, fj.n),
+		remaining: fj.n,
+	}
+	for i, failed := range fj.failed {
+		if failed {
+			fj.drop(e, i)
+		}
+	}
+	fj.entries[item.GetIndex()] = e
+	fj.queue = append(fj.queue, e)
+	return fj.takeReady()
+}
+
+// collect records the output of processor i and emits the entries that are
+// complete.
+func (fj *forkJoin[S]) collect(i int, item S) {
+	fj.mu.Lock()
+	e, ok := fj.entries[item.GetIndex()]
+	if !ok || e.resolved[i] {
+		fj.mu.Unlock()
+		return
+	}
+	// Processors preserve the order: the earlier items were dropped.
+	for _, earlier := range fj.queue {
+		if earlier == e {
+			break
+		}
+		if !earlier.resolved[i] {
+			fj.drop(earlier, i)
+		}
+	}
+	e.results[i], e.resolved[i] = item, true
+	e.remaining--
+	ready := fj.takeReady()
+	fj.mu.Unlock()
+	fj.emit(ready)
+}
+
+// retire marks processor i, whose output is closed, as dropping every pending
+// and future item, and emits the entries that are complete.
+func (fj *forkJoin[S]) retire(i int) {
+	fj.mu.Lock()
+	fj.failed[i] = true
+	for _, e := range fj.queue {
+		if !e.resolved[i] {
+			fj.drop(e, i)
+		}
+	}
+	ready := fj.takeReady()
+	fj.mu.Unlock()
+	fj.emit(ready)
+}
+
+// finish marks every missing result as dropped and emits what remains.
+func (fj *forkJoin[S]) finish() {
+	fj.mu.Lock()
+	for _, e := range fj.queue {
+		for i := range e.resolved {
+			if !e.resolved[i] {
+				fj.drop(e, i)
+			}
+		}
+	}
+	ready := fj.takeReady()
+	fj.mu.Unlock()
+	fj.emit(ready)
+}
+
+// drop fills the slot of processor i with the input item. fj.mu must be held.
+func (fj *forkJoin[S]) drop(e *forkJoinEntry[S], i int) {
+	err := fmt.Errorf("%w (processor %d, index %d)", ErrForkJoinDropped, i, e.input.GetIndex())
+	e.results[i], e.resolved[i] = e.input.WithError(err), true
+	e.remaining--
+}
+
+// takeReady removes the complete entries at the head of the queue and gives
+// them their emission rank. fj.mu must be held.
+func (fj *forkJoin[S]) takeReady() []*forkJoinEntry[S] {
+	var ready []*forkJoinEntry[S]
+	for len(fj.queue) > 0 && fj.queue[0].remaining == 0 {
+		e := fj.queue[0]
+		fj.queue[0] = nil
+		fj.queue = fj.queue[1:]
+		if idx := e.input.GetIndex(); fj.entries[idx] == e {
+			delete(fj.entries, idx)
+		}
+		e.seq = fj.nextSeq
+		fj.nextSeq++
+		ready = append(ready, e)
+	}
+	return ready
+}
+
+// emit combines and sends ready entries, waiting for the turn of each so that
+// outputs keep the input order. fj.mu must NOT be held. Once ctx is canceled,
+// entries are discarded instead, so collectors keep draining the processors.
+func (fj *forkJoin[S]) emit(ready []*forkJoinEntry[S]) {
+	for _, e := range ready {
+		fj.sendMu.Lock()
+		for fj.sent != e.seq {
+			fj.turn.Wait()
+		}
+		aborted := fj.aborted
+		fj.sendMu.Unlock()
+
+		// Only the entry whose turn it is gets here: sends are serialized.
+		if !aborted {
+			select {
+			case fj.out <- fj.safeCombine(e):
+			case <-fj.ctx.Done():
+				aborted = true
+			}
+		}
+
+		fj.sendMu.Lock()
+		fj.aborted = aborted
+		fj.sent++
+		fj.turn.Broadcast()
+		fj.sendMu.Unlock()
+	}
+}
+
+// safeCombine calls combine on the results of e. A panic is recorded into the
+// PanicStore and yields the input item carrying an error.
+func (fj *forkJoin[S]) safeCombine(e *forkJoinEntry[S]) (res S) {
+	idx := e.input.GetIndex()
+	defer func() {
+		if r := recover(); r != nil {
+			if fj.ps != nil {
+				fj.ps.Store(r, debug.Stack())
+			}
+			res = e.input.WithError(fmt.Errorf("fork/join (index %d): combine panic: %v", idx, r))
+		}
+	}()
+	return fj.combine(e.results).WithIndex(idx)
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// joinValues combines fork/join results by joining their values with "+",
+// rendering dropped results as "-".
+func joinValues(results []StringCarrier) StringCarrier {
+	parts := make([]string, len(results))
+	for i, r := range results {
+		if errors.Is(r.Error, ErrForkJoinDropped) {
+			parts[i] = "-"
+			continue
+		}
+		parts[i] = r.Value
+	}
+	return StringCarrier{Value: strings.Join(parts, "+")}
+}
+
+func TestForkJoin_CombinesSuffixes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 3)
+	for i, v := range []string{"a", "b", "c"} {
+		in <- StringCarrier{Value: v, Index: i}
+	}
+	close(in)
+
+	p := ForkJoin(joinValues, procSuffix("|1"), procSuffix("|2"))
+	out, err := collectWithContext(ctx, p.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"a|1+a|2", "b|1+b|2", "c|1+c|2"}
+	if len(out) != len(want) {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), len(want))
+	}
+	for i := range want {
+		if out[i].Value != want[i] || out[i].Index != i {
+			t.Fatalf("unexpected item %d: got %q (index %d) want %q", i, out[i].Value, out[i].Index, want[i])
+		}
+	}
+}
+
+func TestForkJoin_ProcessorDropsItems(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 4)
+	for i, v := range []string{"a", "b", "c", "d"} {
+		in <- StringCarrier{Value: v, Index: i}
+	}
+	close(in)
+
+	evenOnly := Filter(func(_ context.Context, item StringCarrier) bool { return item.Index%2 == 0 })
+	p := ForkJoin(joinValues, procSuffix("|1"), evenOnly)
+	out, err := collectWithContext(ctx, p.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"a|1+a", "b|1+-", "c|1+c", "d|1+-"}
+	if len(out) != len(want) {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), len(want))
+	}
+	for i := range want {
+		if out[i].Value != want[i] || out[i].Index != i {
+			t.Fatalf("unexpected item %d: got %q (index %d) want %q", i, out[i].Value, out[i].Index, want[i])
+		}
+	}
+}
+
+func TestForkJoin_CombinePanicIsRecorded(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ctx, ps := WithPanicStore(ctx)
+
+	in := make(chan StringCarrier, 3)
+	for i, v := range []string{"a", "boom", "c"} {
+		in <- StringCarrier{Value: v, Index: i}
+	}
+	close(in)
+
+	combine := func(results []StringCarrier) StringCarrier {
+		if strings.HasPrefix(results[0].Value, "boom") {
+			panic("combine failed")
+		}
+		return joinValues(results)
+	}
+	out, err := collectWithContext(ctx, ForkJoin(combine, procSuffix("|1"), procSuffix("|2")).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 3 {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), 3)
+	}
+	if out[0].Value != "a|1+a|2" || out[2].Value != "c|1+c|2" {
+		t.Fatalf("unexpected outputs: got %q and %q", out[0].Value, out[2].Value)
+	}
+	if out[1].Value != "boom" || out[1].Index != 1 || out[1].Error == nil {
+		t.Fatalf("unexpected item for the panicking combine: got %+v", out[1])
+	}
+	if info, ok := ps.Load(); !ok || info.Value != "combine failed" {
+		t.Fatalf("expected the combine panic in the PanicStore, got %+v (ok %v)", info, ok)
+	}
+}
+
+func TestForkJoin_ApplyPanicDropsEveryItem(t *testing.T) {
+	ctx, ps := WithPanicStore(context.Background())
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "a", Index: 0}
+	in <- StringCarrier{Value: "b", Index: 1}
+	close(in)
+
+	broken := ProcessorFunc[StringCarrier](func(context.Context, <-chan StringCarrier) <-chan StringCarrier {
+		panic("apply failed")
+	})
+	out := ForkJoin(joinValues, procSuffix("|1"), broken).Apply(ctx, in)
+
+	var got []string
+	deadline := time.After(2 * time.Second)
+	for done := false; !done; {
+		select {
+		case item, ok := <-out:
+			if !ok {
+				done = true
+				break
+			}
+			got = append(got, item.Value)
+		case <-deadline:
+			t.Fatalf("output not closed, got %q so far", got)
+		}
+	}
+	want := []string{"a|1+-", "b|1+-"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected outputs: got %q want %q", got, want)
+	}
+	if info, ok := ps.Load(); !ok || info.Value != "apply failed" {
+		t.Fatalf("expected the Apply panic in the PanicStore, got %+v (ok %v)", info, ok)
+	}
+}
+
+func TestForkJoin_EveryApplyPanics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 1)
+	in <- StringCarrier{Value: "a", Index: 0}
+	close(in)
+
+	broken := ProcessorFunc[StringCarrier](func(context.Context, <-chan StringCarrier) <-chan StringCarrier {
+		panic("apply failed")
+	})
+	out, err := collectWithContext(ctx, ForkJoin(joinValues, broken, broken).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 1 || out[0].Value != "-+-" {
+		t.Fatalf("unexpected outputs: %+v", out)
+	}
+}
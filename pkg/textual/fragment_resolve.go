@@ -0,0 +1,52 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+)
+
+// NewFragmentResolve returns a Processor enforcing the fragment invariants of
+// every Parcel (see Parcel.Validate), for pipelines where several stages
+// contribute fragments:
+//
+//   - fragments out of the text bounds are dropped, and an
+//     ErrFragmentOutOfBounds error is attached to the Parcel for each of them;
+//   - overlapping fragments are resolved with Parcel.ResolveOverlaps according
+//     to strategy. OverlapMaxConfidence keeps the non-overlapping set with the
+//     highest total confidence.
+//
+// Resolving overlaps is a repair, not a failure: no error is attached for it.
+// Parcels already valid are forwarded unchanged.
+func NewFragmentResolve(strategy OverlapStrategy) ProcessorFunc[Parcel] {
+	return NewProcessorFunc(func(_ context.Context, p Parcel) Parcel {
+		if p.Validate() == nil {
+			return p
+		}
+		textLen := utf8.RuneCountInString(p.Text)
+		fragments := make([]Fragment, 0, len(p.Fragments))
+		for _, f := range p.Fragments {
+			if f.Pos < 0 || f.Len < 0 || f.Pos+f.Len > textLen {
+				p = p.WithError(fmt.Errorf("%w: pos %d len %d (text length %d, index %d)", ErrFragmentOutOfBounds, f.Pos, f.Len, textLen, p.Index))
+				continue
+			}
+			fragments = append(fragments, f)
+		}
+		p.Fragments = fragments
+		return p.ResolveOverlaps(strategy)
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func resolveAll(t *testing.T, strategy OverlapStrategy, parcels ...Parcel) []Parcel {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan Parcel, len(parcels))
+	for _, p := range parcels {
+		in <- p
+	}
+	close(in)
+	out, err := collectWithContext(ctx, NewFragmentResolve(strategy).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return out
+}
+
+func TestNewFragmentResolve_KeepsConfidentFragments(t *testing.T) {
+	// "abcdef": the most confident fragment, over "bcd", overlaps two less
+	// confident ones over "ab" and "cd" whose total confidence is higher.
+	p := Parcel{Text: "abcdef", Fragments: []Fragment{
+		{Transformed: "[BCD]", Pos: 1, Len: 3, Confidence: 0.9},
+		{Transformed: "[AB]", Pos: 0, Len: 2, Confidence: 0.5},
+		{Transformed: "[CD]", Pos: 2, Len: 2, Confidence: 0.6},
+		{Transformed: "[F]", Pos: 5, Len: 1, Confidence: 0.1},
+	}}
+
+	cases := []struct {
+		strategy OverlapStrategy
+		want     UTF8String
+	}{
+		{OverlapKeepConfident, "a[BCD]e[F]"},
+		{OverlapMaxConfidence, "[AB][CD]e[F]"},
+	}
+	for _, c := range cases {
+		out := resolveAll(t, c.strategy, p)
+		if len(out) != 1 {
+			t.Fatalf("unexpected output count: got %d want %d", len(out), 1)
+		}
+		if err := out[0].Validate(); err != nil {
+			t.Fatalf("unexpected invalid parcel: %v", err)
+		}
+		if got := out[0].UTF8String(); got != c.want {
+			t.Fatalf("unexpected rendering (strategy %d): got %q want %q", c.strategy, got, c.want)
+		}
+	}
+	if len(p.Fragments) != 4 {
+		t.Fatalf("unexpected mutation of the input: %d fragments", len(p.Fragments))
+	}
+}
+
+func TestNewFragmentResolve_DropsOutOfBounds(t *testing.T) {
+	p := Parcel{Text: "abc", Fragments: []Fragment{
+		{Transformed: "X", Pos: 0, Len: 1, Confidence: 1},
+		{Transformed: "Y", Pos: 2, Len: 5, Confidence: 1},
+	}}
+	out := resolveAll(t, OverlapMaxConfidence, p)
+	if got, want := out[0].UTF8String(), UTF8String("Xbc"); got != want {
+		t.Fatalf("unexpected rendering: got %q want %q", got, want)
+	}
+	if !errors.Is(out[0].Error, ErrFragmentOutOfBounds) {
+		t.Fatalf("unexpected error: got %v want %v", out[0].Error, ErrFragmentOutOfBounds)
+	}
+}
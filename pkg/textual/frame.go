@@ -0,0 +1,197 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// FrameMaxSize is the largest frame payload NewFrameReader accepts, to protect
+// against corrupted length prefixes.
+const FrameMaxSize = 64 << 20
+
+// ErrFrameTooLarge is returned by FrameReader.Err for a frame larger than
+// FrameMaxSize, and attached by NewFrameWriter to the items it cannot frame.
+var ErrFrameTooLarge = errors.New("textual: frame too large")
+
+// NewFrameWriter returns a pass-through Processor writing each item to w as a
+// length-prefixed binary frame before forwarding it, so that a pipeline can
+// span processes (pipes, sockets): NewFrameReader decodes the frames on the
+// other side. The frame format is:
+//
+//	uvarint  payload length
+//	payload:
+//	  uvarint  text length, then the UTF8String bytes
+//	  varint   Index
+//	  uvarint  error message length, then the message bytes (0: no error)
+//
+// Only the rendering, the index and the error message cross the wire:
+// carrier-specific state (Parcel fragments, ...) is rebuilt by FromUTF8String,
+// and errors come back as plain errors with the same message (errors.Is
+// identity is lost).
+//
+// Each frame is written with a single Write call. On the first write error,
+// the error is attached to the item, and the following items are forwarded
+// without being written, carrying the same error. An item whose frame would
+// exceed FrameMaxSize is forwarded with an ErrFrameTooLarge error. w is not
+// closed: close it once the output is drained, so that the reader sees the end
+// of the stream.
+func NewFrameWriter[S Carrier[S]](w io.Writer) ProcessorFunc[S] {
+	var (
+		mu      sync.Mutex
+		failure error
+	)
+	return NewProcessorFunc(func(_ context.Context, item S) S {
+		mu.Lock()
+		defer mu.Unlock()
+		if failure != nil {
+			return item.WithError(failure)
+		}
+		frame, err := appendFrame(nil, item)
+		if err != nil {
+			return item.WithError(err)
+		}
+		if _, err := w.Write(frame); err != nil {
+			failure = fmt.Errorf("textual: frame write: %w", err)
+			return item.WithError(failure)
+		}
+		return item
+	})
+}
+
+// appendFrame appends the frame of item to b.
+func appendFrame[S Carrier[S]](b []byte, item S) ([]byte, error) {
+	text := item.UTF8String()
+	var msg string
+	if err := item.GetError(); err != nil {
+		msg = err.Error()
+	}
+	payload := make([]byte, 0, len(text)+len(msg)+3*binary.MaxVarintLen64)
+	payload = binary.AppendUvarint(payload, uint64(len(text)))
+	payload = append(payload, text...)
+	payload = binary.AppendVarint(payload, int64(item.GetIndex()))
+	payload = binary.AppendUvarint(payload, uint64(len(msg)))
+	payload = append(payload, msg...)
+	if len(payload) > FrameMaxSize {
+		return nil, fmt.Errorf("%w: %d bytes", ErrFrameTooLarge, len(payload))
+	}
+	b = binary.AppendUvarint(b, uint64(len(payload)))
+	return append(b, payload...), nil
+}
+
+// FrameReader decodes the frames written by NewFrameWriter (see its format)
+// into a stream of carriers.
+type FrameReader[S Carrier[S]] struct {
+	r   *bufio.Reader
+	mu  sync.Mutex
+	err error
+}
+
+// NewFrameReader returns a FrameReader reading frames from r.
+func NewFrameReader[S Carrier[S]](r io.Reader) *FrameReader[S] {
+	return &FrameReader[S]{r: bufio.NewReader(r)}
+}
+
+// Start decodes frames until the end of r and emits one carrier per frame,
+// built with FromUTF8String on the zero value of S. The output is closed at
+// the end of r, on a malformed frame (see Err), or when ctx is canceled. A
+// pending Read on r is not interrupted by ctx: close r to stop a blocked
+// reader.
+func (f *FrameReader[S]) Start(ctx context.Context) <-chan S {
+	out := make(chan S)
+	go func() {
+		defer close(out)
+		for {
+			item, err := f.next()
+			if err != nil {
+				if err != io.EOF {
+					f.mu.Lock()
+					f.err = err
+					f.mu.Unlock()
+				}
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- item:
+			}
+		}
+	}()
+	return out
+}
+
+// Err returns the error that stopped the reader, or nil after a clean end of
+// stream (end of r between two frames).
+func (f *FrameReader[S]) Err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// next decodes one frame. It returns io.EOF at a clean end of stream.
+func (f *FrameReader[S]) next() (S, error) {
+	var zero S
+	size, err := binary.ReadUvarint(f.r)
+	if err != nil {
+		return zero, err // io.EOF when no byte was read
+	}
+	if size > FrameMaxSize {
+		return zero, fmt.Errorf("%w: %d bytes", ErrFrameTooLarge, size)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(f.r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return zero, err
+	}
+
+	malformed := errors.New("textual: malformed frame")
+	field := func() ([]byte, error) {
+		n, k := binary.Uvarint(payload)
+		if k <= 0 || n > uint64(len(payload)-k) {
+			return nil, malformed
+		}
+		v := payload[k : k+int(n)]
+		payload = payload[k+int(n):]
+		return v, nil
+	}
+	text, err := field()
+	if err != nil {
+		return zero, err
+	}
+	index, k := binary.Varint(payload)
+	if k <= 0 {
+		return zero, malformed
+	}
+	payload = payload[k:]
+	msg, err := field()
+	if err != nil {
+		return zero, err
+	}
+
+	item := zero.FromUTF8String(UTF8String(text)).WithIndex(int(index))
+	if len(msg) > 0 {
+		item = item.WithError(errors.New(string(msg)))
+	}
+	return item, nil
+}
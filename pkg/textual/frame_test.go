@@ -0,0 +1,82 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestFrame_RoundTripOverPipe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	in := make(chan StringCarrier, 3)
+	in <- StringCarrier{Value: "héllo\nworld", Index: 0}
+	in <- StringCarrier{Value: "", Index: -5, Error: errors.New("boom: upstream failed")}
+	in <- StringCarrier{Value: "last", Index: 2}
+	close(in)
+
+	written := NewFrameWriter[StringCarrier](pw).Apply(ctx, in)
+	go func() {
+		// Drain the writer stage, then signal the end of the stream.
+		_, _ = collectWithContext(ctx, written)
+		_ = pw.Close()
+	}()
+
+	reader := NewFrameReader[StringCarrier](pr)
+	out, err := collectWithContext(ctx, reader.Start(ctx))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := reader.Err(); err != nil {
+		t.Fatalf("unexpected reader error: %v", err)
+	}
+	if len(out) != 3 {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), 3)
+	}
+	if out[0].Value != "héllo\nworld" || out[0].Index != 0 || out[0].Error != nil {
+		t.Fatalf("unexpected item 0: %+v", out[0])
+	}
+	if out[1].Value != "" || out[1].Index != -5 || out[1].Error == nil || out[1].Error.Error() != "boom: upstream failed" {
+		t.Fatalf("unexpected item 1: %+v", out[1])
+	}
+	if out[2].Value != "last" || out[2].Index != 2 {
+		t.Fatalf("unexpected item 2: %+v", out[2])
+	}
+}
+
+func TestFrameReader_TruncatedFrame(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	frame, err := appendFrame(nil, StringCarrier{Value: "abc", Index: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reader := NewFrameReader[StringCarrier](bytes.NewReader(frame[:len(frame)-1]))
+	out, err := collectWithContext(ctx, reader.Start(ctx))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 0 || !errors.Is(reader.Err(), io.ErrUnexpectedEOF) {
+		t.Fatalf("unexpected result: %d item(s), error %v", len(out), reader.Err())
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// StickBoth wraps inner between two transcoders, for the common
+// decode / process / re-encode sandwich (e.g. StringCarrier -> Parcel ->
+// Parcel -> StringCarrier). The resulting processor behaves like:
+//
+//	out := post.Apply(ctx, inner.Apply(ctx, pre.Apply(ctx, in)))
+//
+// A nil inner is ignored (pre feeds post directly). Since the result is a
+// ProcessorFunc, a panic raised while wiring the stages (including a nil pre or
+// post) is recorded into the PanicStore and a closed channel is returned.
+func StickBoth[A Carrier[A], B Carrier[B]](pre Transcoder[A, B], inner Processor[B], post Transcoder[B, A]) ProcessorFunc[A] {
+	return func(ctx context.Context, in <-chan A) <-chan A {
+		mid := pre.Apply(ctx, in)
+		if inner != nil {
+			mid = inner.Apply(ctx, mid)
+		}
+		return post.Apply(ctx, mid)
+	}
+}
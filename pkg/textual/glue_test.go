@@ -0,0 +1,85 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStickBoth_StringParcelSandwich(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	toParcel := NewTranscoderFunc(func(_ context.Context, s StringCarrier) Parcel {
+		return ParcelFrom(s.Value).WithIndex(s.Index).WithError(s.Error)
+	})
+	wrap := NewProcessorFunc(func(_ context.Context, p Parcel) Parcel {
+		p.Text = "[" + p.Text + "]"
+		return p
+	})
+	toString := NewTranscoderFunc(func(_ context.Context, p Parcel) StringCarrier {
+		return StringCarrier{Value: p.UTF8String(), Index: p.Index}.WithError(p.Error)
+	})
+
+	boom := errors.New("boom")
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "a", Index: 0}
+	in <- StringCarrier{Value: "b", Index: 1, Error: boom}
+	close(in)
+
+	items, err := collectWithContext(ctx, StickBoth[StringCarrier, Parcel](toParcel, wrap, toString).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	sortByIndex(items)
+	if len(items) != 2 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 2)
+	}
+	for i, want := range []string{"[a]", "[b]"} {
+		if got := items[i].Value; got != want {
+			t.Fatalf("unexpected item %d: got %q want %q", i, got, want)
+		}
+	}
+	if !errors.Is(items[1].GetError(), boom) {
+		t.Fatalf("unexpected error: got %v want %v", items[1].GetError(), boom)
+	}
+}
+
+func TestStickBoth_NilInner(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	toParcel := NewTranscoderFunc(func(_ context.Context, s StringCarrier) Parcel {
+		return ParcelFrom(s.Value).WithIndex(s.Index)
+	})
+	toString := NewTranscoderFunc(func(_ context.Context, p Parcel) StringCarrier {
+		return StringCarrier{Value: p.UTF8String(), Index: p.Index}
+	})
+
+	in := make(chan StringCarrier, 1)
+	in <- StringCarrier{Value: "x", Index: 7}
+	close(in)
+
+	items, err := collectWithContext(ctx, StickBoth[StringCarrier, Parcel](toParcel, nil, toString).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Value != "x" || items[0].Index != 7 {
+		t.Fatalf("unexpected output: got %+v", items)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"sort"
+)
+
+// GroupBy returns a Processor that partitions the stream by key and emits one
+// aggregated carrier per key when the input is closed.
+//
+// Items are buffered into per-key buckets. Once in is closed, each bucket is
+// merged with Aggregate (so per-item errors are joined and items are ordered by
+// index within their group), and the results are emitted sorted by key. The
+// emitted items are re-indexed 0, 1, 2, ... in key order, so the output is
+// deterministic.
+//
+// GroupBy is blocking: nothing is emitted before in is closed. When ctx is
+// canceled, the stage stops and nothing more is emitted. A panic in key is
+// recorded into the PanicStore and stops the stream, like Async.
+//
+// If key is nil, every item falls into the same group.
+func GroupBy[S Carrier[S]](key func(S) string) ProcessorFunc[S] {
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		buckets := make(map[string][]S)
+		return asyncEmitter(ctx, in, func(_ context.Context, item S, _ func(S)) {
+			k := ""
+			if key != nil {
+				k = key(item)
+			}
+			buckets[k] = append(buckets[k], item)
+		}, func(ctx context.Context, emit func(S)) {
+			keys := make([]string, 0, len(buckets))
+			for k := range buckets {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for i, k := range keys {
+				if ctx.Err() != nil {
+					return
+				}
+				emit(Aggregate(buckets[k]).WithIndex(i))
+			}
+		})
+	}
+}
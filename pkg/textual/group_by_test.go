@@ -0,0 +1,105 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGroupBy_FirstColumn(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	records := []string{
+		"fruit,apple",
+		"vegetable,leek",
+		"fruit,pear",
+		"dairy,milk",
+		"vegetable,kale",
+	}
+	in := make(chan CsvCarrier, len(records))
+	for i, r := range records {
+		in <- CsvCarrier{Value: r, Index: i}
+	}
+	close(in)
+
+	firstColumn := func(c CsvCarrier) string {
+		k, _, _ := strings.Cut(c.Value, ",")
+		return k
+	}
+	items, err := collectWithContext(ctx, GroupBy(firstColumn).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+
+	want := []string{
+		"dairy,milk",
+		"fruit,apple\nfruit,pear",
+		"vegetable,leek\nvegetable,kale",
+	}
+	if len(items) != len(want) {
+		t.Fatalf("unexpected group count: got %d want %d", len(items), len(want))
+	}
+	for i, w := range want {
+		if items[i].Value != w || items[i].Index != i {
+			t.Fatalf("unexpected group %d: got %q (index %d) want %q", i, items[i].Value, items[i].Index, w)
+		}
+	}
+}
+
+func TestGroupBy_CanceledStops(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan StringCarrier, 3)
+	for i := 0; i < 3; i++ {
+		in <- StringCarrier{Value: "a", Index: i}
+	}
+	out := GroupBy(func(s StringCarrier) string { return s.Value }).Apply(ctx, in)
+	cancel()
+
+	select {
+	case item, ok := <-out:
+		if ok {
+			t.Fatalf("unexpected item after cancellation: %#v", item)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("output not closed after cancellation")
+	}
+}
+
+func TestGroupBy_KeyPanicIsRecorded(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ctx, ps := WithPanicStore(ctx)
+
+	in := make(chan StringCarrier, 1)
+	in <- StringCarrier{Value: "a"}
+	close(in)
+
+	out := GroupBy(func(StringCarrier) string { panic("bad key") }).Apply(ctx, in)
+	items, err := collectWithContext(ctx, out)
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("unexpected output: got %+v", items)
+	}
+	if info, ok := ps.Load(); !ok || info.Value != "bad key" {
+		t.Fatalf("expected the key panic in the PanicStore, got %+v (ok %v)", info, ok)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Histogram counts the values observed by a NewHistogram stage. It is safe for
+// concurrent use. Read it with Snapshot.
+type Histogram struct {
+	bins []float64
+
+	mu        sync.Mutex
+	counts    []int64
+	underflow int64
+	overflow  int64
+	skipped   int64
+}
+
+// HistogramSnapshot is a point-in-time copy of a Histogram.
+type HistogramSnapshot struct {
+	Bins      []float64 // Bin boundaries, as given to NewHistogram.
+	Counts    []int64   // Counts[i] is the number of values v with Bins[i] <= v < Bins[i+1].
+	Underflow int64     // Values lower than Bins[0].
+	Overflow  int64     // Values greater than or equal to the last boundary.
+	Skipped   int64     // Items without a number at path (or carrying an error).
+}
+
+// Total returns the number of values counted, Skipped excepted.
+func (s HistogramSnapshot) Total() int64 {
+	total := s.Underflow + s.Overflow
+	for _, c := range s.Counts {
+		total += c
+	}
+	return total
+}
+
+// Snapshot returns a copy of the current counts.
+func (h *Histogram) Snapshot() HistogramSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return HistogramSnapshot{
+		Bins:      append([]float64(nil), h.bins...),
+		Counts:    append([]int64(nil), h.counts...),
+		Underflow: h.underflow,
+		Overflow:  h.overflow,
+		Skipped:   h.skipped,
+	}
+}
+
+// observe counts v.
+func (h *Histogram) observe(v float64, ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	switch {
+	case !ok:
+		h.skipped++
+	case v < h.bins[0]:
+		h.underflow++
+	case v >= h.bins[len(h.bins)-1]:
+		h.overflow++
+	default:
+		// Bins[i] <= v < Bins[i+1], with i+1 the first boundary above v.
+		i := sort.Search(len(h.bins), func(i int) bool { return h.bins[i] > v }) - 1
+		h.counts[i]++
+	}
+}
+
+// NewHistogram returns a Processor that forwards every JSON value unchanged
+// while counting the number at path, an RFC 6901 JSON Pointer ("/latency";
+// "" for a value that is itself a number), into the bins delimited by the
+// boundaries bins. Read the counts with the returned Histogram, during or
+// after the run.
+//
+// Bins are half-open: with bins [0, 10, 100], the buckets are [0, 10) and
+// [10, 100); values below 0 are counted as underflow, values from 100 up as
+// overflow. Items carrying an error, invalid JSON and values without a number
+// at path are counted as skipped. Every Apply call of the Processor counts
+// into the same Histogram.
+//
+// NewHistogram panics if path is not a valid JSON Pointer or if bins has less
+// than two boundaries or is not strictly increasing.
+func NewHistogram(path string, bins []float64) (ProcessorFunc[JsonCarrier], *Histogram) {
+	tokens, err := parseJSONPointer(path)
+	if err != nil {
+		panic(fmt.Sprintf("textual: NewHistogram: %v", err))
+	}
+	if len(bins) < 2 {
+		panic("textual: NewHistogram: at least two bin boundaries are required")
+	}
+	for i := 1; i < len(bins); i++ {
+		if !(bins[i] > bins[i-1]) {
+			panic(fmt.Sprintf("textual: NewHistogram: bin boundaries must be strictly increasing, got %v", bins))
+		}
+	}
+	h := &Histogram{
+		bins:   append([]float64(nil), bins...),
+		counts: make([]int64, len(bins)-1),
+	}
+	return NewProcessorFunc(func(_ context.Context, item JsonCarrier) JsonCarrier {
+		h.observe(jsonNumberAt(item, tokens))
+		return item
+	}), h
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestHistogram_KnownDistribution(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	values := []string{"-1", "0", "3", "9.99", "10", "42", "99", "100", "250", `"n/a"`}
+	in := make(chan JsonCarrier, len(values)+1)
+	for i, v := range values {
+		in <- JsonCarrier{Value: json.RawMessage(fmt.Sprintf(`{"latency":%s}`, v)), Index: i}
+	}
+	in <- JsonCarrier{Value: json.RawMessage(`{}`), Index: len(values)}
+	close(in)
+
+	p, h := NewHistogram("/latency", []float64{0, 10, 100})
+	out, err := collectWithContext(ctx, p.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != len(values)+1 {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), len(values)+1)
+	}
+
+	s := h.Snapshot()
+	if s.Counts[0] != 3 || s.Counts[1] != 3 {
+		t.Fatalf("unexpected counts: got %v want %v", s.Counts, []int64{3, 3})
+	}
+	if s.Underflow != 1 || s.Overflow != 2 || s.Skipped != 2 {
+		t.Fatalf("unexpected underflow/overflow/skipped: got %d/%d/%d want 1/2/2", s.Underflow, s.Overflow, s.Skipped)
+	}
+	if s.Total() != 9 {
+		t.Fatalf("unexpected total: got %d want %d", s.Total(), 9)
+	}
+}
+
+func TestHistogram_RejectsInvalidBins(t *testing.T) {
+	for _, bins := range [][]float64{nil, {1}, {0, 0}, {2, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic for bins %v", bins)
+				}
+			}()
+			NewHistogram("/v", bins)
+		}()
+	}
+}
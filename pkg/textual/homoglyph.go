@@ -0,0 +1,123 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrMixedScriptConfusable is attached (as a per-item warning) by
+// NewHomoglyphNormalize (when flagMixedScript is true) if a word mixes scripts
+// and contains confusable characters, a typical sign of a spoofed domain or
+// user name.
+var ErrMixedScriptConfusable = errors.New("textual: mixed-script confusable")
+
+// NewHomoglyphNormalize returns a Processor that maps confusable characters to
+// their Latin look-alike, producing a "skeleton" in the spirit of Unicode
+// Technical Standard #39 (e.g. Cyrillic "а" U+0430 becomes Latin "a").
+//
+// The mapping covers the Cyrillic and Greek letters that are visually
+// identical to Latin letters, and the fullwidth ASCII forms. It is a curated
+// subset of the Unicode confusables data, not the full table.
+//
+// When flagMixedScript is true, every word (run of letters) that mixes several
+// scripts and contains at least one confusable character is reported before
+// normalization: the output item carries an ErrMixedScriptConfusable warning
+// (via WithError) listing the offending words. Items with a single script per
+// word (e.g. plain Russian text) are normalized without warning. When
+// flagMixedScript is false, items are only normalized.
+//
+// Index is preserved.
+func NewHomoglyphNormalize[S Carrier[S]](flagMixedScript bool) ProcessorFunc[S] {
+	return NewProcessorFunc(func(_ context.Context, item S) S {
+		text := item.UTF8String()
+		res := item.FromUTF8String(HomoglyphSkeleton(text)).WithIndex(item.GetIndex())
+		res = res.WithError(item.GetError())
+		if !flagMixedScript {
+			return res
+		}
+		if words := mixedScriptConfusables(text); len(words) > 0 {
+			res = res.WithError(fmt.Errorf("%w: %s", ErrMixedScriptConfusable, strings.Join(words, ", ")))
+		}
+		return res
+	})
+}
+
+// HomoglyphSkeleton maps every confusable rune of s to its Latin look-alike.
+func HomoglyphSkeleton(s UTF8String) UTF8String {
+	return UTF8String(strings.Map(func(r rune) rune {
+		if m, ok := homoglyphs[r]; ok {
+			return m
+		}
+		if r >= 0xFF01 && r <= 0xFF5E {
+			// Fullwidth ASCII forms.
+			return r - 0xFEE0
+		}
+		return r
+	}, string(s)))
+}
+
+// mixedScriptConfusables returns the words of s mixing scripts and containing
+// at least one confusable rune, each described as `word (Script1+Script2)`.
+func mixedScriptConfusables(s UTF8String) []string {
+	var found []string
+	runes := []rune(string(s))
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+		start := i
+		scripts := map[string]bool{}
+		confusable := false
+		for i < len(runes) && isWordRune(runes[i]) {
+			if name := scriptOf(runes[i]); name != "Common" && name != "Inherited" {
+				scripts[name] = true
+			}
+			if _, ok := homoglyphs[runes[i]]; ok {
+				confusable = true
+			}
+			i++
+		}
+		if confusable && len(scripts) > 1 {
+			names := make([]string, 0, len(scripts))
+			for n := range scripts {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			found = append(found, fmt.Sprintf("%s (%s)", string(runes[start:i]), strings.Join(names, "+")))
+		}
+	}
+	return found
+}
+
+// homoglyphs maps confusable Cyrillic and Greek letters to Latin.
+var homoglyphs = map[rune]rune{
+	// Cyrillic lowercase.
+	'а': 'a', 'с': 'c', 'ԁ': 'd', 'е': 'e', 'һ': 'h', 'і': 'i', 'ј': 'j', 'о': 'o',
+	'р': 'p', 'ԛ': 'q', 'ѕ': 's', 'у': 'y', 'ԝ': 'w', 'х': 'x', 'ү': 'y',
+	// Cyrillic uppercase.
+	'А': 'A', 'В': 'B', 'С': 'C', 'Е': 'E', 'Н': 'H', 'І': 'I', 'Ј': 'J', 'К': 'K',
+	'М': 'M', 'О': 'O', 'Р': 'P', 'Ѕ': 'S', 'Т': 'T', 'Х': 'X', 'Ү': 'Y', 'Ԛ': 'Q', 'Ԝ': 'W',
+	// Greek lowercase.
+	'α': 'a', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'υ': 'u',
+	// Greek uppercase.
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M',
+	'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHomoglyphNormalize_FlagsCyrillicInLatinWord(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// "pаypal" with a Cyrillic "а" (U+0430), then a genuine Russian word.
+	spoofed := "p\u0430ypal.com"
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: spoofed, Index: 0}
+	in <- StringCarrier{Value: "привет", Index: 1}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewHomoglyphNormalize[StringCarrier](true).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	sortByIndex(items)
+	if len(items) != 2 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 2)
+	}
+
+	if items[0].Value != "paypal.com" {
+		t.Fatalf("unexpected skeleton: got %q want %q", items[0].Value, "paypal.com")
+	}
+	if err := items[0].GetError(); !errors.Is(err, ErrMixedScriptConfusable) || !strings.Contains(err.Error(), "Cyrillic+Latin") {
+		t.Fatalf("expected a mixed-script warning, got %v", err)
+	}
+
+	if items[1].GetError() != nil {
+		t.Fatalf("single-script word should not be flagged: %v", items[1].GetError())
+	}
+}
+
+func TestNewHomoglyphNormalize_WithoutFlagging(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 1)
+	in <- StringCarrier{Value: "p\u0430ypal.com", Index: 0}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewHomoglyphNormalize[StringCarrier](false).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 1)
+	}
+	if items[0].Value != "paypal.com" {
+		t.Fatalf("unexpected skeleton: got %q want %q", items[0].Value, "paypal.com")
+	}
+	if err := items[0].GetError(); err != nil {
+		t.Fatalf("unexpected warning with flagging disabled: %v", err)
+	}
+}
+
+func TestHomoglyphSkeleton_FullwidthAndGreek(t *testing.T) {
+	if got, want := HomoglyphSkeleton("ＡＢＣ ΟΚ"), UTF8String("ABC OK"); got != want {
+		t.Fatalf("unexpected skeleton: got %q want %q", got, want)
+	}
+}
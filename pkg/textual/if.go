@@ -58,7 +58,7 @@ must preserve the index itself (typically by calling WithIndex on the output).
 // processor (If / ELSEIf) or the fallback processor (ELSE).
 //
 // Configure it during pipeline construction; mutating it while Apply is running
-// is not concurrency-safe (same as Router).
+// is not concurrency-safe.
 type ConditionalProc[S Carrier[S]] struct {
 	branches      []ifBranch[S]
 	elseProcessor Processor[S]
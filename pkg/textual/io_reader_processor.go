@@ -65,6 +65,8 @@ import (
 // Start / StartWithTimeout spawn a goroutine that scans the input and feeds the
 // processor's input channel. Stop cancels the context, which should cause the
 // processor and the scanner goroutine to exit promptly.
+// Drain, instead, only stops the scanner and lets in-flight items be emitted
+// before canceling.
 //
 // The generic type parameter S is the carrier flowing through the pipeline (see
 // Carrier). P is the concrete processor type.
@@ -85,6 +87,16 @@ type IOReaderProcessor[S Carrier[S], P Processor[S]] struct {
 	// panicStore is the PanicStore carried by ctx (either inherited from the
 	// provided context or created internally).
 	panicStore *PanicStore
+
+	// stopSource stops the scanning goroutine only (see Drain), and done is
+	// closed once the output channel returned by Start has been closed. Both
+	// are nil until Start is called.
+	stopSource context.CancelFunc
+	done       chan struct{}
+
+	// progress, when set, receives the number of bytes read so far from
+	// reader (see SetProgress).
+	progress func(bytesRead int64)
 }
 
 // NewIOReaderProcessor constructs a new IOReaderProcessor using the provided
@@ -145,6 +157,19 @@ func (p *IOReaderProcessor[S, P]) SetSplitFunc(splitFunc bufio.SplitFunc) {
 	p.splitFunc = splitFunc
 }
 
+// SetProgress registers a callback receiving the number of bytes consumed from
+// the reader so far, to drive a progress bar.
+//
+// It must be called before Start / StartWithTimeout. The reader is wrapped in a
+// counting reader that only counts bytes, so the split func and the UTF-8
+// handling are unchanged. The callback runs on the scanning goroutine after
+// each scanned token and once more at the end of input, when it reports the
+// total length. As the scanner reads ahead, the count can be ahead of the
+// tokens emitted so far. A nil callback disables reporting.
+func (p *IOReaderProcessor[S, P]) SetProgress(progress func(bytesRead int64)) {
+	p.progress = progress
+}
+
 // ensureContext initializes ctx / cancel if needed and ensures a PanicStore is attached.
 //
 // When a context has been injected via SetContext, it is reused. If ctx is nil,
@@ -182,11 +207,18 @@ func (p *IOReaderProcessor[S, P]) ensureContext() {
 func (p *IOReaderProcessor[S, P]) Start() <-chan S {
 	p.ensureContext()
 
-	scanner := bufio.NewScanner(p.reader)
+	reader, report := withByteCount(p.reader, p.progress)
+	scanner := bufio.NewScanner(reader)
 	if p.splitFunc != nil {
 		scanner.Split(p.splitFunc)
 	}
 
+	// The source context lets downstream stages stop the scanning gracefully
+	// (see StopSource) without canceling the whole pipeline.
+	sourceCtx, stopSource := context.WithCancel(p.ctx)
+	p.stopSource = stopSource
+	p.done = make(chan struct{})
+
 	// Channel feeding the underlying processor.
 	in := make(chan S)
 
@@ -209,7 +241,7 @@ func (p *IOReaderProcessor[S, P]) Start() <-chan S {
 			}
 		}()
 
-		out = p.processor.Apply(p.ctx, in)
+		out = p.processor.Apply(WithSourceStop(p.ctx, stopSource), in)
 		if out == nil {
 			panic("textual: Processor.Apply returned a nil channel")
 		}
@@ -219,10 +251,16 @@ func (p *IOReaderProcessor[S, P]) Start() <-chan S {
 	// Goroutine responsible for scanning and feeding the input channel.
 	go func() {
 		prototype := *new(S)
+		log := LoggerFromContext(p.ctx)
+		counter := 0
+
+		// Release the source context once scanning is over.
+		defer stopSource()
 
 		// One finalizer handles both normal completion and panic recovery.
 		defer func() {
 			if r := recover(); r != nil {
+				log.Warnf("textual: IOReaderProcessor recovered a panic after %d token(s): %v", counter, r)
 				if ps := PanicStoreFromContext(p.ctx); ps != nil {
 					ps.Store(r, debug.Stack())
 				}
@@ -248,21 +286,38 @@ func (p *IOReaderProcessor[S, P]) Start() <-chan S {
 			}()
 		}()
 
-		counter := 0
+		// logStop reports why scanning stopped early: either the pipeline
+		// context was canceled or a downstream stage stopped the source.
+		logStop := func() {
+			if p.ctx.Err() != nil {
+				log.Debugf("textual: IOReaderProcessor canceled after %d token(s)", counter)
+			} else {
+				log.Debugf("textual: IOReaderProcessor source stopped after %d token(s)", counter)
+			}
+		}
+
 		for {
-			// Check for cancellation before attempting to scan.
+			// Check for cancellation (or a source stop) before attempting to scan.
 			select {
-			case <-p.ctx.Done():
+			case <-sourceCtx.Done():
+				logStop()
 				return
 			default:
 				// Continue to scanning.
 			}
 
 			// Perform one scan step.
-			if !scanner.Scan() {
+			scanned := scanner.Scan()
+			report()
+			if !scanned {
 				// scanner.Scan() returned false: EOF or error.
 				// scanner.Err() can be inspected here if a dedicated
 				// error-reporting mechanism is added in the future.
+				if err := scanner.Err(); err != nil {
+					log.Warnf("textual: IOReaderProcessor scan error after %d token(s): %v", counter, err)
+				} else {
+					log.Debugf("textual: IOReaderProcessor reached end of input after %d token(s)", counter)
+				}
 				return
 			}
 
@@ -272,15 +327,16 @@ func (p *IOReaderProcessor[S, P]) Start() <-chan S {
 
 			// Send the value to the processor, remaining cancellable.
 			select {
-			case <-p.ctx.Done():
-				// Context canceled while we were trying to send.
+			case <-sourceCtx.Done():
+				// Context canceled (or source stopped) while we were trying to send.
+				logStop()
 				return
 			case in <- item:
 				// Successfully sent to processor.
 			}
 		}
 	}()
-	return out
+	return notifyOnClose(p.ctx, out, p.done)
 }
 
 // StartWithTimeout is like Start but automatically cancels the context when
@@ -302,6 +358,29 @@ func (p *IOReaderProcessor[S, P]) StartWithTimeout(timeout time.Duration) <-chan
 	return p.Start()
 }
 
+// Drain stops reading new tokens but lets the items already read finish: the
+// scanning goroutine stops at the next token boundary and closes the processor
+// input, while the processing context stays alive so in-flight items are fully
+// emitted.
+//
+// Drain blocks until the output channel returned by Start has been closed or
+// timeout elapses, then cancels the context (aborting whatever is still in
+// flight on timeout). The output must keep being consumed meanwhile, typically
+// from another goroutine. If timeout <= 0, Drain waits until the output is
+// closed.
+//
+// A reader blocked in Read delays the stop until Read returns. It is safe to
+// call Drain even if Start / StartWithTimeout has not been invoked yet; in that
+// case it is a no-op.
+func (p *IOReaderProcessor[S, P]) Drain(timeout time.Duration) {
+	if p.stopSource == nil {
+		return
+	}
+	p.stopSource()
+	waitClosed(p.done, timeout)
+	p.Stop()
+}
+
 // Stop cancels the current processing context, if any.
 //
 // It is safe to call Stop even if Start / StartWithTimeout has not been
@@ -311,3 +390,65 @@ func (p *IOReaderProcessor[S, P]) Stop() {
 		p.cancel()
 	}
 }
+
+// notifyOnClose forwards every value of in to the returned channel and closes
+// done once both are over. It lets Drain observe the end of a stage output it
+// does not own.
+//
+// When ctx is canceled, remaining values are drained (but not forwarded) so
+// that the stage is never blocked on send.
+func notifyOnClose[S any](ctx context.Context, in <-chan S, done chan<- struct{}) <-chan S {
+	out := make(chan S)
+	go func() {
+		defer close(done)
+		defer close(out)
+		for v := range in {
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				for range in {
+				}
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// waitClosed blocks until done is closed or timeout elapses. If timeout <= 0,
+// it waits for done only.
+func waitClosed(done <-chan struct{}, timeout time.Duration) {
+	if timeout <= 0 {
+		<-done
+		return
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+	}
+}
+
+// withByteCount wraps r so that report calls progress with the number of
+// bytes read so far. When progress is nil, r is returned as-is and report is a
+// no-op. The returned reader and report must be used from the same goroutine.
+func withByteCount(r io.Reader, progress func(int64)) (io.Reader, func()) {
+	if progress == nil {
+		return r, func() {}
+	}
+	cr := &byteCounter{r: r}
+	return cr, func() { progress(cr.n) }
+}
+
+// byteCounter is an io.Reader counting the bytes read from r.
+type byteCounter struct {
+	r io.Reader
+	n int64
+}
+
+func (c *byteCounter) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.n += int64(n)
+	return n, err
+}
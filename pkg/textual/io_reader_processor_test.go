@@ -18,6 +18,7 @@ import (
 	"bufio"
 	"context"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -95,3 +96,101 @@ func TestIOReaderProcessor_CustomSplit_ReconstructsInput(t *testing.T) {
 		t.Fatalf("reconstructed text mismatch:\n got: %q\nwant: %q", got, input)
 	}
 }
+
+// endlessLines is an io.Reader producing "x\n" forever.
+type endlessLines struct{}
+
+func (endlessLines) Read(b []byte) (int, error) {
+	for i := range b {
+		if i%2 == 0 {
+			b[i] = 'x'
+		} else {
+			b[i] = '\n'
+		}
+	}
+	return len(b) - len(b)%2, nil
+}
+
+func TestIOReaderProcessor_Drain_EmitsInFlightItems(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// A slow processor, so items are in flight when Drain is called.
+	var received atomic.Int64
+	seen := make(chan struct{}, 1)
+	slow := ProcessorFunc[StringCarrier](func(ctx context.Context, in <-chan StringCarrier) <-chan StringCarrier {
+		return Async(ctx, in, func(ctx context.Context, s StringCarrier) StringCarrier {
+			if received.Add(1) == 3 {
+				seen <- struct{}{}
+			}
+			time.Sleep(5 * time.Millisecond)
+			s.Value = "done"
+			return s
+		})
+	})
+
+	p := NewIOReaderProcessor[StringCarrier](slow, endlessLines{})
+	p.SetContext(ctx)
+	out := p.Start()
+
+	type result struct {
+		items []StringCarrier
+		err   error
+	}
+	collected := make(chan result, 1)
+	go func() {
+		items, err := collectWithContext(ctx, out)
+		collected <- result{items, err}
+	}()
+
+	<-seen
+	start := time.Now()
+	p.Drain(time.Second)
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Fatalf("unexpected Drain timeout: took %v", elapsed)
+	}
+
+	res := <-collected
+	if res.err != nil {
+		t.Fatalf("unexpected error: %v", res.err)
+	}
+	if got, want := int64(len(res.items)), received.Load(); got != want {
+		t.Fatalf("unexpected output count: got %d want %d", got, want)
+	}
+	for i, item := range res.items {
+		if item.Index != i || item.Value != "done" {
+			t.Fatalf("unexpected item %d: got %#v", i, item)
+		}
+	}
+}
+
+func TestIOReaderProcessor_SetProgress_ReportsTotal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	input := "héllo\nwörld\nlast line without newline"
+	var calls, last int64
+	p := NewIOReaderProcessor[StringCarrier](passThroughProcessor[StringCarrier](), strings.NewReader(input))
+	p.SetContext(ctx)
+	p.SetProgress(func(bytesRead int64) {
+		if bytesRead < last {
+			t.Errorf("unexpected decreasing progress: got %d after %d", bytesRead, last)
+		}
+		calls++
+		last = bytesRead
+	})
+
+	items, err := collectWithContext(ctx, p.Start())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 3)
+	}
+	if last != int64(len(input)) {
+		t.Fatalf("unexpected final progress: got %d want %d", last, len(input))
+	}
+	if calls < 4 {
+		t.Fatalf("unexpected callback count: got %d want at least %d", calls, 4)
+	}
+}
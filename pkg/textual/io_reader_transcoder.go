@@ -65,6 +65,8 @@ import (
 // Start / StartWithTimeout spawn a goroutine that scans the input and feeds the
 // transcoder's input channel. Stop cancels the context, which should cause the
 // transcoder and the scanner goroutine to exit promptly.
+// Drain, instead, only stops the scanner and lets in-flight items be emitted
+// before canceling.
 //
 // The generic type parameters S1 and S2 are the carriers flowing through the
 // transcoding stage (see Carrier). T is the concrete transcoder type.
@@ -85,6 +87,16 @@ type IOReaderTranscoder[S1 Carrier[S1], S2 Carrier[S2], T Transcoder[S1, S2]] st
 	// panicStore is the PanicStore carried by ctx (either inherited from the
 	// provided context or created internally).
 	panicStore *PanicStore
+
+	// stopSource stops the scanning goroutine only (see Drain), and done is
+	// closed once the output channel returned by Start has been closed. Both
+	// are nil until Start is called.
+	stopSource context.CancelFunc
+	done       chan struct{}
+
+	// progress, when set, receives the number of bytes read so far from
+	// reader (see SetProgress).
+	progress func(bytesRead int64)
 }
 
 // NewIOReaderTranscoder constructs a new IOReaderTranscoder using the provided
@@ -145,6 +157,19 @@ func (t *IOReaderTranscoder[S1, S2, T]) SetSplitFunc(splitFunc bufio.SplitFunc)
 	t.splitFunc = splitFunc
 }
 
+// SetProgress registers a callback receiving the number of bytes consumed from
+// the reader so far, to drive a progress bar.
+//
+// It must be called before Start / StartWithTimeout. The reader is wrapped in a
+// counting reader that only counts bytes, so the split func and the UTF-8
+// handling are unchanged. The callback runs on the scanning goroutine after
+// each scanned token and once more at the end of input, when it reports the
+// total length. As the scanner reads ahead, the count can be ahead of the
+// tokens emitted so far. A nil callback disables reporting.
+func (t *IOReaderTranscoder[S1, S2, T]) SetProgress(progress func(bytesRead int64)) {
+	t.progress = progress
+}
+
 // ensureContext initializes ctx / cancel if needed and ensures a PanicStore is attached.
 //
 // When a context has been injected via SetContext, it is reused. If ctx is nil,
@@ -182,11 +207,18 @@ func (t *IOReaderTranscoder[S1, S2, T]) ensureContext() {
 func (t *IOReaderTranscoder[S1, S2, T]) Start() <-chan S2 {
 	t.ensureContext()
 
-	scanner := bufio.NewScanner(t.reader)
+	reader, report := withByteCount(t.reader, t.progress)
+	scanner := bufio.NewScanner(reader)
 	if t.splitFunc != nil {
 		scanner.Split(t.splitFunc)
 	}
 
+	// The source context lets downstream stages stop the scanning gracefully
+	// (see StopSource) without canceling the whole pipeline.
+	sourceCtx, stopSource := context.WithCancel(t.ctx)
+	t.stopSource = stopSource
+	t.done = make(chan struct{})
+
 	// Channel feeding the underlying transcoder.
 	in := make(chan S1)
 
@@ -209,7 +241,7 @@ func (t *IOReaderTranscoder[S1, S2, T]) Start() <-chan S2 {
 			}
 		}()
 
-		out = t.transcoder.Apply(t.ctx, in)
+		out = t.transcoder.Apply(WithSourceStop(t.ctx, stopSource), in)
 		if out == nil {
 			panic("textual: Transcoder.Apply returned a nil channel")
 		}
@@ -219,10 +251,16 @@ func (t *IOReaderTranscoder[S1, S2, T]) Start() <-chan S2 {
 	// Goroutine responsible for scanning and feeding the input channel.
 	go func() {
 		prototype := *new(S1)
+		log := LoggerFromContext(t.ctx)
+		counter := 0
+
+		// Release the source context once scanning is over.
+		defer stopSource()
 
 		// One finalizer handles both normal completion and panic recovery.
 		defer func() {
 			if r := recover(); r != nil {
+				log.Warnf("textual: IOReaderTranscoder recovered a panic after %d token(s): %v", counter, r)
 				if ps := PanicStoreFromContext(t.ctx); ps != nil {
 					ps.Store(r, debug.Stack())
 				}
@@ -248,21 +286,38 @@ func (t *IOReaderTranscoder[S1, S2, T]) Start() <-chan S2 {
 			}()
 		}()
 
-		counter := 0
+		// logStop reports why scanning stopped early: either the pipeline
+		// context was canceled or a downstream stage stopped the source.
+		logStop := func() {
+			if t.ctx.Err() != nil {
+				log.Debugf("textual: IOReaderTranscoder canceled after %d token(s)", counter)
+			} else {
+				log.Debugf("textual: IOReaderTranscoder source stopped after %d token(s)", counter)
+			}
+		}
+
 		for {
-			// Check for cancellation before attempting to scan.
+			// Check for cancellation (or a source stop) before attempting to scan.
 			select {
-			case <-t.ctx.Done():
+			case <-sourceCtx.Done():
+				logStop()
 				return
 			default:
 				// Continue to scanning.
 			}
 
 			// Perform one scan step.
-			if !scanner.Scan() {
+			scanned := scanner.Scan()
+			report()
+			if !scanned {
 				// scanner.Scan() returned false: EOF or error.
 				// scanner.Err() can be inspected here if a dedicated
 				// error-reporting mechanism is added in the future.
+				if err := scanner.Err(); err != nil {
+					log.Warnf("textual: IOReaderTranscoder scan error after %d token(s): %v", counter, err)
+				} else {
+					log.Debugf("textual: IOReaderTranscoder reached end of input after %d token(s)", counter)
+				}
 				return
 			}
 
@@ -272,8 +327,9 @@ func (t *IOReaderTranscoder[S1, S2, T]) Start() <-chan S2 {
 
 			// Send the value to the transcoder, remaining cancellable.
 			select {
-			case <-t.ctx.Done():
-				// Context canceled while we were trying to send.
+			case <-sourceCtx.Done():
+				// Context canceled (or source stopped) while we were trying to send.
+				logStop()
 				return
 			case in <- item:
 				// Successfully sent to transcoder.
@@ -281,7 +337,7 @@ func (t *IOReaderTranscoder[S1, S2, T]) Start() <-chan S2 {
 		}
 	}()
 
-	return out
+	return notifyOnClose(t.ctx, out, t.done)
 }
 
 // StartWithTimeout is like Start but automatically cancels the context when
@@ -303,6 +359,29 @@ func (t *IOReaderTranscoder[S1, S2, T]) StartWithTimeout(timeout time.Duration)
 	return t.Start()
 }
 
+// Drain stops reading new tokens but lets the items already read finish: the
+// scanning goroutine stops at the next token boundary and closes the transcoder
+// input, while the transcoding context stays alive so in-flight items are fully
+// emitted.
+//
+// Drain blocks until the output channel returned by Start has been closed or
+// timeout elapses, then cancels the context (aborting whatever is still in
+// flight on timeout). The output must keep being consumed meanwhile, typically
+// from another goroutine. If timeout <= 0, Drain waits until the output is
+// closed.
+//
+// A reader blocked in Read delays the stop until Read returns. It is safe to
+// call Drain even if Start / StartWithTimeout has not been invoked yet; in that
+// case it is a no-op.
+func (t *IOReaderTranscoder[S1, S2, T]) Drain(timeout time.Duration) {
+	if t.stopSource == nil {
+		return
+	}
+	t.stopSource()
+	waitClosed(t.done, timeout)
+	t.Stop()
+}
+
 // Stop cancels the current transcoding context, if any.
 //
 // It is safe to call Stop even if Start / StartWithTimeout has not been
@@ -116,3 +116,31 @@ func TestIOReaderTranscoder_CustomSplit_ScanJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestIOReaderTranscoder_Drain_TimeoutCancels(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// A transcoder stuck on its first item until the context is canceled.
+	stuck := TranscoderFunc[StringCarrier, Parcel](func(ctx context.Context, in <-chan StringCarrier) <-chan Parcel {
+		return Async(ctx, in, func(ctx context.Context, s StringCarrier) Parcel {
+			<-ctx.Done()
+			return Parcel{}.FromUTF8String(s.Value).WithIndex(s.Index)
+		})
+	})
+
+	ioT := NewIOReaderTranscoder[StringCarrier](stuck, endlessLines{})
+	ioT.SetContext(ctx)
+	out := ioT.Start()
+
+	collected := make(chan error, 1)
+	go func() {
+		_, err := collectWithContext(ctx, out)
+		collected <- err
+	}()
+
+	ioT.Drain(20 * time.Millisecond)
+	if err := <-collected; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
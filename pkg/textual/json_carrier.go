@@ -15,6 +15,7 @@
 package textual
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 )
@@ -72,3 +73,32 @@ func (s JsonCarrier) WithError(err error) JsonCarrier {
 func (s JsonCarrier) GetError() error {
 	return s.Error
 }
+
+// Aggregate concatenates multiple JsonCarrier values into a single JSON array
+// after stably sorting them by Index:
+//
+//	[ <value0>, <value1>, ... ]
+//
+// Empty values are rendered as null so that positions are preserved.
+// The result carries the first index and the joined per-item errors.
+func (s JsonCarrier) Aggregate(items []JsonCarrier) JsonCarrier {
+	if len(items) == 0 {
+		return JsonCarrier{Value: json.RawMessage("[]")}
+	}
+	sorted := sortedByIndex(items)
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i, it := range sorted {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if len(bytes.TrimSpace(it.Value)) == 0 {
+			b.WriteString("null")
+			continue
+		}
+		b.Write(it.Value)
+	}
+	b.WriteByte(']')
+	res := JsonCarrier{Value: json.RawMessage(b.Bytes()), Index: sorted[0].Index}
+	return withJoinedErrors(res, sorted)
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrJSONPathNotFound reports that a JSON value has nothing at the path given
+// to ExtractJSON.
+var ErrJSONPathNotFound = errors.New("textual: JSON path not found")
+
+// jsonPathStep is one step of an ExtractJSON path: an object key, or an array
+// index when isIndex is true.
+type jsonPathStep struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// ExtractJSON returns a Transcoder extracting the value at path from each JSON
+// value and decoding it into a typed carrier.
+//
+// path is a dotted path with bracketed array indexes: "a.b.c", "items[2].id",
+// "[0].name" (the value is itself an array), "matrix[1][0]"; "" selects the
+// whole value. Keys containing '.' or '[' cannot be addressed (use a JSON
+// Pointer with NewJSONPatch or NewTopK for those).
+//
+// Only the containers along the path are decoded, one level at a time, as
+// json.RawMessage: siblings are skipped, not unmarshaled. The extracted value is
+// then unmarshaled into T.
+//
+// Index and error are preserved. When the path is missing (absent key, index
+// out of range, or a step through a scalar), the output holds the zero Value
+// and an error wrapping ErrJSONPathNotFound. When the input is not valid JSON or
+// the extracted value cannot be unmarshaled into T, the error matches
+// ErrTranscode.
+//
+// ExtractJSON panics if path is malformed.
+func ExtractJSON[T any](path string) TranscoderFunc[JsonCarrier, JsonGenericCarrier[T]] {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		panic(fmt.Sprintf("textual: ExtractJSON: %v", err))
+	}
+	return NewTranscoderFunc(func(_ context.Context, j JsonCarrier) JsonGenericCarrier[T] {
+		res := JsonGenericCarrier[T]{Index: j.Index}.WithError(j.Error)
+		raw, err := extractJSONRaw(j.Value, steps)
+		if errors.Is(err, ErrInvalidJSON) {
+			return res.WithError(transcodeErrorf("extract json (index %d): %w", j.Index, err))
+		}
+		if err != nil {
+			return res.WithError(fmt.Errorf("extract json (index %d): %w", j.Index, err))
+		}
+		if err := json.Unmarshal(raw, &res.Value); err != nil {
+			return res.WithError(transcodeErrorf("extract json (index %d): %q: %w", j.Index, path, err))
+		}
+		return res
+	})
+}
+
+// parseJSONPath parses an ExtractJSON path.
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	if path == "" {
+		return nil, nil
+	}
+	var steps []jsonPathStep
+	for i, part := range strings.Split(path, ".") {
+		key, indexes := part, ""
+		if j := strings.IndexByte(part, '['); j >= 0 {
+			key, indexes = part[:j], part[j:]
+		}
+		if key == "" && (i > 0 || indexes == "") {
+			return nil, fmt.Errorf("invalid path %q: empty key", path)
+		}
+		if strings.ContainsRune(key, ']') {
+			return nil, fmt.Errorf("invalid path %q: unexpected ']'", path)
+		}
+		if key != "" {
+			steps = append(steps, jsonPathStep{key: key})
+		}
+		for indexes != "" {
+			end := strings.IndexByte(indexes, ']')
+			if indexes[0] != '[' || end < 0 {
+				return nil, fmt.Errorf("invalid path %q: malformed index", path)
+			}
+			digits := indexes[1:end]
+			if digits == "" || strings.Trim(digits, "0123456789") != "" {
+				return nil, fmt.Errorf("invalid path %q: bad index [%s]", path, digits)
+			}
+			n, err := strconv.Atoi(digits)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: bad index [%s]", path, digits)
+			}
+			steps = append(steps, jsonPathStep{index: n, isIndex: true})
+			indexes = indexes[end+1:]
+		}
+	}
+	return steps, nil
+}
+
+// extractJSONRaw walks steps into raw, decoding only the containers along the
+// way.
+func extractJSONRaw(raw json.RawMessage, steps []jsonPathStep) (json.RawMessage, error) {
+	if !json.Valid(raw) {
+		return nil, ErrInvalidJSON
+	}
+	var at strings.Builder // The path walked so far, for error messages.
+	for _, step := range steps {
+		parent := at.String()
+		if step.isIndex {
+			at.WriteString("[" + strconv.Itoa(step.index) + "]")
+			var elems []json.RawMessage
+			if err := json.Unmarshal(raw, &elems); err != nil || elems == nil {
+				return nil, fmt.Errorf("%w: %q is not an array", ErrJSONPathNotFound, parent)
+			}
+			if step.index >= len(elems) {
+				return nil, fmt.Errorf("%w: %q (array length %d)", ErrJSONPathNotFound, at.String(), len(elems))
+			}
+			raw = elems[step.index]
+			continue
+		}
+		if at.Len() > 0 {
+			at.WriteByte('.')
+		}
+		at.WriteString(step.key)
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+			return nil, fmt.Errorf("%w: %q is not an object", ErrJSONPathNotFound, parent)
+		}
+		value, ok := fields[step.key]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrJSONPathNotFound, at.String())
+		}
+		raw = value
+	}
+	return raw, nil
+}
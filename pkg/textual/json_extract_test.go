@@ -0,0 +1,125 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func runExtractJSON[T any](t *testing.T, path string, values ...string) []JsonGenericCarrier[T] {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan JsonCarrier, len(values))
+	for i, v := range values {
+		in <- JsonCarrier{Value: json.RawMessage(v), Index: i}
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, ExtractJSON[T](path).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != len(values) {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), len(values))
+	}
+	return items
+}
+
+func TestExtractJSON_NestedObjects(t *testing.T) {
+	items := runExtractJSON[string](t, "user.address.city",
+		`{"id":1,"user":{"name":"Ada","address":{"city":"London","zip":"N1"}}}`)
+	if err := items[0].GetError(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := items[0].Value, "London"; got != want {
+		t.Fatalf("unexpected value: got %q want %q", got, want)
+	}
+
+	type address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip"`
+	}
+	typed := runExtractJSON[address](t, "user.address",
+		`{"user":{"address":{"city":"Paris","zip":"75001"}}}`)
+	if got, want := typed[0].Value, (address{City: "Paris", Zip: "75001"}); got != want {
+		t.Fatalf("unexpected value: got %+v want %+v", got, want)
+	}
+
+	whole := runExtractJSON[map[string]int](t, "", `{"a":1}`)
+	if got := whole[0].Value["a"]; got != 1 {
+		t.Fatalf("unexpected whole value: got %d want %d", got, 1)
+	}
+}
+
+func TestExtractJSON_ArrayIndices(t *testing.T) {
+	items := runExtractJSON[int](t, "orders[1].lines[0].qty",
+		`{"orders":[{"lines":[]},{"lines":[{"qty":3},{"qty":4}]}]}`)
+	if err := items[0].GetError(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := items[0].Value, 3; got != want {
+		t.Fatalf("unexpected value: got %d want %d", got, want)
+	}
+
+	matrix := runExtractJSON[int](t, "[1][0]", `[[1,2],[3,4]]`)
+	if got, want := matrix[0].Value, 3; got != want {
+		t.Fatalf("unexpected value: got %d want %d", got, want)
+	}
+}
+
+func TestExtractJSON_MissingPaths(t *testing.T) {
+	items := runExtractJSON[string](t, "a.b[2].c",
+		`{"a":{"b":[{"c":"x"}]}}`,
+		`{"a":{"x":1}}`,
+		`{"a":"scalar"}`,
+		`{"a":{"b":[1,2,{"d":1}]}}`,
+		`not json`,
+	)
+	for i, item := range items {
+		if !errors.Is(item.GetError(), ErrJSONPathNotFound) && !errors.Is(item.GetError(), ErrInvalidJSON) {
+			t.Fatalf("item %d: unexpected error: got %v want %v", i, item.GetError(), ErrJSONPathNotFound)
+		}
+		if item.Value != "" || item.Index != i {
+			t.Fatalf("item %d: unexpected carrier: got %+v", i, item)
+		}
+	}
+	if !errors.Is(items[4].GetError(), ErrInvalidJSON) {
+		t.Fatalf("unexpected error for invalid JSON: got %v want %v", items[4].GetError(), ErrInvalidJSON)
+	}
+
+	mistyped := runExtractJSON[int](t, "a", `{"a":"x"}`)
+	if !errors.Is(mistyped[0].GetError(), ErrTranscode) {
+		t.Fatalf("unexpected error for a mistyped value: got %v want %v", mistyped[0].GetError(), ErrTranscode)
+	}
+}
+
+func TestExtractJSON_InvalidPathPanics(t *testing.T) {
+	for _, path := range []string{"a..b", "a[", "a[x]", "a[-1]", "a]", ".a", "a[1]b"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic for path %q", path)
+				}
+			}()
+			ExtractJSON[any](path)
+		}()
+	}
+}
@@ -15,20 +15,39 @@
 package textual
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 )
 
 // JsonGenericCarrier is a generic Carrier that encodes a typed Value with "json".
-// It is the typed counterpart of JsonCarrier
+// It is the typed counterpart of JsonCarrier.
+//
+//   - UTF8String marshals Value (not the whole carrier), unless Raw is set.
+//   - FromUTF8String unmarshals the text into Value. When the text cannot be
+//     unmarshaled into T, the returned carrier holds the zero Value and carries
+//     the unmarshal error (see GetError); it never panics.
+//   - Aggregate builds a JSON array of the Values (sorted by index) and keeps
+//     it in Raw. The array is also decoded into Value when T can hold it (a
+//     slice, any, json.RawMessage, ...); for other types (e.g. a struct),
+//     Value stays zero and Raw is the only holder of the aggregated data.
 type JsonGenericCarrier[T any] struct {
 	Value T     `json:"value"`
 	Index int   `json:"index,omitempty"`
 	Error error `json:"error,omitempty"`
+	// Raw holds the JSON array built by Aggregate. When set, UTF8String
+	// returns it instead of the encoding of Value.
+	Raw UTF8String `json:"raw,omitempty"`
 }
 
+// UTF8String returns Raw when set, the JSON encoding of Value otherwise.
+//
+// If Value cannot be marshaled, the marshal error message is returned.
 func (s JsonGenericCarrier[T]) UTF8String() UTF8String {
-	b, err := json.Marshal(s)
+	if s.Raw != "" {
+		return s.Raw
+	}
+	b, err := json.Marshal(s.Value)
 	if err != nil {
 		return err.Error()
 	}
@@ -36,7 +55,6 @@ func (s JsonGenericCarrier[T]) UTF8String() UTF8String {
 }
 
 func (s JsonGenericCarrier[T]) FromUTF8String(str UTF8String) JsonGenericCarrier[T] {
-	// Note: no JsonCarrier validation is performed here; the carrier only transports bytes.
 	proto := *new(JsonGenericCarrier[T])
 	err := json.Unmarshal([]byte(str), &proto.Value)
 	if err != nil {
@@ -69,3 +87,44 @@ func (s JsonGenericCarrier[T]) WithError(err error) JsonGenericCarrier[T] {
 func (s JsonGenericCarrier[T]) GetError() error {
 	return s.Error
 }
+
+// Aggregate merges items into a single carrier whose Raw is the JSON array of
+// the item Values, stably sorted by Index:
+//
+//	[ <value0>, <value1>, ... ]
+//
+// Values that cannot be marshaled are rendered as null. The array is decoded
+// into Value when T can hold it, and Value is left zero otherwise. The result
+// carries the first index and the joined per-item errors.
+func (s JsonGenericCarrier[T]) Aggregate(items []JsonGenericCarrier[T]) JsonGenericCarrier[T] {
+	if len(items) == 0 {
+		return aggregatedJSON[T]("[]")
+	}
+	sorted := sortedByIndex(items)
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i, it := range sorted {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		v, err := json.Marshal(it.Value)
+		if err != nil {
+			b.WriteString("null")
+			continue
+		}
+		b.Write(v)
+	}
+	b.WriteByte(']')
+	res := aggregatedJSON[T](UTF8String(b.String())).WithIndex(sorted[0].Index)
+	return withJoinedErrors(res, sorted)
+}
+
+// aggregatedJSON returns a carrier holding the JSON array raw, decoded into
+// Value when T can hold it.
+func aggregatedJSON[T any](raw UTF8String) JsonGenericCarrier[T] {
+	res := JsonGenericCarrier[T]{Raw: raw}
+	if err := json.Unmarshal([]byte(raw), &res.Value); err != nil {
+		res.Value = *new(T)
+	}
+	return res
+}
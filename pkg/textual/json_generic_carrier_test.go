@@ -0,0 +1,96 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type jsonGenericPoint struct {
+	X int    `json:"x"`
+	Y int    `json:"y"`
+	L string `json:"label,omitempty"`
+}
+
+func TestJsonGenericCarrier_RoundTrip(t *testing.T) {
+	in := JsonGenericCarrier[jsonGenericPoint]{Value: jsonGenericPoint{X: 1, Y: 2, L: "a"}, Index: 4}
+
+	s := in.UTF8String()
+	if want := `{"x":1,"y":2,"label":"a"}`; string(s) != want {
+		t.Fatalf("unexpected rendering: got %q want %q", s, want)
+	}
+
+	out := JSONCarrierFrom[jsonGenericPoint](s)
+	if out.GetError() != nil {
+		t.Fatalf("unexpected error: %v", out.GetError())
+	}
+	if out.Value != in.Value {
+		t.Fatalf("unexpected value: got %#v want %#v", out.Value, in.Value)
+	}
+}
+
+func TestJsonGenericCarrier_UnmarshalFailureIsCarried(t *testing.T) {
+	out := JSONCarrierFrom[jsonGenericPoint](`["not", "a", "point"]`)
+	if out.GetError() == nil {
+		t.Fatalf("expected the unmarshal error to be carried")
+	}
+	if out.Value != (jsonGenericPoint{}) {
+		t.Fatalf("expected the zero value, got %#v", out.Value)
+	}
+}
+
+func TestJsonGenericCarrier_AggregateBuildsSortedArray(t *testing.T) {
+	items := []JsonGenericCarrier[any]{
+		{Value: map[string]any{"x": 2}, Index: 2},
+		{Value: map[string]any{"x": 0}, Index: 0},
+		{Value: map[string]any{"x": 1}, Index: 1},
+	}
+
+	res := Aggregate(items)
+	if res.GetError() != nil {
+		t.Fatalf("unexpected error: %v", res.GetError())
+	}
+	if res.GetIndex() != 0 {
+		t.Fatalf("unexpected index: got %d want %d", res.GetIndex(), 0)
+	}
+	if got, want := string(res.UTF8String()), `[{"x":0},{"x":1},{"x":2}]`; got != want {
+		t.Fatalf("unexpected aggregate: got %q want %q", got, want)
+	}
+
+}
+
+func TestJsonGenericCarrier_AggregateKeepsStructValues(t *testing.T) {
+	points := []JsonGenericCarrier[jsonGenericPoint]{
+		{Value: jsonGenericPoint{X: 2, Y: 3}, Index: 1},
+		{Value: jsonGenericPoint{X: 1, L: "a"}, Index: 0},
+	}
+
+	// A struct cannot hold an array: the data is kept in Raw.
+	res := Aggregate(points)
+	if res.GetError() != nil {
+		t.Fatalf("unexpected error: %v", res.GetError())
+	}
+	if got, want := string(res.UTF8String()), `[{"x":1,"y":0,"label":"a"},{"x":2,"y":3}]`; got != want {
+		t.Fatalf("unexpected aggregate: got %q want %q", got, want)
+	}
+	var decoded []jsonGenericPoint
+	if err := json.Unmarshal([]byte(res.Raw), &decoded); err != nil || len(decoded) != 2 || decoded[1].Y != 3 {
+		t.Fatalf("unexpected raw array: %q (%v)", res.Raw, err)
+	}
+	if res.Value != (jsonGenericPoint{}) {
+		t.Fatalf("expected the zero value, got %#v", res.Value)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// JsonLinesCarrier is a JsonCarrier whose fan-in produces JSON Lines (NDJSON)
+// instead of a JSON array.
+//
+// It has the same fields as JsonCarrier, so both convert into each other
+// (JsonLinesCarrier(c), JsonCarrier(l)). Use it to read NDJSON (framed with
+// ScanJSON or ScanLines), transform the values and emit NDJSON again.
+//
+// Aggregate joins the values with "\n", one value per line.
+type JsonLinesCarrier JsonCarrier
+
+func (s JsonLinesCarrier) UTF8String() UTF8String {
+	return UTF8String(s.Value)
+}
+
+func (s JsonLinesCarrier) FromUTF8String(str UTF8String) JsonLinesCarrier {
+	// Note: no JSON validation is performed here; the carrier only transports bytes.
+	return JsonLinesCarrier{
+		Value: json.RawMessage([]byte(str)),
+		Index: 0,
+		Error: nil,
+	}
+}
+
+func (s JsonLinesCarrier) WithIndex(idx int) JsonLinesCarrier {
+	s.Index = idx
+	return s
+}
+
+func (s JsonLinesCarrier) GetIndex() int {
+	return s.Index
+}
+
+func (s JsonLinesCarrier) WithError(err error) JsonLinesCarrier {
+	if err == nil {
+		return s
+	}
+	if s.Error == nil {
+		s.Error = err
+	} else {
+		s.Error = errors.Join(s.Error, err)
+	}
+	return s
+}
+
+func (s JsonLinesCarrier) GetError() error {
+	return s.Error
+}
+
+// Aggregate joins multiple values into JSON Lines after stably sorting them by
+// Index:
+//
+//	<value0>
+//	<value1>
+//	...
+//
+// There are no enclosing brackets and no trailing newline. Each value is
+// compacted so that it fits on its own line (invalid JSON is kept as is, only
+// trimmed). Empty values are rendered as null so that positions are preserved.
+// The result carries the first index and the joined per-item errors.
+func (s JsonLinesCarrier) Aggregate(items []JsonLinesCarrier) JsonLinesCarrier {
+	if len(items) == 0 {
+		return JsonLinesCarrier{Value: json.RawMessage{}}
+	}
+	sorted := sortedByIndex(items)
+	var b bytes.Buffer
+	for i, it := range sorted {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		value := bytes.TrimSpace(it.Value)
+		if len(value) == 0 {
+			b.WriteString("null")
+			continue
+		}
+		if err := json.Compact(&b, value); err != nil {
+			b.Write(value)
+		}
+	}
+	res := JsonLinesCarrier{Value: json.RawMessage(b.Bytes()), Index: sorted[0].Index}
+	return withJoinedErrors(res, sorted)
+}
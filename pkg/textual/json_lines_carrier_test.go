@@ -0,0 +1,63 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJsonLinesCarrier_AggregateOneObjectPerLine(t *testing.T) {
+	items := []JsonLinesCarrier{
+		{Value: json.RawMessage("{\n  \"b\": [1, 2]\n}"), Index: 1},
+		{Value: json.RawMessage(`{"a": 1}`), Index: 0},
+		{Value: json.RawMessage(""), Index: 2},
+		{Value: json.RawMessage(" {broken "), Index: 3},
+	}
+	got := string(Aggregate(items).Value)
+	want := "{\"a\":1}\n{\"b\":[1,2]}\nnull\n{broken"
+	if got != want {
+		t.Fatalf("unexpected aggregate: got %q want %q", got, want)
+	}
+	if strings.HasPrefix(got, "[") || strings.HasSuffix(got, "]") {
+		t.Fatalf("unexpected enclosing brackets: %q", got)
+	}
+}
+
+func TestJsonLinesCarrier_NDJSONRoundTrip(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	input := "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n"
+	reader := NewIOReaderProcessor[JsonLinesCarrier](passThroughProcessor[JsonLinesCarrier](), strings.NewReader(input))
+	reader.SetContext(ctx)
+	reader.SetSplitFunc(ScanJSON)
+
+	out, err := collectWithContext(ctx, reader.Start())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, item := range out {
+		if item.Error != nil {
+			t.Fatalf("unexpected item error: %v", item.Error)
+		}
+	}
+	if got := string(Aggregate(out).Value) + "\n"; got != input {
+		t.Fatalf("unexpected round trip: got %q want %q", got, input)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrJSONMergePatch is attached by NewJSONMergePatch to the items it could not
+// patch.
+var ErrJSONMergePatch = errors.New("textual: JSON merge patch failed")
+
+// NewJSONMergePatch returns a Processor applying the RFC 7386 JSON Merge Patch
+// patch to each JSON value, typically to overlay configuration objects.
+//
+// Objects are merged recursively: a member of patch replaces the member of the
+// same name, a null member deletes it, and an object member is merged into the
+// existing one (or into an empty object when the existing value is not an
+// object). A patch that is not an object replaces the whole value. Arrays are
+// replaced, not merged.
+//
+// Patched values are re-encoded compactly: numbers are kept verbatim but
+// object keys are sorted. Invalid JSON, in the item or in patch, yields an
+// ErrJSONMergePatch error and leaves the item unchanged. Index and error are
+// preserved.
+func NewJSONMergePatch(patch json.RawMessage) ProcessorFunc[JsonCarrier] {
+	p, invalid := decodeJSONValue(patch)
+	if invalid != nil {
+		invalid = fmt.Errorf("%w: invalid patch: %v", ErrJSONMergePatch, invalid)
+	}
+	return NewProcessorFunc(func(_ context.Context, item JsonCarrier) JsonCarrier {
+		if invalid != nil {
+			return item.WithError(invalid)
+		}
+		target, err := decodeJSONValue(item.Value)
+		if err != nil {
+			return item.WithError(fmt.Errorf("%w: invalid JSON: %v", ErrJSONMergePatch, err))
+		}
+		merged, err := encodeJSONValue(mergePatchJSON(target, p))
+		if err != nil {
+			return item.WithError(fmt.Errorf("%w: %v", ErrJSONMergePatch, err))
+		}
+		item.Value = merged
+		return item
+	})
+}
+
+// mergePatchJSON implements the MergePatch function of RFC 7386 on decoded
+// JSON values. target is modified in place; patch is never modified.
+func mergePatchJSON(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return deepCopyJSON(patch)
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any, len(p))
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatchJSON(t[k], v)
+	}
+	return t
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJSONMergePatch_NestedMergeAndDeletion(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	patch := json.RawMessage(`{"server":{"port":8443,"tls":{"enabled":true},"debug":null},"tags":["b"],"legacy":null}`)
+	in := make(chan JsonCarrier, 3)
+	in <- JsonCarrier{Value: json.RawMessage(`{"server":{"host":"a","port":80,"debug":true},"tags":["a"],"legacy":1}`), Index: 0}
+	in <- JsonCarrier{Value: json.RawMessage(`{"server":"off"}`), Index: 1}
+	in <- JsonCarrier{Value: json.RawMessage(`{"server":`), Index: 2}
+	close(in)
+
+	out, err := collectWithContext(ctx, NewJSONMergePatch(patch).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 3 {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), 3)
+	}
+	want := []string{
+		`{"server":{"host":"a","port":8443,"tls":{"enabled":true}},"tags":["b"]}`,
+		`{"server":{"port":8443,"tls":{"enabled":true}},"tags":["b"]}`,
+	}
+	for i, w := range want {
+		if out[i].Error != nil || string(out[i].Value) != w {
+			t.Fatalf("unexpected item %d: got %s (error %v) want %s", i, out[i].Value, out[i].Error, w)
+		}
+	}
+	if !errors.Is(out[2].Error, ErrJSONMergePatch) || string(out[2].Value) != `{"server":` {
+		t.Fatalf("unexpected invalid item: got %s (error %v)", out[2].Value, out[2].Error)
+	}
+}
+
+func TestJSONMergePatch_NonObjectPatchReplaces(t *testing.T) {
+	got, ok := mergePatchJSON(map[string]any{"a": "b"}, []any{"c"}).([]any)
+	if !ok || len(got) != 1 || got[0] != "c" {
+		t.Fatalf("unexpected merge: %v", got)
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrJSONPatch is attached by NewJSONPatch to the items it could not patch.
+var ErrJSONPatch = errors.New("textual: JSON patch failed")
+
+// PatchOp is one operation of an RFC 6902 JSON Patch.
+//
+// Op is "add", "remove", "replace", "move", "copy" or "test". Path, and From
+// for move and copy, are RFC 6901 JSON Pointers. Value is used by add, replace
+// and test.
+type PatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// NewJSONPatch returns a Processor applying the RFC 6902 JSON Patch patch to
+// each JSON value.
+//
+// The patch is atomic: if an operation fails (missing path, failed test,
+// invalid index, ...), the item is forwarded unchanged with an ErrJSONPatch
+// error naming the operation. Invalid JSON, and an invalid patch (unknown
+// operation, malformed pointer or value), fail every item the same way.
+//
+// Patched values are re-encoded compactly: numbers are kept verbatim but
+// object keys are sorted. Index and error are preserved.
+func NewJSONPatch(patch []PatchOp) ProcessorFunc[JsonCarrier] {
+	invalid := validateJSONPatch(patch)
+	return NewProcessorFunc(func(_ context.Context, item JsonCarrier) JsonCarrier {
+		if invalid != nil {
+			return item.WithError(invalid)
+		}
+		doc, err := decodeJSONValue(item.Value)
+		if err != nil {
+			return item.WithError(fmt.Errorf("%w: invalid JSON: %v", ErrJSONPatch, err))
+		}
+		for i, op := range patch {
+			if doc, err = applyPatchOp(doc, op); err != nil {
+				return item.WithError(fmt.Errorf("%w: operation %d (%s %s): %v", ErrJSONPatch, i, op.Op, op.Path, err))
+			}
+		}
+		patched, err := encodeJSONValue(doc)
+		if err != nil {
+			return item.WithError(fmt.Errorf("%w: %v", ErrJSONPatch, err))
+		}
+		item.Value = patched
+		return item
+	})
+}
+
+// validateJSONPatch checks the operations, pointers and values of patch.
+func validateJSONPatch(patch []PatchOp) error {
+	for i, op := range patch {
+		fail := func(format string, args ...any) error {
+			return fmt.Errorf("%w: operation %d (%s %s): %s", ErrJSONPatch, i, op.Op, op.Path, fmt.Sprintf(format, args...))
+		}
+		switch op.Op {
+		case "add", "replace", "test":
+			if _, err := decodeJSONValue(op.Value); err != nil {
+				return fail("invalid value: %v", err)
+			}
+		case "move", "copy":
+			if _, err := parseJSONPointer(op.From); err != nil {
+				return fail("%v", err)
+			}
+		case "remove":
+		default:
+			return fail("unknown operation")
+		}
+		if _, err := parseJSONPointer(op.Path); err != nil {
+			return fail("%v", err)
+		}
+	}
+	return nil
+}
+
+// applyPatchOp applies op to doc and returns the new document. op is valid.
+func applyPatchOp(doc any, op PatchOp) (any, error) {
+	path, _ := parseJSONPointer(op.Path)
+	switch op.Op {
+	case "add":
+		// Decoded for every item: documents are mutated in place.
+		v, _ := decodeJSONValue(op.Value)
+		return jsonPatchAdd(doc, path, v)
+	case "remove":
+		doc, _, err := jsonPatchRemove(doc, path)
+		return doc, err
+	case "replace":
+		if _, err := jsonPointerGet(doc, path); err != nil {
+			return nil, err
+		}
+		v, _ := decodeJSONValue(op.Value)
+		return jsonPatchSet(doc, path, v)
+	case "move":
+		from, _ := parseJSONPointer(op.From)
+		if op.Path == op.From {
+			_, err := jsonPointerGet(doc, from)
+			return doc, err
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("cannot move %q into itself", op.From)
+		}
+		doc, v, err := jsonPatchRemove(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPatchAdd(doc, path, v)
+	case "copy":
+		from, _ := parseJSONPointer(op.From)
+		v, err := jsonPointerGet(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPatchAdd(doc, path, deepCopyJSON(v))
+	case "test":
+		got, err := jsonPointerGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		want, _ := decodeJSONValue(op.Value)
+		if !jsonEqual(got, want) {
+			return nil, fmt.Errorf("test failed: value is %s", mustEncodeJSON(got))
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("unknown operation")
+}
+
+// jsonPatchAdd adds v at path: it sets an object member, or inserts into an
+// array ("-" appends).
+func jsonPatchAdd(doc any, path []string, v any) (any, error) {
+	if len(path) == 0 {
+		return v, nil
+	}
+	parent, last := path[:len(path)-1], path[len(path)-1]
+	container, err := jsonPointerGet(doc, parent)
+	if err != nil {
+		return nil, err
+	}
+	switch c := container.(type) {
+	case map[string]any:
+		c[last] = v
+		return doc, nil
+	case []any:
+		i := len(c)
+		if last != "-" {
+			if i, err = jsonArrayIndex(last, len(c), true); err != nil {
+				return nil, err
+			}
+		}
+		grown := make([]any, 0, len(c)+1)
+		grown = append(append(append(grown, c[:i]...), v), c[i:]...)
+		return jsonPatchSet(doc, parent, grown)
+	}
+	return nil, fmt.Errorf("path %q not found", jsonPointerString(parent))
+}
+
+// jsonPatchRemove removes the value at path and returns the new document and
+// the removed value.
+func jsonPatchRemove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	v, err := jsonPointerGet(doc, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, last := path[:len(path)-1], path[len(path)-1]
+	container, _ := jsonPointerGet(doc, parent)
+	switch c := container.(type) {
+	case map[string]any:
+		delete(c, last)
+		return doc, v, nil
+	case []any:
+		i, _ := jsonArrayIndex(last, len(c), false)
+		shrunk := append(append(make([]any, 0, len(c)-1), c[:i]...), c[i+1:]...)
+		doc, err = jsonPatchSet(doc, parent, shrunk)
+		return doc, v, err
+	}
+	return nil, nil, fmt.Errorf("path %q not found", jsonPointerString(path))
+}
+
+// jsonPatchSet replaces the existing value at path with v.
+func jsonPatchSet(doc any, path []string, v any) (any, error) {
+	if len(path) == 0 {
+		return v, nil
+	}
+	container, err := jsonPointerGet(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch c := container.(type) {
+	case map[string]any:
+		c[last] = v
+	case []any:
+		i, err := jsonArrayIndex(last, len(c), false)
+		if err != nil {
+			return nil, err
+		}
+		c[i] = v
+	}
+	return doc, nil
+}
+
+// deepCopyJSON copies the objects and arrays of a decoded JSON value.
+func deepCopyJSON(v any) any {
+	switch c := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(c))
+		for k, e := range c {
+			m[k] = deepCopyJSON(e)
+		}
+		return m
+	case []any:
+		s := make([]any, len(c))
+		for i, e := range c {
+			s[i] = deepCopyJSON(e)
+		}
+		return s
+	}
+	return v
+}
+
+// jsonEqual compares decoded JSON values; numbers are compared by value
+// (1 equals 1.0).
+func jsonEqual(a, b any) bool {
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, e := range x {
+			f, ok := y[k]
+			if !ok || !jsonEqual(e, f) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !jsonEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if x == y {
+			return true
+		}
+		fx, errX := x.Float64()
+		fy, errY := y.Float64()
+		return errX == nil && errY == nil && fx == fy
+	}
+	return a == b
+}
+
+// mustEncodeJSON renders a decoded JSON value for error messages.
+func mustEncodeJSON(v any) string {
+	b, err := encodeJSONValue(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
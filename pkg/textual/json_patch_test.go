@@ -0,0 +1,95 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func applyJSONPatch(t *testing.T, patch []PatchOp, doc string) JsonCarrier {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan JsonCarrier, 1)
+	in <- JsonCarrier{Value: json.RawMessage(doc), Index: 3}
+	close(in)
+
+	out, err := collectWithContext(ctx, NewJSONPatch(patch).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 1 || out[0].Index != 3 {
+		t.Fatalf("unexpected output: %v", out)
+	}
+	return out[0]
+}
+
+func TestJSONPatch_Operations(t *testing.T) {
+	doc := `{"a":{"b":1,"c":[1,2]},"d":"x"}`
+	cases := []struct {
+		name string
+		op   PatchOp
+		want string
+	}{
+		{"add member", PatchOp{Op: "add", Path: "/a/e", Value: json.RawMessage(`{"f":true}`)}, `{"a":{"b":1,"c":[1,2],"e":{"f":true}},"d":"x"}`},
+		{"add insert", PatchOp{Op: "add", Path: "/a/c/1", Value: json.RawMessage(`9`)}, `{"a":{"b":1,"c":[1,9,2]},"d":"x"}`},
+		{"add append", PatchOp{Op: "add", Path: "/a/c/-", Value: json.RawMessage(`3`)}, `{"a":{"b":1,"c":[1,2,3]},"d":"x"}`},
+		{"remove member", PatchOp{Op: "remove", Path: "/d"}, `{"a":{"b":1,"c":[1,2]}}`},
+		{"remove element", PatchOp{Op: "remove", Path: "/a/c/0"}, `{"a":{"b":1,"c":[2]},"d":"x"}`},
+		{"replace", PatchOp{Op: "replace", Path: "/a/b", Value: json.RawMessage(`"<b>"`)}, `{"a":{"b":"<b>","c":[1,2]},"d":"x"}`},
+		{"move", PatchOp{Op: "move", From: "/a/c", Path: "/c"}, `{"a":{"b":1},"c":[1,2],"d":"x"}`},
+		{"copy", PatchOp{Op: "copy", From: "/a/b", Path: "/a/c/0"}, `{"a":{"b":1,"c":[1,1,2]},"d":"x"}`},
+		{"test", PatchOp{Op: "test", Path: "/a/b", Value: json.RawMessage(`1.0`)}, doc},
+	}
+	for _, c := range cases {
+		got := applyJSONPatch(t, []PatchOp{c.op}, doc)
+		if got.Error != nil {
+			t.Fatalf("unexpected error for %s: %v", c.name, got.Error)
+		}
+		if string(got.Value) != c.want {
+			t.Fatalf("unexpected result for %s: got %s want %s", c.name, got.Value, c.want)
+		}
+	}
+}
+
+func TestJSONPatch_FailedTestIsAtomic(t *testing.T) {
+	doc := `{"a":1}`
+	patch := []PatchOp{
+		{Op: "replace", Path: "/a", Value: json.RawMessage(`2`)},
+		{Op: "test", Path: "/missing", Value: json.RawMessage(`1`)},
+	}
+	got := applyJSONPatch(t, patch, doc)
+	if !errors.Is(got.Error, ErrJSONPatch) {
+		t.Fatalf("unexpected error: got %v want %v", got.Error, ErrJSONPatch)
+	}
+	if string(got.Value) != doc {
+		t.Fatalf("unexpected value: got %s want %s", got.Value, doc)
+	}
+
+	got = applyJSONPatch(t, []PatchOp{{Op: "test", Path: "/a", Value: json.RawMessage(`"1"`)}}, doc)
+	if !errors.Is(got.Error, ErrJSONPatch) {
+		t.Fatalf("unexpected error for a mismatching test: got %v want %v", got.Error, ErrJSONPatch)
+	}
+
+	got = applyJSONPatch(t, []PatchOp{{Op: "frobnicate", Path: "/a"}}, doc)
+	if !errors.Is(got.Error, ErrJSONPatch) {
+		t.Fatalf("unexpected error for an unknown operation: got %v want %v", got.Error, ErrJSONPatch)
+	}
+}
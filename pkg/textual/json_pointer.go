@@ -0,0 +1,137 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseJSONPointer splits an RFC 6901 JSON Pointer ("/a/b~1c/0") into its
+// unescaped reference tokens. The empty pointer refers to the whole document
+// and yields no token.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must start with '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// jsonArrayIndex parses an array index reference token: a decimal number
+// without leading zeros, lower than n (or equal to n when allowEnd is set).
+func jsonArrayIndex(token string, n int, allowEnd bool) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i > n || (i == n && !allowEnd) {
+		return 0, fmt.Errorf("array index %s out of range", token)
+	}
+	return i, nil
+}
+
+// jsonPointerGet returns the value tokens refer to in doc, as decoded by
+// decodeJSONValue.
+func jsonPointerGet(doc any, tokens []string) (any, error) {
+	for depth, t := range tokens {
+		switch c := doc.(type) {
+		case map[string]any:
+			v, ok := c[t]
+			if !ok {
+				return nil, fmt.Errorf("path %q not found", jsonPointerString(tokens[:depth+1]))
+			}
+			doc = v
+		case []any:
+			i, err := jsonArrayIndex(t, len(c), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = c[i]
+		default:
+			return nil, fmt.Errorf("path %q not found", jsonPointerString(tokens[:depth+1]))
+		}
+	}
+	return doc, nil
+}
+
+// jsonNumberAt returns the number at tokens in the value of item. It reports
+// false for an item carrying an error, invalid JSON, or a missing or
+// non-numeric value.
+func jsonNumberAt(item JsonCarrier, tokens []string) (float64, bool) {
+	if item.Error != nil {
+		return 0, false
+	}
+	doc, err := decodeJSONValue(item.Value)
+	if err != nil {
+		return 0, false
+	}
+	v, err := jsonPointerGet(doc, tokens)
+	if err != nil {
+		return 0, false
+	}
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+// jsonPointerString renders tokens back as a JSON Pointer.
+func jsonPointerString(tokens []string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(t, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// decodeJSONValue decodes a single JSON value, keeping numbers as json.Number
+// so that they are re-encoded verbatim.
+func decodeJSONValue(raw []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return v, nil
+}
+
+// encodeJSONValue encodes v compactly, without HTML escaping. Object keys are
+// sorted.
+func encodeJSONValue(v any) (json.RawMessage, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return json.RawMessage(bytes.TrimRight(b.Bytes(), "\n")), nil
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrTruncatedJSON is attached by NewJSONRepairTruncated to the items whose
+// JSON value was cut before its end.
+var ErrTruncatedJSON = errors.New("textual: truncated JSON")
+
+// NewJSONRepairTruncated returns a Processor that detects JsonCarrier values
+// cut mid-object or mid-array (dropped connection, size limit, ...) and closes
+// them minimally, so that truncated streams still yield usable JSON.
+//
+// Truncation is detected with the same nesting logic as ScanJSON: a value is
+// truncated when an object or array (or a string inside it) is still open at
+// the end of the bytes. The repair is heuristic and best-effort:
+//
+//   - an open string is closed (dropping an incomplete escape sequence),
+//   - a dangling ',' is dropped, and a key or ':' without value gets null,
+//   - an incomplete literal is completed (tru -> true) and an incomplete number
+//     is trimmed (1.5e -> 1.5),
+//   - the open arrays and objects are closed in order.
+//
+// Data that was lost is not recovered: the result is valid JSON, not the
+// original value. Every truncated item gets an ErrTruncatedJSON error, and its
+// Value is replaced only when the repaired bytes are valid JSON. Complete
+// values (and malformed ones, such as mismatched delimiters) are forwarded
+// untouched.
+func NewJSONRepairTruncated() ProcessorFunc[JsonCarrier] {
+	return NewProcessorFunc(func(_ context.Context, item JsonCarrier) JsonCarrier {
+		repaired, truncated := repairTruncatedJSON(item.Value)
+		if !truncated {
+			return item
+		}
+		if !json.Valid(repaired) {
+			return item.WithError(fmt.Errorf("%w: repair failed (index %d)", ErrTruncatedJSON, item.Index))
+		}
+		item.Value = repaired
+		return item.WithError(fmt.Errorf("%w: repaired (index %d)", ErrTruncatedJSON, item.Index))
+	})
+}
+
+// jsonScanState is the nesting state at the end of a JSON prefix.
+type jsonScanState struct {
+	stack      []byte // open '{' and '['
+	inString   bool   // the prefix ends inside a string
+	escaped    bool   // the prefix ends right after a backslash in a string
+	pendingKey bool   // the last token is an object key not followed by ':'
+	complete   bool   // a top-level value was closed
+	malformed  bool   // mismatched or unexpected closing delimiter
+}
+
+// scanJSONState walks data with the ScanJSON nesting rules.
+func scanJSONState(data []byte) jsonScanState {
+	var st jsonScanState
+	var last byte // last significant byte outside strings
+	keyString := false
+	for _, b := range data {
+		if st.inString {
+			switch {
+			case st.escaped:
+				st.escaped = false
+			case b == '\\':
+				st.escaped = true
+			case b == '"':
+				st.inString = false
+				st.pendingKey = keyString
+				last = '"'
+			}
+			continue
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '"':
+			st.inString = true
+			keyString = len(st.stack) > 0 && st.stack[len(st.stack)-1] == '{' && (last == '{' || last == ',')
+		case '{', '[':
+			st.stack = append(st.stack, b)
+		case '}', ']':
+			if len(st.stack) == 0 {
+				st.malformed = true
+				return st
+			}
+			top := st.stack[len(st.stack)-1]
+			if (b == '}') != (top == '{') {
+				st.malformed = true
+				return st
+			}
+			st.stack = st.stack[:len(st.stack)-1]
+			if len(st.stack) == 0 {
+				st.complete = true
+				return st
+			}
+		}
+		st.pendingKey = false
+		last = b
+	}
+	return st
+}
+
+// repairTruncatedJSON closes a truncated JSON object or array. It reports
+// whether data was truncated; when it was not, data is returned unchanged.
+func repairTruncatedJSON(data []byte) ([]byte, bool) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return data, false
+	}
+	st := scanJSONState(trimmed)
+	if st.complete || st.malformed || len(st.stack) == 0 {
+		return data, false
+	}
+
+	out := append([]byte(nil), trimmed...)
+	if st.inString {
+		out = trimIncompleteEscape(out, st.escaped)
+		out = append(out, '"')
+	} else {
+		out = trimIncompleteToken(out)
+	}
+
+	// Re-scan: the tail edits may have changed the pending key state.
+	st = scanJSONState(out)
+	if st.pendingKey {
+		out = append(out, ":null"...)
+	} else if len(out) > 0 && out[len(out)-1] == ':' {
+		out = append(out, "null"...)
+	}
+	for i := len(st.stack) - 1; i >= 0; i-- {
+		if st.stack[i] == '{' {
+			out = append(out, '}')
+		} else {
+			out = append(out, ']')
+		}
+	}
+	return out, true
+}
+
+// trimIncompleteEscape removes an escape sequence cut at the end of an open
+// string ("\" or an incomplete "\uXXXX").
+func trimIncompleteEscape(out []byte, escaped bool) []byte {
+	if escaped {
+		return out[:len(out)-1]
+	}
+	for n := 1; n <= 4 && n < len(out); n++ {
+		i := len(out) - n - 1
+		if out[i+1] == 'u' && out[i] == '\\' && !isEscapedBackslash(out, i) {
+			return out[:i]
+		}
+		if !isHexDigit(out[len(out)-n]) {
+			break
+		}
+	}
+	return out
+}
+
+// isEscapedBackslash reports whether the backslash at out[i] is itself escaped.
+func isEscapedBackslash(out []byte, i int) bool {
+	n := 0
+	for j := i - 1; j >= 0 && out[j] == '\\'; j-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+func isHexDigit(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
+
+// trimIncompleteToken fixes the end of a truncated value outside strings:
+// incomplete literals and numbers, and dangling commas.
+func trimIncompleteToken(out []byte) []byte {
+	out = bytes.TrimRight(out, " \t\r\n")
+
+	// Trailing bare token (literal or number).
+	start := len(out)
+	for start > 0 && strings.IndexByte("abcdefghijklmnopqrstuvwxyz0123456789.+-E", out[start-1]) >= 0 {
+		start--
+	}
+	if token := string(out[start:]); token != "" {
+		out = out[:start]
+		switch {
+		case token[0] == 't' && strings.HasPrefix("true", token):
+			out = append(out, "true"...)
+		case token[0] == 'f' && strings.HasPrefix("false", token):
+			out = append(out, "false"...)
+		case token[0] == 'n' && strings.HasPrefix("null", token):
+			out = append(out, "null"...)
+		default:
+			// A number: drop the trailing characters that cannot end it.
+			token = strings.TrimRight(token, ".eE+-")
+			out = append(out, token...)
+		}
+		out = bytes.TrimRight(out, " \t\r\n")
+	}
+	return bytes.TrimSuffix(out, []byte(","))
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewJSONRepairTruncated(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string // "" when the value must be left untouched
+	}{
+		{`{"a":1,"b":{"c":[1,2`, `{"a":1,"b":{"c":[1,2]}}`},
+		{`[1,2,{"x":"y`, `[1,2,{"x":"y"}]`},
+		{`[1, 2,`, `[1, 2]`},
+		{`{"a":`, `{"a":null}`},
+		{`{"a":1,"ke`, `{"a":1,"ke":null}`},
+		{`{"ok":tr`, `{"ok":true}`},
+		{`[1.5e`, `[1.5]`},
+		{`["a\u00`, `["a"]`},
+		{`["a\`, `["a"]`},
+		{`{"a":[1,2]}`, ``},
+		{`{"a":1]`, ``},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan JsonCarrier, len(cases))
+	for i, c := range cases {
+		in <- JsonCarrier{Value: json.RawMessage(c.in), Index: i}
+	}
+	close(in)
+	out, err := collectWithContext(ctx, NewJSONRepairTruncated().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != len(cases) {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), len(cases))
+	}
+	for i, c := range cases {
+		got := out[i]
+		if c.want == "" {
+			if string(got.Value) != c.in || got.Error != nil {
+				t.Fatalf("unexpected change for %q: got %q (err %v)", c.in, got.Value, got.Error)
+			}
+			continue
+		}
+		if string(got.Value) != c.want {
+			t.Fatalf("unexpected repair of %q: got %q want %q", c.in, got.Value, c.want)
+		}
+		if !errors.Is(got.Error, ErrTruncatedJSON) {
+			t.Fatalf("unexpected error for %q: got %v want %v", c.in, got.Error, ErrTruncatedJSON)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// ErrInvalidJSON is attached by NewJSONStringLeaves to the output of an item
+// whose value is not valid JSON.
+var ErrInvalidJSON = errors.New("textual: invalid JSON")
+
+// NewJSONStringLeaves returns a Transcoder emitting one StringCarrier per
+// string leaf of each JSON value, to feed text-search pipelines from
+// structured data. Object keys, numbers, booleans and nulls are ignored.
+//
+// Leaves are emitted in document order (object members in their original
+// order, then array elements), and indexed like FlatMap: the leaf at position
+// pos of the item with index parent gets the index parent*FlatMapStride + pos
+// (see FlatMapIndex). The position is therefore derived from the leaf path:
+// the same document structure always yields the same sub-indices. The error
+// of the item, if any, is attached to each of its leaves.
+//
+// A value without string leaves produces no output. An invalid value produces
+// a single empty StringCarrier carrying an ErrInvalidJSON error.
+func NewJSONStringLeaves() TranscoderFunc[JsonCarrier, StringCarrier] {
+	return func(ctx context.Context, in <-chan JsonCarrier) <-chan StringCarrier {
+		return FlatMap(ctx, in, func(_ context.Context, item JsonCarrier) []StringCarrier {
+			leaves, err := jsonStringLeaves(item.Value)
+			if err != nil {
+				return []StringCarrier{{Error: transcodeErrorf("%w: %v", ErrInvalidJSON, err)}}
+			}
+			out := make([]StringCarrier, len(leaves))
+			for i, leaf := range leaves {
+				out[i] = StringCarrier{Value: UTF8String(leaf)}
+			}
+			return out
+		})
+	}
+}
+
+// jsonStringLeaves walks raw, a single JSON value, and returns its string
+// leaves in document order.
+func jsonStringLeaves(raw []byte) ([]string, error) {
+	type frame struct {
+		object bool
+		isKey  bool // the next string of an object is a member name
+	}
+	var (
+		leaves []string
+		stack  []frame
+	)
+	// next moves the innermost object past the value just read.
+	next := func() {
+		if n := len(stack); n > 0 && stack[n-1].object {
+			stack[n-1].isKey = true
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case json.Delim:
+			switch t {
+			case '{':
+				stack = append(stack, frame{object: true, isKey: true})
+			case '[':
+				stack = append(stack, frame{})
+			default:
+				stack = stack[:len(stack)-1]
+				next()
+			}
+		case string:
+			if n := len(stack); n > 0 && stack[n-1].object && stack[n-1].isKey {
+				stack[n-1].isKey = false
+				continue
+			}
+			leaves = append(leaves, t)
+			next()
+		default:
+			next()
+		}
+		if len(stack) == 0 && dec.More() {
+			return nil, errors.New("unexpected data after the JSON value")
+		}
+	}
+	if len(stack) > 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return leaves, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJSONStringLeaves_NestedObjectsAndArrays(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan JsonCarrier, 2)
+	in <- JsonCarrier{Value: json.RawMessage(`{"title":"Hello","meta":{"tags":["a","b"],"views":3,"draft":false},"authors":[{"name":"Ann"},{"name":"Bob","id":null}],"empty":{}}`), Index: 0}
+	in <- JsonCarrier{Value: json.RawMessage(`{"broken":`), Index: 1}
+	close(in)
+
+	out, err := collectWithContext(ctx, NewJSONStringLeaves().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"Hello", "a", "b", "Ann", "Bob"}
+	if len(out) != len(want)+1 {
+		t.Fatalf("unexpected output count: got %d want %d (%v)", len(out), len(want)+1, out)
+	}
+	for i, w := range want {
+		parent, pos := FlatMapIndex(out[i].Index)
+		if string(out[i].Value) != w || parent != 0 || pos != i || out[i].Error != nil {
+			t.Fatalf("unexpected leaf %d: got %q (parent %d, pos %d, error %v) want %q", i, out[i].Value, parent, pos, out[i].Error, w)
+		}
+	}
+	last := out[len(out)-1]
+	if parent, _ := FlatMapIndex(last.Index); parent != 1 || !errors.Is(last.Error, ErrInvalidJSON) {
+		t.Fatalf("unexpected invalid item output: parent %d, error %v", parent, last.Error)
+	}
+}
+
+func TestJSONStringLeaves_DocumentOrder(t *testing.T) {
+	leaves, err := jsonStringLeaves([]byte(`["x",{"k":{"c":["y",1,"z"]},"a":"v"},"w"]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"x", "y", "z", "v", "w"}
+	if len(leaves) != len(want) {
+		t.Fatalf("unexpected leaf count: got %d want %d (%v)", len(leaves), len(want), leaves)
+	}
+	for i := range want {
+		if leaves[i] != want[i] {
+			t.Fatalf("unexpected leaf %d: got %q want %q", i, leaves[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JSONToXMLItemName is the element name NewJSONToXML gives to the elements of
+// an array that is not the value of an object key.
+const JSONToXMLItemName = "item"
+
+// NewJSONToXML returns a Transcoder converting each JsonCarrier value into one
+// XML element named rootName (default "root"), to bridge JSON producers and XML
+// consumers.
+//
+// Mapping:
+//
+//   - an object becomes an element with one child element per key, in the
+//     original key order: {"a":{"b":1}} -> <a><b>1</b></a>;
+//   - an array that is the value of a key repeats that key's element, without
+//     wrapper: {"a":[1,2]} -> <a>1</a><a>2</a> (an empty array produces no
+//     element);
+//   - any other array (the top-level value, or an array inside an array) is
+//     wrapped in its element and its values are named JSONToXMLItemName:
+//     [1,[2]] -> <root><item>1</item><item><item>2</item></item></root>;
+//   - strings, numbers and booleans become escaped text content, null an empty
+//     element.
+//
+// Keys that are not valid XML names are rewritten following the XML 1.0 name
+// rules: every character that is not a name character (':' included) becomes
+// '_', an underscore is prepended when the name starts with a character that
+// cannot start a name (a digit, '-', '.', '·', a combining mark: "1st" ->
+// "_1st"), and an empty key becomes "_". Different keys
+// may therefore map to the same name.
+//
+// Index and error are preserved. Invalid JSON yields an empty value with an
+// error attached.
+func NewJSONToXML(rootName string) TranscoderFunc[JsonCarrier, XmlCarrier] {
+	root := "root"
+	if rootName != "" {
+		root = xmlName(rootName)
+	}
+	return NewTranscoderFunc(func(_ context.Context, j JsonCarrier) XmlCarrier {
+		res := XmlCarrier{Index: j.Index}.WithError(j.Error)
+		value, err := jsonToXML(j.Value, root)
+		if err != nil {
+			return res.WithError(transcodeErrorf("json to xml (index %d): %w", j.Index, err))
+		}
+		res.Value = value
+		return res
+	})
+}
+
+// jsonToXML converts one JSON value into an element named root.
+func jsonToXML(data []byte, root string) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := writeJSONValue(&b, dec, tok, root); err != nil {
+		return "", err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return "", errors.New("unexpected data after the JSON value")
+	}
+	return b.String(), nil
+}
+
+// writeJSONValue writes the value starting with tok as an element named name.
+func writeJSONValue(b *strings.Builder, dec *json.Decoder, tok json.Token, name string) error {
+	switch v := tok.(type) {
+	case json.Delim:
+		b.WriteString("<" + name + ">")
+		var err error
+		if v == '{' {
+			err = writeJSONObject(b, dec)
+		} else {
+			err = writeJSONArray(b, dec, JSONToXMLItemName)
+		}
+		if err != nil {
+			return err
+		}
+		b.WriteString("</" + name + ">")
+	case nil:
+		b.WriteString("<" + name + "/>")
+	default:
+		b.WriteString("<" + name + ">")
+		if err := xml.EscapeText(b, []byte(jsonScalarText(v))); err != nil {
+			return err
+		}
+		b.WriteString("</" + name + ">")
+	}
+	return nil
+}
+
+// writeJSONObject writes the fields of an object whose '{' has been read, up
+// to and including its '}'.
+func writeJSONObject(b *strings.Builder, dec *json.Decoder) error {
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		name := xmlName(key)
+
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == json.Delim('[') {
+			// A keyed array repeats the key's element.
+			err = writeJSONArray(b, dec, name)
+		} else {
+			err = writeJSONValue(b, dec, tok, name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token() // '}'
+	return err
+}
+
+// writeJSONArray writes the values of an array whose '[' has been read as
+// elements named name, up to and including its ']'.
+func writeJSONArray(b *strings.Builder, dec *json.Decoder, name string) error {
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if err := writeJSONValue(b, dec, tok, name); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token() // ']'
+	return err
+}
+
+func jsonScalarText(v json.Token) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// xmlName rewrites s into a valid XML element name (see NewJSONToXML), using
+// the XML 1.0 name rules of ScanXML (isXMLNameStart, isXMLNameChar).
+func xmlName(s string) string {
+	if s == "" {
+		return "_"
+	}
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case !isXMLNameChar(r):
+			b.WriteByte('_')
+		case i == 0 && !isXMLNameStart(r):
+			b.WriteByte('_')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNewJSONToXML(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{`{"user":{"name":"Ann & Bob","age":42,"admin":true,"tags":["a","b"],"note":null}}`,
+			`<doc><user><name>Ann &amp; Bob</name><age>42</age><admin>true</admin>` +
+				`<tags>a</tags><tags>b</tags><note/></user></doc>`},
+		{`[1,[2,3],{"x":"<y>"}]`,
+			`<doc><item>1</item><item><item>2</item><item>3</item></item><item><x>&lt;y&gt;</x></item></doc>`},
+		{`{"1st":1,"a b":2,"":3,"empty":[]}`,
+			`<doc><_1st>1</_1st><a_b>2</a_b><_>3</_></doc>`},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan JsonCarrier, len(cases)+1)
+	for i, c := range cases {
+		in <- JsonCarrier{Value: json.RawMessage(c.in), Index: i}
+	}
+	in <- JsonCarrier{Value: json.RawMessage(`{"a":`), Index: len(cases)}
+	close(in)
+
+	out, err := collectWithContext(ctx, NewJSONToXML("doc").Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != len(cases)+1 {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), len(cases)+1)
+	}
+	for i, c := range cases {
+		if out[i].Error != nil {
+			t.Fatalf("unexpected error for %q: %v", c.in, out[i].Error)
+		}
+		if out[i].Value != c.want {
+			t.Fatalf("unexpected xml for %q: got %q want %q", c.in, out[i].Value, c.want)
+		}
+	}
+	if last := out[len(cases)]; last.Error == nil || last.Value != "" {
+		t.Fatalf("unexpected result for invalid JSON: got %#v", last)
+	}
+}
+
+func TestXMLName_FollowsXMLNameRules(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"", "_"},
+		{"1st", "_1st"},
+		{"a b", "a_b"},
+		{"ns:key", "ns_key"},
+		{"a·b", "a·b"},
+		{"·a", "_·a"},
+		{"été", "été"},
+		{"\u0301x", "_\u0301x"}, // combining mark
+		{"½", "_"},              // not a name character
+	}
+	for _, c := range cases {
+		got := xmlName(c.in)
+		if got != c.want {
+			t.Fatalf("unexpected name for %q: got %q want %q", c.in, got, c.want)
+		}
+		if !isXMLName(got) {
+			t.Fatalf("xmlName(%q) = %q is not a valid XML name", c.in, got)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// LexErrorLabel is the Label of the fragments NewLexer produces for the spans
+// no rule matches.
+const LexErrorLabel = "error"
+
+// ErrLexUnmatched is attached by NewLexer to the Parcels containing spans no
+// rule matches.
+var ErrLexUnmatched = errors.New("textual: no lexer rule matches")
+
+// LexRule is a token rule of NewLexer.
+type LexRule struct {
+	Name    string         // Token name, stored in Fragment.Label.
+	Pattern *regexp.Regexp // Token pattern; it is anchored at the current position.
+	Skip    bool           // Consume the matches without producing fragments (e.g. white space).
+}
+
+// NewLexer returns a Transcoder that tokenizes the text of each item with
+// rules and produces a Parcel whose fragments mark the token spans.
+//
+// At each position, every rule is tried and the longest non-empty match wins;
+// on a tie, the first rule wins. Each token becomes a Fragment whose
+// Transformed is the token text, whose Label is the rule name and whose
+// Confidence is 1, so UTF8String renders the original text unchanged. Matches
+// of a Skip rule produce no fragment and stay raw text.
+//
+// Spans no rule matches (grouped into maximal runs) become fragments labeled
+// LexErrorLabel, with Confidence 0, and the Parcel carries an ErrLexUnmatched
+// error giving their rune position.
+//
+// The Parcel keeps the index and the per-item error of the input item.
+// NewLexer panics if a rule has an empty name or a nil pattern.
+func NewLexer(rules []LexRule) TranscoderFunc[StringCarrier, Parcel] {
+	anchored := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		if rule.Name == "" || rule.Pattern == nil {
+			panic(fmt.Sprintf("textual: invalid lexer rule %d: a name and a pattern are required", i))
+		}
+		anchored[i] = regexp.MustCompile(`^(?:` + rule.Pattern.String() + `)`)
+	}
+	return NewTranscoderFunc(func(_ context.Context, item StringCarrier) Parcel {
+		p := ParcelFrom(UTF8String(item.Value)).WithIndex(item.Index).WithError(item.Error)
+		text := item.Value
+
+		pos := 0                  // rune position of offset
+		errStart, errPos := -1, 0 // byte offset and rune position of the pending unmatched run
+		flushErr := func(end, endPos int) {
+			if errStart < 0 {
+				return
+			}
+			p.Fragments = append(p.Fragments, Fragment{
+				Transformed: UTF8String(text[errStart:end]),
+				Pos:         errPos,
+				Len:         endPos - errPos,
+				Label:       LexErrorLabel,
+			})
+			p = p.WithError(fmt.Errorf("%w at position %d: %q", ErrLexUnmatched, errPos, text[errStart:end]))
+			errStart = -1
+		}
+
+		for offset := 0; offset < len(text); {
+			best, bestLen := -1, 0
+			for i, re := range anchored {
+				if loc := re.FindStringIndex(text[offset:]); loc != nil && loc[1] > bestLen {
+					best, bestLen = i, loc[1]
+				}
+			}
+			if best < 0 {
+				if errStart < 0 {
+					errStart, errPos = offset, pos
+				}
+				_, size := utf8.DecodeRuneInString(text[offset:])
+				offset += size
+				pos++
+				continue
+			}
+			flushErr(offset, pos)
+			token := text[offset : offset+bestLen]
+			n := utf8.RuneCountInString(token)
+			if !rules[best].Skip {
+				p.Fragments = append(p.Fragments, Fragment{
+					Transformed: UTF8String(token),
+					Pos:         pos,
+					Len:         n,
+					Confidence:  1,
+					Label:       rules[best].Name,
+				})
+			}
+			offset += bestLen
+			pos += n
+		}
+		flushErr(len(text), pos)
+		return p
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func arithmeticRules() []LexRule {
+	return []LexRule{
+		{Name: "space", Pattern: regexp.MustCompile(`\s+`), Skip: true},
+		{Name: "number", Pattern: regexp.MustCompile(`\d+(\.\d+)?`)},
+		{Name: "ident", Pattern: regexp.MustCompile(`[a-zA-Z_]\w*`)},
+		{Name: "op", Pattern: regexp.MustCompile(`\*\*|[-+*/]`)},
+		{Name: "lparen", Pattern: regexp.MustCompile(`\(`)},
+		{Name: "rparen", Pattern: regexp.MustCompile(`\)`)},
+	}
+}
+
+func TestLexer_ArithmeticExpression(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 1)
+	in <- StringCarrier{Value: "x ** (3.5 + y2) / 4", Index: 7}
+	close(in)
+
+	out, err := collectWithContext(ctx, NewLexer(arithmeticRules()).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 1 {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), 1)
+	}
+	p := out[0]
+	if p.Error != nil || p.Index != 7 {
+		t.Fatalf("unexpected parcel: error %v, index %d", p.Error, p.Index)
+	}
+	type token struct {
+		label, text string
+		pos         int
+	}
+	want := []token{
+		{"ident", "x", 0}, {"op", "**", 2}, {"lparen", "(", 5}, {"number", "3.5", 6},
+		{"op", "+", 10}, {"ident", "y2", 12}, {"rparen", ")", 14}, {"op", "/", 16}, {"number", "4", 18},
+	}
+	if len(p.Fragments) != len(want) {
+		t.Fatalf("unexpected token count: got %d want %d (%v)", len(p.Fragments), len(want), p.Fragments)
+	}
+	for i, w := range want {
+		f := p.Fragments[i]
+		if f.Label != w.label || string(f.Transformed) != w.text || f.Pos != w.pos {
+			t.Fatalf("unexpected token %d: got %s %q at %d want %s %q at %d", i, f.Label, f.Transformed, f.Pos, w.label, w.text, w.pos)
+		}
+	}
+	if got := string(p.UTF8String()); got != "x ** (3.5 + y2) / 4" {
+		t.Fatalf("unexpected rendering: got %q", got)
+	}
+}
+
+func TestLexer_UnmatchedSpans(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 1)
+	in <- StringCarrier{Value: "1 + é$ 2"}
+	close(in)
+
+	out, err := collectWithContext(ctx, NewLexer(arithmeticRules()).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p := out[0]
+	if !errors.Is(p.Error, ErrLexUnmatched) {
+		t.Fatalf("unexpected error: got %v want %v", p.Error, ErrLexUnmatched)
+	}
+	if len(p.Fragments) != 4 {
+		t.Fatalf("unexpected fragment count: got %d want %d (%v)", len(p.Fragments), 4, p.Fragments)
+	}
+	f := p.Fragments[2]
+	if f.Label != LexErrorLabel || string(f.Transformed) != "é$" || f.Pos != 4 || f.Len != 2 {
+		t.Fatalf("unexpected error fragment: %+v", f)
+	}
+	if err := p.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+}
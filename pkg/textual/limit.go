@@ -0,0 +1,65 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// NewLimit returns a Processor that forwards at most max items, then closes
+// its output and asks the pipeline source to stop reading (see StopSource).
+//
+// This matters when the source is expensive (a large file, a network stream,
+// a paid API): once the limit is reached, IOReaderProcessor and
+// IOReaderTranscoder stop scanning and close their channel, and the pipeline
+// drains gracefully. Items already flowing between the source and the limit are
+// discarded.
+//
+// Downstream sees the end of the stream as soon as max items are forwarded,
+// whatever the source. The rest of the input is drained in the background,
+// until it is closed or ctx is canceled, so upstream stages never block. Other
+// sources than IOReaderProcessor and IOReaderTranscoder are not stopped, and
+// keep producing until their natural end (or until ctx is canceled).
+//
+// When max <= 0, no item is forwarded.
+func NewLimit[S Carrier[S]](max int) ProcessorFunc[S] {
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		ctx, ps := EnsurePanicStore(ctx)
+		out := make(chan S)
+		go func() {
+			defer close(out)
+			for forwarded := 0; forwarded < max; forwarded++ {
+				var item S
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-in:
+					if !ok {
+						return
+					}
+					item = v
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case out <- item:
+				}
+			}
+			StopSource(ctx)
+			drainInBackground(ctx, ps, in)
+		}()
+		return out
+	}
+}
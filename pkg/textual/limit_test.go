@@ -0,0 +1,148 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+func TestNewLimit_StopsTheSourceEarly(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var b strings.Builder
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	total := int64(b.Len())
+	reader := &countingReader{r: iotest.OneByteReader(strings.NewReader(b.String()))}
+
+	upper := NewProcessorFunc(func(_ context.Context, s StringCarrier) StringCarrier {
+		s.Value = strings.ToUpper(s.Value)
+		return s
+	})
+	chain := NewChain[StringCarrier](upper, NewLimit[StringCarrier](3), upper)
+
+	p := NewIOReaderProcessor[StringCarrier](chain, reader)
+	p.SetContext(ctx)
+	items, err := collectWithContext(ctx, p.Start())
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 3)
+	}
+	for i, it := range items {
+		if want := fmt.Sprintf("LINE %d\n", i); it.Value != want || it.Index != i {
+			t.Fatalf("unexpected item %d: got %#v want %q", i, it, want)
+		}
+	}
+	if read := reader.n.Load(); read > total/10 {
+		t.Fatalf("source kept reading: %d of %d bytes", read, total)
+	}
+}
+
+func TestNewLimit_WithoutSourceHookDrainsInput(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier)
+	go func() {
+		defer close(in)
+		for i := 0; i < 10; i++ {
+			in <- StringCarrier{Value: "x", Index: i}
+		}
+	}()
+
+	items, err := collectWithContext(ctx, NewLimit[StringCarrier](2).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 2)
+	}
+}
+
+func TestNewLimit_ClosesOutputWithoutSourceHook(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// An endless upstream, not stopped by StopSource.
+	in := make(chan StringCarrier)
+	go func() {
+		for i := 0; ctx.Err() == nil; i++ {
+			select {
+			case in <- StringCarrier{Value: "x", Index: i}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	items, err := collectWithContext(ctx, NewLimit[StringCarrier](3).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 3)
+	}
+}
+
+func TestNewLimit_DrainStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	in := make(chan StringCarrier) // never closed
+	out := NewLimit[StringCarrier](1).Apply(ctx, in)
+	in <- StringCarrier{Value: "a"}
+	if _, ok := <-out; !ok {
+		t.Fatalf("expected one item before the end of the stream")
+	}
+	if _, ok := <-out; ok {
+		t.Fatalf("expected the output to be closed after the limit")
+	}
+
+	// The background drain consumes the input until ctx is canceled.
+	in <- StringCarrier{Value: "b"}
+	cancel()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case in <- StringCarrier{Value: "c"}:
+		case <-time.After(50 * time.Millisecond):
+			return // nobody is reading anymore
+		case <-deadline:
+			t.Fatalf("input still drained after cancellation")
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// Map returns a Processor applying f to every item (1:1), for the common
+// transforms that need neither the context nor a custom stage.
+//
+// f may rebuild the item from scratch (e.g. with FromUTF8String): the index of
+// the input is restored on the result, and its error is carried over unless f
+// returned an item with an error of its own.
+func Map[S Carrier[S]](f func(S) S) ProcessorFunc[S] {
+	return NewProcessorFunc(func(_ context.Context, item S) S {
+		res := f(item).WithIndex(item.GetIndex())
+		if res.GetError() == nil {
+			res = res.WithError(item.GetError())
+		}
+		return res
+	})
+}
+
+// MapErr returns a Processor applying f to every item (1:1), for business
+// logic that reports failures as errors.
+//
+// When f returns a non-nil error, it is attached to the output with WithError
+// and the item keeps flowing, so a single failure never ends the stream. The
+// index and error of the input are carried over as in Map.
+func MapErr[S Carrier[S]](f func(context.Context, S) (S, error)) ProcessorFunc[S] {
+	return NewProcessorFunc(func(ctx context.Context, item S) S {
+		res, err := f(ctx, item)
+		res = res.WithIndex(item.GetIndex())
+		if res.GetError() == nil {
+			res = res.WithError(item.GetError())
+		}
+		return res.WithError(err)
+	})
+}
+
+// MapString returns a Processor replacing the text of every StringCarrier with
+// f(text). Index and error are preserved.
+func MapString(f func(string) string) ProcessorFunc[StringCarrier] {
+	return NewProcessorFunc(func(_ context.Context, item StringCarrier) StringCarrier {
+		item.Value = f(item.Value)
+		return item
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMapString_Chain(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	reverse := func(s string) string {
+		r := []rune(s)
+		for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+			r[i], r[j] = r[j], r[i]
+		}
+		return string(r)
+	}
+	errBoom := errors.New("boom")
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "hello", Index: 0}
+	in <- StringCarrier{Value: "world", Index: 1, Error: errBoom}
+	close(in)
+
+	p := MapString(strings.ToUpper).Chain(MapString(reverse))
+	out, err := collectWithContext(ctx, p.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"OLLEH", "DLROW"}
+	if len(out) != len(want) {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), len(want))
+	}
+	for i := range want {
+		if out[i].Value != want[i] || out[i].Index != i {
+			t.Fatalf("unexpected item %d: got %q (index %d) want %q", i, out[i].Value, out[i].Index, want[i])
+		}
+	}
+	if !errors.Is(out[1].Error, errBoom) {
+		t.Fatalf("unexpected error: got %v want %v", out[1].Error, errBoom)
+	}
+}
+
+func TestMap_RestoresIndexAndError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	errBoom := errors.New("boom")
+	in := make(chan Parcel, 1)
+	in <- Parcel{Text: "abc", Index: 7}.WithError(errBoom)
+	close(in)
+
+	// f rebuilds the carrier, losing index and error.
+	p := Map(func(p Parcel) Parcel { return Parcel{}.FromUTF8String(p.Text + "!") })
+	out, err := collectWithContext(ctx, p.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 1 || out[0].Text != "abc!" || out[0].Index != 7 || !errors.Is(out[0].Error, errBoom) {
+		t.Fatalf("unexpected output: got %#v", out)
+	}
+}
+
+func TestMapErr_AttachesErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	errOdd := errors.New("odd")
+	in := make(chan StringCarrier, 4)
+	for i, v := range []string{"a", "b", "c", "d"} {
+		in <- StringCarrier{Value: v, Index: i}
+	}
+	close(in)
+
+	p := MapErr(func(_ context.Context, s StringCarrier) (StringCarrier, error) {
+		if s.Index%2 == 1 {
+			return s, errOdd
+		}
+		s.Value = strings.ToUpper(s.Value)
+		return s, nil
+	})
+	out, err := collectWithContext(ctx, p.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 4 {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), 4)
+	}
+	for i, item := range out {
+		if i%2 == 1 {
+			if !errors.Is(item.GetError(), errOdd) {
+				t.Fatalf("unexpected error for item %d: got %v want %v", i, item.GetError(), errOdd)
+			}
+			continue
+		}
+		if item.GetError() != nil {
+			t.Fatalf("unexpected error for item %d: %v", i, item.GetError())
+		}
+		if item.Value != strings.ToUpper(item.Value) {
+			t.Fatalf("unexpected value for item %d: got %q", i, item.Value)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// NewMemoryGuard returns a Processor that buffers items between a fast
+// producer and a slow consumer, bounded by their total size rather than by
+// their count: it provides memory-based backpressure where channel buffers
+// only bound the number of items.
+//
+// Items are admitted (read from the input) while the total size of the items
+// admitted but not yet emitted stays within maxPendingBytes. Once the next
+// item would exceed it, the guard holds that item and stops reading its input
+// until the consumer has taken enough items. An item larger than
+// maxPendingBytes on its own is admitted when nothing else is pending, so the
+// stream never deadlocks.
+//
+// The size of an item is estimated as len(item.UTF8String()), i.e. the length
+// of its UTF-8 rendering in bytes. It does not account for the Go overhead of
+// the item (struct fields, fragments metadata, ...): leave some headroom.
+//
+// Items are forwarded unchanged and in order. When ctx is canceled, pending
+// items are discarded. If maxPendingBytes <= 0, items are passed through.
+func NewMemoryGuard[S Carrier[S]](maxPendingBytes int) ProcessorFunc[S] {
+	if maxPendingBytes <= 0 {
+		return passThroughProcessor[S]()
+	}
+	type pendingItem struct {
+		item S
+		size int
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		out := make(chan S)
+		go func() {
+			defer close(out)
+			var (
+				queue   []pendingItem
+				pending int // bytes of the items in queue
+				held    *pendingItem
+				src     = in
+			)
+			for {
+				// Admit the held item as soon as it fits.
+				if held != nil && (len(queue) == 0 || pending+held.size <= maxPendingBytes) {
+					queue = append(queue, *held)
+					pending += held.size
+					held = nil
+				}
+				// Only read the input when nothing is held.
+				admit := src
+				if held != nil {
+					admit = nil
+				}
+				var (
+					emit chan<- S
+					head S
+				)
+				if len(queue) > 0 {
+					emit, head = out, queue[0].item
+				}
+				if admit == nil && emit == nil && held == nil {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-admit:
+					if !ok {
+						src = nil
+						continue
+					}
+					held = &pendingItem{item: item, size: len(item.UTF8String())}
+				case emit <- head:
+					pending -= queue[0].size
+					var zero pendingItem
+					queue[0] = zero
+					queue = queue[1:]
+				}
+			}
+		}()
+		return out
+	}
+}
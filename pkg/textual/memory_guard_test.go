@@ -0,0 +1,97 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryGuard_BlocksAdmissionWhenFull(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier)
+	out := NewMemoryGuard[StringCarrier](250).Apply(ctx, in)
+
+	large := strings.Repeat("x", 100)
+	index := 0
+	// trySend reports whether the guard admitted one more item in time.
+	trySend := func() bool {
+		select {
+		case in <- StringCarrier{Value: large, Index: index}:
+			index++
+			return true
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	}
+
+	// Two items are pending (200 bytes), the third one would exceed the cap
+	// and is held: the fourth is not admitted.
+	for i := 0; i < 3; i++ {
+		if !trySend() {
+			t.Fatalf("unexpected blocked admission of item %d", i)
+		}
+	}
+	if trySend() {
+		t.Fatalf("unexpected admission beyond the cap")
+	}
+
+	// Consuming one item frees room for the held one, then for one more.
+	if got := recvOne(t, ctx, out); got.Index != 0 {
+		t.Fatalf("unexpected item: got index %d want %d", got.Index, 0)
+	}
+	if !trySend() {
+		t.Fatalf("unexpected blocked admission after consumption")
+	}
+	if trySend() {
+		t.Fatalf("unexpected admission beyond the cap after consumption")
+	}
+
+	close(in)
+	rest, err := collectWithContext(ctx, out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rest) != 3 {
+		t.Fatalf("unexpected output count: got %d want %d", len(rest), 3)
+	}
+	for i, item := range rest {
+		if item.Index != i+1 {
+			t.Fatalf("unexpected order: got index %d want %d", item.Index, i+1)
+		}
+	}
+}
+
+func TestMemoryGuard_OversizedItemPasses(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: strings.Repeat("x", 64), Index: 0}
+	in <- StringCarrier{Value: "y", Index: 1}
+	close(in)
+
+	out, err := collectWithContext(ctx, NewMemoryGuard[StringCarrier](8).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 2 || out[0].Index != 0 || out[1].Index != 1 {
+		t.Fatalf("unexpected output: %v", out)
+	}
+}
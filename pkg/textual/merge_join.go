@@ -0,0 +1,178 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"runtime/debug"
+)
+
+// JoinKind selects which unmatched items MergeJoin emits.
+type JoinKind int
+
+const (
+	// InnerJoin only emits combined items for keys present in both streams.
+	InnerJoin JoinKind = iota
+	// LeftJoin also emits the items of the left stream without a match.
+	LeftJoin
+	// OuterJoin also emits the unmatched items of both streams.
+	OuterJoin
+)
+
+// MergeJoin joins two streams sorted by key (ascending string order) in a
+// single pass, like the merge join of relational databases.
+//
+// For every key present in both a and b, combine is called once per pair of
+// matching items (the cartesian product of the duplicates of that key, in
+// arrival order). Unmatched items are dropped (InnerJoin), or emitted unchanged
+// for the left stream (LeftJoin) or for both streams (OuterJoin).
+//
+// Memory is bounded by the duplicates of a single key: only the items sharing
+// the current key are buffered, whatever the length of the streams.
+//
+// Both inputs must be sorted by keyOf; otherwise matches are silently missed.
+// NewRequireSorted can be used upstream to make the precondition explicit.
+//
+// Emitted items are re-indexed 0, 1, 2, ... in output order. The output is
+// closed when both inputs are closed, or when ctx is canceled; the remaining
+// inputs are then drained in the background so upstream stages never block.
+// A nil input is treated as an empty stream. Panics in keyOf or combine are
+// recorded in the context PanicStore and close the output.
+func MergeJoin[S Carrier[S]](ctx context.Context, a, b <-chan S, keyOf func(S) string, combine func(a, b S) S, kind JoinKind) <-chan S {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, ps := EnsurePanicStore(ctx)
+
+	out := make(chan S)
+	go func() {
+		defer close(out)
+		defer func() {
+			go joinDrain(a)
+			go joinDrain(b)
+		}()
+		defer func() {
+			if r := recover(); r != nil {
+				ps.Store(r, debug.Stack())
+			}
+		}()
+
+		left, right := newJoinReader(a), newJoinReader(b)
+		index := 0
+		emit := func(item S) bool {
+			select {
+			case out <- item.WithIndex(index):
+				index++
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			l, okL := left.peek(ctx)
+			r, okR := right.peek(ctx)
+			if ctx.Err() != nil || (!okL && !okR) {
+				return
+			}
+			switch {
+			case okL && (!okR || keyOf(l) < keyOf(r)):
+				left.take()
+				if kind != InnerJoin && !emit(l) {
+					return
+				}
+			case okR && (!okL || keyOf(r) < keyOf(l)):
+				right.take()
+				if kind == OuterJoin && !emit(r) {
+					return
+				}
+			default:
+				key := keyOf(l)
+				lefts := left.run(ctx, key, keyOf)
+				rights := right.run(ctx, key, keyOf)
+				for _, x := range lefts {
+					for _, y := range rights {
+						if !emit(combine(x, y)) {
+							return
+						}
+					}
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// joinReader is a channel reader with a one item lookahead.
+type joinReader[S any] struct {
+	ch   <-chan S
+	head S
+	has  bool
+	done bool
+}
+
+func newJoinReader[S any](ch <-chan S) *joinReader[S] {
+	return &joinReader[S]{ch: ch, done: ch == nil}
+}
+
+// peek returns the next item without consuming it. It returns false when the
+// channel is closed or ctx is canceled.
+func (r *joinReader[S]) peek(ctx context.Context) (S, bool) {
+	if r.has {
+		return r.head, true
+	}
+	var zero S
+	if r.done {
+		return zero, false
+	}
+	select {
+	case v, ok := <-r.ch:
+		if !ok {
+			r.done = true
+			return zero, false
+		}
+		r.head, r.has = v, true
+		return v, true
+	case <-ctx.Done():
+		return zero, false
+	}
+}
+
+// take consumes the item returned by the last peek.
+func (r *joinReader[S]) take() {
+	r.has = false
+}
+
+// run consumes and returns the consecutive items whose key is key.
+func (r *joinReader[S]) run(ctx context.Context, key string, keyOf func(S) string) []S {
+	var items []S
+	for {
+		v, ok := r.peek(ctx)
+		if !ok || keyOf(v) != key {
+			return items
+		}
+		items = append(items, v)
+		r.take()
+	}
+}
+
+// joinDrain consumes ch until it is closed.
+func joinDrain[S any](ch <-chan S) {
+	if ch == nil {
+		return
+	}
+	for range ch {
+	}
+}
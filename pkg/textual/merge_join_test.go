@@ -0,0 +1,86 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func csvStream(records ...string) <-chan CsvCarrier {
+	ch := make(chan CsvCarrier, len(records))
+	for i, r := range records {
+		ch <- CsvCarrier{Value: r, Index: i}
+	}
+	close(ch)
+	return ch
+}
+
+func csvKey(c CsvCarrier) string {
+	k, _, _ := strings.Cut(c.Value, ",")
+	return k
+}
+
+func csvCombine(a, b CsvCarrier) CsvCarrier {
+	_, rest, _ := strings.Cut(b.Value, ",")
+	a.Value += "," + rest
+	return a
+}
+
+func TestMergeJoin(t *testing.T) {
+	left := []string{"1,ann", "2,bob", "2,bea", "4,dan"}
+	right := []string{"2,paris", "3,rome", "4,oslo", "4,lima"}
+
+	cases := []struct {
+		kind JoinKind
+		want []string
+	}{
+		{InnerJoin, []string{"2,bob,paris", "2,bea,paris", "4,dan,oslo", "4,dan,lima"}},
+		{LeftJoin, []string{"1,ann", "2,bob,paris", "2,bea,paris", "4,dan,oslo", "4,dan,lima"}},
+		{OuterJoin, []string{"1,ann", "2,bob,paris", "2,bea,paris", "3,rome", "4,dan,oslo", "4,dan,lima"}},
+	}
+	for _, tc := range cases {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		items, err := collectWithContext(ctx, MergeJoin(ctx, csvStream(left...), csvStream(right...), csvKey, csvCombine, tc.kind))
+		cancel()
+		if err != nil {
+			t.Fatalf("collect failed: %v", err)
+		}
+		if len(items) != len(tc.want) {
+			t.Fatalf("kind %d: unexpected output count: got %d want %d", tc.kind, len(items), len(tc.want))
+		}
+		for i, w := range tc.want {
+			if items[i].Value != w || items[i].Index != i {
+				t.Fatalf("kind %d: unexpected item %d: got %q (index %d) want %q", tc.kind, i, items[i].Value, items[i].Index, w)
+			}
+		}
+	}
+}
+
+func TestMergeJoin_NoMatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	out := MergeJoin(ctx, csvStream("1,a", "3,c"), csvStream("2,b"), csvKey, csvCombine, InnerJoin)
+	items, err := collectWithContext(ctx, out)
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("unexpected items: %#v", items)
+	}
+}
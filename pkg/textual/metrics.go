@@ -0,0 +1,182 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics collects throughput counters for one pipeline stage instrumented
+// with Instrument. The zero value is ready to use, and it is safe for
+// concurrent use. Read it with Snapshot.
+//
+// A Metrics is bound to the name of the first stage it observes: use one
+// Metrics per stage. Instrument panics when m is already bound to another
+// name, and measurements reported under another stage label (when used as a
+// MetricsObserver) are ignored, so counters of distinct stages are never
+// summed.
+type Metrics struct {
+	name        atomic.Pointer[string]
+	in          atomic.Int64
+	out         atomic.Int64
+	bytes       atomic.Int64
+	sendWait    atomic.Int64
+	maxSendWait atomic.Int64
+}
+
+// MetricsSnapshot is a point-in-time copy of Metrics.
+type MetricsSnapshot struct {
+	Name        string        // Name of the stage the Metrics is bound to.
+	In          int64         // Items received.
+	Out         int64         // Items delivered downstream.
+	Bytes       int64         // Total len(UTF8String()) of the received items.
+	SendWait    time.Duration // Total time spent blocked sending downstream.
+	MaxSendWait time.Duration // Longest single blocked send.
+}
+
+// Snapshot returns a copy of the current counters.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	var name string
+	if p := m.name.Load(); p != nil {
+		name = *p
+	}
+	return MetricsSnapshot{
+		Name:        name,
+		In:          m.in.Load(),
+		Out:         m.out.Load(),
+		Bytes:       m.bytes.Load(),
+		SendWait:    time.Duration(m.sendWait.Load()),
+		MaxSendWait: time.Duration(m.maxSendWait.Load()),
+	}
+}
+
+// observeSendWait records the duration of one blocked send.
+func (m *Metrics) observeSendWait(d time.Duration) {
+	m.sendWait.Add(int64(d))
+	for {
+		current := m.maxSendWait.Load()
+		if int64(d) <= current || m.maxSendWait.CompareAndSwap(current, int64(d)) {
+			return
+		}
+	}
+}
+
+// bind binds m to stage if it is not bound yet, and reports whether m is
+// bound to stage.
+func (m *Metrics) bind(stage string) bool {
+	if p := m.name.Load(); p != nil {
+		return *p == stage
+	}
+	if m.name.CompareAndSwap(nil, &stage) {
+		return true
+	}
+	return *m.name.Load() == stage
+}
+
+// IncItems counts one delivered item (MetricsObserver).
+func (m *Metrics) IncItems(stage string) {
+	if m.bind(stage) {
+		m.out.Add(1)
+	}
+}
+
+// ObserveBytes counts one received item of n bytes (MetricsObserver).
+func (m *Metrics) ObserveBytes(stage string, n int) {
+	if m.bind(stage) {
+		m.in.Add(1)
+		m.bytes.Add(int64(n))
+	}
+}
+
+// ObserveLatency records one blocked send (MetricsObserver).
+func (m *Metrics) ObserveLatency(stage string, d time.Duration) {
+	if m.bind(stage) {
+		m.observeSendWait(d)
+	}
+}
+
+// Instrument returns a pass-through Processor recording throughput metrics
+// into m, so it can be inserted anywhere in a Chain to find bottlenecks.
+//
+// It counts the items received and delivered, sums their UTF-8 byte size, and
+// measures the time spent blocked on the output send. A growing send wait means
+// the downstream stages are slower than the upstream ones (backpressure); a
+// stage whose upstream Instrument waits while its downstream one does not is
+// the bottleneck.
+//
+// name labels the snapshot, and m is bound to it: Instrument panics if m is
+// already bound to another stage name (see Metrics). Items are forwarded
+// unchanged. If m is nil, items are passed through without instrumentation.
+// Instrument is InstrumentObserver with m as the observer.
+func Instrument[S Carrier[S]](name string, m *Metrics) ProcessorFunc[S] {
+	if m == nil {
+		return passThroughProcessor[S]()
+	}
+	if !m.bind(name) {
+		panic(fmt.Sprintf("textual: Instrument %q: Metrics already bound to stage %q", name, m.Snapshot().Name))
+	}
+	return InstrumentObserver[S](name, m)
+}
+
+// MetricsObserver receives the measurements of an instrumented stage (see
+// Instrument and InstrumentObserver). It is the extension point to export
+// pipeline metrics to an external system (Prometheus, OpenTelemetry, expvar,
+// ...) without adding a dependency to textual; *Metrics implements it.
+// Implementations must be safe for concurrent use.
+type MetricsObserver interface {
+	// IncItems counts one item delivered downstream.
+	IncItems(stage string)
+	// ObserveBytes is called once per received item, with its UTF-8 byte size.
+	ObserveBytes(stage string, n int)
+	// ObserveLatency records the time an item waited to be delivered
+	// downstream (backpressure, see Instrument).
+	ObserveLatency(stage string, d time.Duration)
+}
+
+// NopMetricsObserver is a MetricsObserver discarding every measurement.
+type NopMetricsObserver struct{}
+
+func (NopMetricsObserver) IncItems(string)                      {}
+func (NopMetricsObserver) ObserveBytes(string, int)             {}
+func (NopMetricsObserver) ObserveLatency(string, time.Duration) {}
+
+// InstrumentObserver returns a pass-through Processor reporting the
+// measurements of each item to o under the stage label, as Instrument does for
+// Metrics.
+//
+// Items are forwarded unchanged. A panic in o is recorded into the PanicStore
+// and stops the stream, like Async. If o is nil, items are passed through
+// without instrumentation.
+func InstrumentObserver[S Carrier[S]](stage string, o MetricsObserver) ProcessorFunc[S] {
+	if o == nil {
+		return passThroughProcessor[S]()
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		return AsyncEmitter(ctx, in, func(ctx context.Context, item S, emit func(S)) {
+			o.ObserveBytes(stage, len(item.UTF8String()))
+			start := time.Now()
+			emit(item)
+			if ctx.Err() != nil {
+				// Not delivered: the stage is stopping.
+				return
+			}
+			o.ObserveLatency(stage, time.Since(start))
+			o.IncItems(stage)
+		})
+	}
+}
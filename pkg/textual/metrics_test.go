@@ -0,0 +1,208 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInstrument_CountsAndBackpressure(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 3)
+	for i, w := range []string{"a", "bb", "été"} {
+		in <- StringCarrier{Value: w, Index: i}
+	}
+	close(in)
+
+	var m Metrics
+	count := 0
+	for range Instrument[StringCarrier]("source", &m).Apply(ctx, in) {
+		// Slow consumer.
+		time.Sleep(10 * time.Millisecond)
+		count++
+	}
+	if count != 3 {
+		t.Fatalf("unexpected output count: got %d want %d", count, 3)
+	}
+
+	s := m.Snapshot()
+	if s.Name != "source" || s.In != 3 || s.Out != 3 {
+		t.Fatalf("unexpected counters: %+v", s)
+	}
+	if s.Bytes != 1+2+5 {
+		t.Fatalf("unexpected byte count: got %d want %d", s.Bytes, 8)
+	}
+	if s.MaxSendWait < 5*time.Millisecond || s.SendWait < s.MaxSendWait {
+		t.Fatalf("expected backpressure to be measured: %+v", s)
+	}
+}
+
+// recordingObserver is a MetricsObserver keeping every measurement.
+type recordingObserver struct {
+	mu        sync.Mutex
+	items     map[string]int
+	bytes     map[string]int
+	latencies map[string]int
+}
+
+func newRecordingObserver() *recordingObserver {
+	return &recordingObserver{items: map[string]int{}, bytes: map[string]int{}, latencies: map[string]int{}}
+}
+
+func (r *recordingObserver) IncItems(stage string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items[stage]++
+}
+
+func (r *recordingObserver) ObserveBytes(stage string, n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bytes[stage] += n
+}
+
+func (r *recordingObserver) ObserveLatency(stage string, _ time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latencies[stage]++
+}
+
+func TestInstrumentObserver_ReportsPerStage(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 3)
+	for i, w := range []string{"a", "bb", "été"} {
+		in <- StringCarrier{Value: w, Index: i}
+	}
+	close(in)
+
+	obs := newRecordingObserver()
+	chain := NewChain[StringCarrier](
+		InstrumentObserver[StringCarrier]("source", obs),
+		Filter[StringCarrier](func(_ context.Context, s StringCarrier) bool { return s.Value != "bb" }),
+		InstrumentObserver[StringCarrier]("sink", obs),
+	)
+	items, err := collectWithContext(ctx, chain.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 2)
+	}
+
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	for stage, want := range map[string][3]int{"source": {3, 8, 3}, "sink": {2, 6, 2}} {
+		got := [3]int{obs.items[stage], obs.bytes[stage], obs.latencies[stage]}
+		if got != want {
+			t.Fatalf("unexpected %s measurements (items, bytes, latencies): got %v want %v", stage, got, want)
+		}
+	}
+}
+
+func TestInstrumentObserver_Nop(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 1)
+	in <- StringCarrier{Value: "a"}
+	close(in)
+
+	items, err := collectWithContext(ctx, InstrumentObserver[StringCarrier]("nop", NopMetricsObserver{}).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Value != "a" {
+		t.Fatalf("unexpected output: got %+v", items)
+	}
+}
+
+// panickingObserver panics on every delivered item.
+type panickingObserver struct{ NopMetricsObserver }
+
+func (panickingObserver) IncItems(string) { panic("observer failed") }
+
+func TestInstrumentObserver_ObserverPanicIsRecorded(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ctx, ps := WithPanicStore(ctx)
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "a", Index: 0}
+	in <- StringCarrier{Value: "b", Index: 1}
+	close(in)
+
+	items, err := collectWithContext(ctx, InstrumentObserver[StringCarrier]("p", panickingObserver{}).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 1)
+	}
+	if info, ok := ps.Load(); !ok || info.Value != "observer failed" {
+		t.Fatalf("expected the observer panic in the PanicStore, got %+v (ok %v)", info, ok)
+	}
+}
+
+func TestMetrics_IsAMetricsObserver(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "ab", Index: 0}
+	in <- StringCarrier{Value: "c", Index: 1}
+	close(in)
+
+	var m Metrics
+	var o MetricsObserver = &m
+	if _, err := collectWithContext(ctx, InstrumentObserver[StringCarrier]("s", o).Apply(ctx, in)); err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if snap := m.Snapshot(); snap.In != 2 || snap.Out != 2 || snap.Bytes != 3 {
+		t.Fatalf("unexpected snapshot: got %+v", snap)
+	}
+}
+
+func TestInstrument_OneMetricsPerStage(t *testing.T) {
+	var m Metrics
+	Instrument[StringCarrier]("a", &m)
+	Instrument[StringCarrier]("a", &m) // same stage: allowed
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected a panic when binding a Metrics to a second stage")
+		}
+		if got := m.Snapshot().Name; got != "a" {
+			t.Fatalf("unexpected name: got %q want %q", got, "a")
+		}
+	}()
+	Instrument[StringCarrier]("b", &m)
+}
+
+func TestMetrics_IgnoresOtherStageLabels(t *testing.T) {
+	var m Metrics
+	m.ObserveBytes("a", 3)
+	m.ObserveBytes("b", 5)
+	m.IncItems("b")
+	if snap := m.Snapshot(); snap.Name != "a" || snap.In != 1 || snap.Bytes != 3 || snap.Out != 0 {
+		t.Fatalf("unexpected snapshot: got %+v", snap)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidContact is attached to a Parcel by NewNormalizeContacts when a
+// detected email address or URL is malformed.
+var ErrInvalidContact = errors.New("textual: invalid contact")
+
+// NewNormalizeContacts returns a Processor that detects email addresses and
+// URLs in the text of a Parcel and normalizes them through Fragments.
+//
+// Detection works on whitespace-separated words, after trimming surrounding
+// punctuation (a trailing "." or ")" is not part of an address):
+//
+//   - a word containing "@" is an email address: its domain is lower-cased,
+//     the local part is kept as is,
+//   - a word starting with "http://", "https://" or "www." is a URL: its
+//     scheme and host are lower-cased, the path and query are kept as is.
+//
+// Each valid address becomes a Fragment whose Label is "email" or "url" and
+// whose Transformed is the normalized form. Other text stays raw. A detected
+// but malformed address stays raw and an ErrInvalidContact error naming it is
+// attached to the Parcel (via WithError).
+func NewNormalizeContacts() ProcessorFunc[Parcel] {
+	return NewProcessorFunc(func(_ context.Context, p Parcel) Parcel {
+		runes := []rune(string(p.Text))
+		for i := 0; i < len(runes); {
+			if unicode.IsSpace(runes[i]) {
+				i++
+				continue
+			}
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) {
+				i++
+			}
+			// Trim surrounding punctuation.
+			s, e := start, i
+			for s < e && strings.ContainsRune(`([{<"'`, runes[s]) {
+				s++
+			}
+			for e > s && strings.ContainsRune(`.,;:!?)]}>"'`, runes[e-1]) {
+				e--
+			}
+			word := string(runes[s:e])
+
+			var (
+				label      string
+				normalized string
+				ok         bool
+			)
+			switch lower := strings.ToLower(word); {
+			case strings.Contains(word, "@"):
+				label = "email"
+				normalized, ok = normalizeEmail(word)
+			case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"), strings.HasPrefix(lower, "www."):
+				label = "url"
+				normalized, ok = normalizeURL(word)
+			default:
+				continue
+			}
+			if !ok {
+				p = p.WithError(fmt.Errorf("%w %s: %q", ErrInvalidContact, label, word))
+				continue
+			}
+			p.Fragments = append(p.Fragments, Fragment{
+				Transformed: UTF8String(normalized),
+				Pos:         s,
+				Len:         e - s,
+				Confidence:  1,
+				Label:       label,
+			})
+		}
+		return p
+	})
+}
+
+// normalizeEmail lower-cases the domain of a syntactically valid address.
+func normalizeEmail(s string) (string, bool) {
+	at := strings.IndexByte(s, '@')
+	if at <= 0 || at != strings.LastIndexByte(s, '@') {
+		return "", false
+	}
+	local, domain := s[:at], strings.ToLower(s[at+1:])
+	if strings.ContainsAny(local, " ,;:<>()[]\\\"") || !validHostname(domain) || !strings.Contains(domain, ".") {
+		return "", false
+	}
+	return local + "@" + domain, true
+}
+
+// normalizeURL lower-cases the scheme and the host of a URL. The rest of the
+// URL (user info, port, path, query and fragment) is kept byte for byte.
+func normalizeURL(s string) (string, bool) {
+	withScheme := s
+	if strings.HasPrefix(strings.ToLower(s), "www.") {
+		withScheme = "http://" + s
+	}
+	u, err := url.Parse(withScheme)
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+	host := strings.ToLower(u.Hostname())
+	if !validHostname(host) || (!strings.Contains(host, ".") && host != "localhost") {
+		return "", false
+	}
+
+	// Rebuild from the original bytes: u.String() would re-escape the path
+	// and the query.
+	scheme, authority := "", s
+	if withScheme == s {
+		i := strings.Index(s, "://")
+		if i < 0 {
+			return "", false
+		}
+		scheme, authority = strings.ToLower(s[:i+3]), s[i+3:]
+	}
+	rest := ""
+	if end := strings.IndexAny(authority, "/?#"); end >= 0 {
+		authority, rest = authority[:end], authority[end:]
+	}
+	at := strings.LastIndexByte(authority, '@') + 1
+	return scheme + authority[:at] + strings.ToLower(authority[at:]) + rest, true
+}
+
+// validHostname reports whether host is made of non-empty labels of letters,
+// digits and inner hyphens.
+func validHostname(host string) bool {
+	if host == "" {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewNormalizeContacts(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan Parcel, 2)
+	in <- ParcelFrom("Write to John.Doe@Example.COM, or see (HTTPS://Example.org/Path?q=A).").WithIndex(0)
+	in <- ParcelFrom("Broken: jane@@example.com and http://exa_mple.com").WithIndex(1)
+	close(in)
+
+	items, err := collectWithContext(ctx, NewNormalizeContacts().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	sortByIndex(items)
+	if len(items) != 2 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 2)
+	}
+
+	valid := items[0]
+	if valid.GetError() != nil {
+		t.Fatalf("unexpected error: %v", valid.GetError())
+	}
+	want := "Write to John.Doe@example.com, or see (https://example.org/Path?q=A)."
+	if got := valid.UTF8String(); got != UTF8String(want) {
+		t.Fatalf("unexpected normalization:\n got: %q\nwant: %q", got, want)
+	}
+	if len(valid.Fragments) != 2 || valid.Fragments[0].Label != "email" || valid.Fragments[1].Label != "url" {
+		t.Fatalf("unexpected fragments: %#v", valid.Fragments)
+	}
+
+	malformed := items[1]
+	if len(malformed.Fragments) != 0 || malformed.UTF8String() != malformed.Text {
+		t.Fatalf("malformed contacts should stay raw: %#v", malformed)
+	}
+	err = malformed.GetError()
+	if !errors.Is(err, ErrInvalidContact) || !strings.Contains(err.Error(), "jane@@example.com") || !strings.Contains(err.Error(), "exa_mple") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNormalizeURL_KeepsPathAndQueryBytes(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"https://Example.com/café", "https://example.com/café"},
+		{"HTTP://Example.com/a|b?q=x y", "http://example.com/a|b?q=x y"},
+		{"https://User@Example.COM:8080/P%41th?Q=A#Frag", "https://User@example.com:8080/P%41th?Q=A#Frag"},
+		{"www.Example.com/Café?q=é", "www.example.com/Café?q=é"},
+	}
+	for _, c := range cases {
+		got, ok := normalizeURL(c.in)
+		if !ok {
+			t.Fatalf("normalizeURL(%q) rejected a valid URL", c.in)
+		}
+		if got != c.want {
+			t.Fatalf("unexpected normalization of %q: got %q want %q", c.in, got, c.want)
+		}
+	}
+}
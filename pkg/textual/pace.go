@@ -0,0 +1,54 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"time"
+)
+
+// Pace returns a Processor that forwards items unchanged with at least interval
+// between two emissions, to replay a stream at a steady cadence (demos,
+// typewriter effects).
+//
+// The first item is emitted without delay. While the stage waits, it does not
+// read its input, so upstream is slowed down by backpressure rather than
+// buffered. A consumer slower than interval is not delayed further: the gap is
+// measured from the previous emission. Waiting stops when ctx is canceled.
+//
+// When interval <= 0, items are passed through without delay.
+func Pace[S Carrier[S]](interval time.Duration) ProcessorFunc[S] {
+	if interval <= 0 {
+		return passThroughProcessor[S]()
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		var last time.Time // time of the previous emission
+		return AsyncEmitter(ctx, in, func(ctx context.Context, item S, emit func(S)) {
+			if !last.IsZero() {
+				if wait := interval - time.Since(last); wait > 0 {
+					timer := time.NewTimer(wait)
+					select {
+					case <-timer.C:
+					case <-ctx.Done():
+						timer.Stop()
+						return
+					}
+				}
+			}
+			emit(item)
+			last = time.Now()
+		})
+	}
+}
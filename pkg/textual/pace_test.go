@@ -0,0 +1,69 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPace_SpacesEmissions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	const interval = 20 * time.Millisecond
+	in := make(chan StringCarrier, 4)
+	for i, s := range []string{"a", "b", "c", "d"} {
+		in <- StringCarrier{Value: s, Index: i}
+	}
+	close(in)
+
+	start := time.Now()
+	out := Pace[StringCarrier](interval).Apply(ctx, in)
+
+	var times []time.Time
+	for range out {
+		times = append(times, time.Now())
+	}
+	if len(times) != 4 {
+		t.Fatalf("unexpected output count: got %d want %d", len(times), 4)
+	}
+	if first := times[0].Sub(start); first >= interval {
+		t.Fatalf("unexpected delay before the first item: got %v", first)
+	}
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); gap < interval-5*time.Millisecond {
+			t.Fatalf("unexpected gap before item %d: got %v want at least %v", i, gap, interval)
+		}
+	}
+}
+
+func TestPace_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	stageCtx, stop := context.WithCancel(ctx)
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "a"}
+	in <- StringCarrier{Value: "b", Index: 1}
+
+	out := Pace[StringCarrier](time.Hour).Apply(stageCtx, in)
+	<-out
+	stop()
+	if _, err := collectWithContext(ctx, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
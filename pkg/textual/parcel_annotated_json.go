@@ -0,0 +1,98 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"unicode/utf8"
+)
+
+// ErrFragmentOutOfBounds is attached to a Parcel rebuilt from annotated JSON
+// when a fragment does not fit within the text.
+var ErrFragmentOutOfBounds = errors.New("textual: fragment out of bounds")
+
+// AnnotatedParcel is the "annotated JSON" representation of a Parcel: the
+// original text plus the transformed spans, e.g.
+//
+//	{
+//	  "text": "Bonjour le monde",
+//	  "fragments": [
+//	    {"transformed": "bɔ̃ʒuʁ", "pos": 0, "len": 7, "confidence": 0.9, "variant": 0}
+//	  ]
+//	}
+//
+// Fragment positions and lengths are expressed in runes, as in Parcel.
+type AnnotatedParcel struct {
+	Text      UTF8String `json:"text"`
+	Fragments []Fragment `json:"fragments"`
+}
+
+// NewParcelToAnnotatedJSON returns a Transcoder that serializes each Parcel
+// into its AnnotatedParcel JSON representation (1:1).
+//
+// The index and the per-item error of the Parcel are preserved on the
+// JsonCarrier. A Parcel without fragments is rendered with an empty
+// "fragments" array.
+func NewParcelToAnnotatedJSON() TranscoderFunc[Parcel, JsonCarrier] {
+	return NewTranscoderFunc(func(_ context.Context, p Parcel) JsonCarrier {
+		a := AnnotatedParcel{Text: p.Text, Fragments: p.Fragments}
+		if a.Fragments == nil {
+			a.Fragments = make([]Fragment, 0)
+		}
+		res := JsonCarrier{Index: p.Index}
+		b, err := json.Marshal(a)
+		if err != nil {
+			return res.WithError(transcodeErrorf("annotated json (index %d): %w", p.Index, err)).WithError(p.Error)
+		}
+		res.Value = b
+		return res.WithError(p.Error)
+	})
+}
+
+// NewAnnotatedJSONToParcel returns a Transcoder that rebuilds a Parcel from its
+// AnnotatedParcel JSON representation (1:1). It is the inverse of
+// NewParcelToAnnotatedJSON.
+//
+// Fragment bounds are validated against the text (in runes): a fragment with a
+// negative Pos or Len, or extending past the end of the text, is dropped and
+// an ErrFragmentOutOfBounds error is attached to the Parcel. Valid fragments
+// are kept.
+//
+// When the JSON cannot be decoded, the Parcel has an empty text and carries the
+// decoding error. The index and the per-item error of the JsonCarrier are
+// preserved.
+func NewAnnotatedJSONToParcel() TranscoderFunc[JsonCarrier, Parcel] {
+	return NewTranscoderFunc(func(_ context.Context, j JsonCarrier) Parcel {
+		res := ParcelFrom("").WithIndex(j.Index).WithError(j.Error)
+
+		var a AnnotatedParcel
+		if err := json.Unmarshal(j.Value, &a); err != nil {
+			return res.WithError(transcodeErrorf("annotated parcel (index %d): %w", j.Index, err))
+		}
+
+		res.Text = a.Text
+		textLen := utf8.RuneCountInString(a.Text)
+		for _, f := range a.Fragments {
+			if f.Pos < 0 || f.Len < 0 || f.Pos+f.Len > textLen {
+				res = res.WithError(transcodeErrorf("%w: pos %d len %d (text length %d)", ErrFragmentOutOfBounds, f.Pos, f.Len, textLen))
+				continue
+			}
+			res.Fragments = append(res.Fragments, f)
+		}
+		return res
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func annotatedTestParcel() Parcel {
+	return Parcel{
+		Index: 7,
+		Text:  "Le chat dort",
+		Fragments: []Fragment{
+			{Transformed: "lə", Pos: 0, Len: 2, Confidence: 0.9},
+			{Transformed: "ʃa", Pos: 3, Len: 4, Confidence: 0.8},
+			{Transformed: "ʃat", Pos: 3, Len: 4, Confidence: 0.2, Variant: 1},
+		},
+	}
+}
+
+func TestNewParcelToAnnotatedJSON_SerializesTextAndFragments(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	p := annotatedTestParcel()
+	in := make(chan Parcel, 2)
+	in <- p
+	in <- Parcel{Index: 8, Text: "raw"}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewParcelToAnnotatedJSON().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 2)
+	}
+	sortByIndex(items)
+
+	a, err := CastJson[AnnotatedParcel](items[0])
+	if err != nil {
+		t.Fatalf("unexpected cast error: %v", err)
+	}
+	if items[0].Index != p.Index || a.Text != p.Text || !reflect.DeepEqual(a.Fragments, p.Fragments) {
+		t.Fatalf("unexpected round trip:\n got: %#v (index %d)\nwant: %#v", a, items[0].Index, p)
+	}
+
+	if got, want := string(items[1].Value), `{"text":"raw","fragments":[]}`; got != want {
+		t.Fatalf("unexpected json: got %q want %q", got, want)
+	}
+}
+
+func TestNewAnnotatedJSONToParcel_RoundTrip(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	p := annotatedTestParcel()
+	in := make(chan Parcel, 1)
+	in <- p
+	close(in)
+
+	encoded := NewParcelToAnnotatedJSON().Apply(ctx, in)
+	items, err := collectWithContext(ctx, NewAnnotatedJSONToParcel().Apply(ctx, encoded))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 1)
+	}
+	if !reflect.DeepEqual(items[0], p) {
+		t.Fatalf("unexpected round trip:\n got: %#v\nwant: %#v", items[0], p)
+	}
+	if got, want := items[0].UTF8String(), p.UTF8String(); got != want {
+		t.Fatalf("unexpected rendering: got %q want %q", got, want)
+	}
+}
+
+func TestNewAnnotatedJSONToParcel_RejectsOutOfBoundsFragments(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan JsonCarrier, 2)
+	in <- JSONFrom(`{"text":"abc","fragments":[{"transformed":"A","pos":0,"len":1},{"transformed":"X","pos":2,"len":5}]}`).WithIndex(0)
+	in <- JSONFrom(`{"text":`).WithIndex(1)
+	close(in)
+
+	items, err := collectWithContext(ctx, NewAnnotatedJSONToParcel().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	sortByIndex(items)
+	if len(items) != 2 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 2)
+	}
+
+	if !errors.Is(items[0].GetError(), ErrFragmentOutOfBounds) {
+		t.Fatalf("expected ErrFragmentOutOfBounds, got %v", items[0].GetError())
+	}
+	if len(items[0].Fragments) != 1 || items[0].UTF8String() != "Abc" {
+		t.Fatalf("expected the valid fragment to be kept: %#v", items[0])
+	}
+
+	if items[1].GetError() == nil {
+		t.Fatalf("expected a decoding error for malformed json")
+	}
+}
@@ -16,8 +16,10 @@ package textual
 
 import (
 	"errors"
+	"fmt"
 	"sort"
 	"strings"
+	"unicode/utf8"
 )
 
 // Parcel is a Carrier implementation designed for partial transformations.
@@ -61,12 +63,15 @@ type Parcel struct {
 // (e.g. alternative phonetic renderings).
 // Confidence is an arbitrary score (0..1 by convention) produced by the
 // processor.
+// Label optionally classifies the span (script name, token type, ...); it is
+// not rendered.
 type Fragment struct {
-	Transformed UTF8String `json:"transformed"` // Transformed text (dialect-specific: IPA, SAMPA, pseudo phonetics, ...).
-	Pos         int        `json:"pos"`         // Start position (rune index) in the original text.
-	Len         int        `json:"len"`         // Length (in runes) of the original span.
-	Confidence  float64    `json:"confidence"`  // Confidence score.
-	Variant     int        `json:"variant"`     // Variant number when offering multiple candidates.
+	Transformed UTF8String `json:"transformed"`     // Transformed text (dialect-specific: IPA, SAMPA, pseudo phonetics, ...).
+	Pos         int        `json:"pos"`             // Start position (rune index) in the original text.
+	Len         int        `json:"len"`             // Length (in runes) of the original span.
+	Confidence  float64    `json:"confidence"`      // Confidence score.
+	Variant     int        `json:"variant"`         // Variant number when offering multiple candidates.
+	Label       string     `json:"label,omitempty"` // Optional classification of the span.
 }
 
 // RawTexts is a set of raw (non-transformed) segments derived from a Parcel.
@@ -173,6 +178,76 @@ func (r Parcel) GetError() error {
 	return r.Error
 }
 
+// ErrMatchNotFound is attached by AddFragmentForMatch when the matched
+// substring does not occur in Parcel.Text.
+var ErrMatchNotFound = errors.New("textual: match not found")
+
+// AddFragmentAt returns a copy of r with a fragment covering the rune range
+// [pos, pos+length) of Text appended (Variant 0).
+//
+// The range is validated against the rune length of Text: when it does not
+// fit, no fragment is added and an ErrFragmentOutOfBounds error is attached
+// instead. The receiver's Fragments slice is never modified.
+func (r Parcel) AddFragmentAt(pos, length int, transformed UTF8String, confidence float64) Parcel {
+	textLen := utf8.RuneCountInString(r.Text)
+	if pos < 0 || length < 0 || pos+length > textLen {
+		return r.WithError(fmt.Errorf("%w: pos %d len %d (text length %d)", ErrFragmentOutOfBounds, pos, length, textLen))
+	}
+	fragments := make([]Fragment, len(r.Fragments), len(r.Fragments)+1)
+	copy(fragments, r.Fragments)
+	r.Fragments = append(fragments, Fragment{
+		Transformed: transformed,
+		Pos:         pos,
+		Len:         length,
+		Confidence:  confidence,
+	})
+	return r
+}
+
+// AddFragmentForMatch returns a copy of r with a fragment replacing the first
+// occurrence of original in Text by transformed, with a confidence of 1.
+//
+// The rune position is computed from the byte offset of the match, so callers
+// do not have to deal with multi-byte characters. When original is empty or
+// does not occur in Text, an ErrMatchNotFound error is attached instead.
+func (r Parcel) AddFragmentForMatch(original UTF8String, transformed UTF8String) Parcel {
+	offset := strings.Index(r.Text, original)
+	if original == "" || offset < 0 {
+		return r.WithError(fmt.Errorf("%w: %q", ErrMatchNotFound, original))
+	}
+	pos := utf8.RuneCountInString(r.Text[:offset])
+	return r.AddFragmentAt(pos, utf8.RuneCountInString(original), transformed, 1)
+}
+
+// Aggregate concatenates the texts of items after stably sorting them by Index.
+//
+// Fragments are kept and their Pos is shifted by the rune length of the texts
+// that precede them, so they keep pointing at the same spans. The result
+// carries the first index and the joined per-item errors.
+func (r Parcel) Aggregate(items []Parcel) Parcel {
+	if len(items) == 0 {
+		return r.FromUTF8String("")
+	}
+	sorted := sortedByIndex(items)
+	var text strings.Builder
+	fragments := make([]Fragment, 0)
+	offset := 0
+	for _, it := range sorted {
+		text.WriteString(it.Text)
+		for _, f := range it.Fragments {
+			f.Pos += offset
+			fragments = append(fragments, f)
+		}
+		offset += utf8.RuneCountInString(it.Text)
+	}
+	res := Parcel{
+		Index:     sorted[0].Index,
+		Text:      UTF8String(text.String()),
+		Fragments: fragments,
+	}
+	return withJoinedErrors(res, sorted)
+}
+
 /////////////////////////////////
 //
 //
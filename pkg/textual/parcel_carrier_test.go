@@ -0,0 +1,65 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParcel_AddFragmentForMatch(t *testing.T) {
+	p := Parcel{}.FromUTF8String("Un café très chaud")
+	res := p.AddFragmentForMatch("café", "kafe")
+
+	if res.GetError() != nil {
+		t.Fatalf("unexpected error: %v", res.GetError())
+	}
+	if len(p.Fragments) != 0 {
+		t.Fatalf("receiver must not be modified: got %d fragments", len(p.Fragments))
+	}
+	if len(res.Fragments) != 1 {
+		t.Fatalf("unexpected fragment count: got %d want %d", len(res.Fragments), 1)
+	}
+	if f := res.Fragments[0]; f.Pos != 3 || f.Len != 4 || f.Confidence != 1 {
+		t.Fatalf("unexpected fragment: %#v", f)
+	}
+
+	raw := res.RawTexts()
+	if len(raw) != 2 || raw[0].Text != "Un " || raw[1].Text != " très chaud" || raw[1].Pos != 7 {
+		t.Fatalf("unexpected raw texts: %#v", raw)
+	}
+	if got, want := res.UTF8String(), "Un kafe très chaud"; got != want {
+		t.Fatalf("unexpected reconstruction: got %q want %q", got, want)
+	}
+}
+
+func TestParcel_AddFragmentAt_Bounds(t *testing.T) {
+	p := Parcel{}.FromUTF8String("café")
+
+	res := p.AddFragmentAt(3, 1, "e", 0.5)
+	if res.GetError() != nil || len(res.Fragments) != 1 || res.UTF8String() != "cafe" {
+		t.Fatalf("unexpected result: %#v", res)
+	}
+
+	res = p.AddFragmentAt(3, 2, "e", 0.5)
+	if !errors.Is(res.GetError(), ErrFragmentOutOfBounds) || len(res.Fragments) != 0 {
+		t.Fatalf("expected ErrFragmentOutOfBounds without fragment, got %#v", res)
+	}
+
+	res = p.AddFragmentForMatch("tea", "ti")
+	if !errors.Is(res.GetError(), ErrMatchNotFound) || len(res.Fragments) != 0 {
+		t.Fatalf("expected ErrMatchNotFound without fragment, got %#v", res)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// ErrFragmentOverlap is returned by Parcel.Validate when two fragments starting
+// at different positions cover a common span.
+var ErrFragmentOverlap = errors.New("textual: overlapping fragments")
+
+// OverlapStrategy tells Parcel.ResolveOverlaps which fragment wins when two
+// fragments overlap.
+type OverlapStrategy int
+
+const (
+	// OverlapKeepConfident keeps the fragment with the highest Confidence
+	// (ties: the longer span, then the first fragment).
+	OverlapKeepConfident OverlapStrategy = iota
+	// OverlapKeepLonger keeps the fragment covering the longest span
+	// (ties: the highest Confidence, then the first fragment).
+	OverlapKeepLonger
+	// OverlapMaxConfidence keeps the set of non-overlapping fragments whose
+	// total Confidence is the highest (weighted interval scheduling), so two
+	// fragments may win over a single more confident one covering both.
+	OverlapMaxConfidence
+)
+
+// Validate checks the fragment invariants of r:
+//
+//   - every fragment fits within Text (Pos >= 0, Len >= 0, Pos+Len <= rune
+//     length), otherwise ErrFragmentOutOfBounds is reported,
+//   - fragments starting at different positions do not overlap, otherwise
+//     ErrFragmentOverlap is reported.
+//
+// Fragments sharing the same Pos are variants of the same span and are not
+// considered overlapping. Adjacent fragments ([0,3) and [3,5)) are valid.
+//
+// All violations are joined in the returned error; nil means the Parcel renders
+// deterministically.
+func (r Parcel) Validate() error {
+	var errs []error
+	textLen := utf8.RuneCountInString(r.Text)
+	for _, f := range r.Fragments {
+		if f.Pos < 0 || f.Len < 0 || f.Pos+f.Len > textLen {
+			errs = append(errs, fmt.Errorf("%w: pos %d len %d (text length %d)", ErrFragmentOutOfBounds, f.Pos, f.Len, textLen))
+		}
+	}
+	for i := 0; i < len(r.Fragments); i++ {
+		for j := i + 1; j < len(r.Fragments); j++ {
+			a, b := r.Fragments[i], r.Fragments[j]
+			if a.Pos != b.Pos && fragmentsOverlap(a, b) {
+				errs = append(errs, fmt.Errorf("%w: [%d,%d) and [%d,%d)", ErrFragmentOverlap, a.Pos, a.Pos+a.Len, b.Pos, b.Pos+b.Len))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ResolveOverlaps returns a copy of r without overlapping fragments.
+//
+// Fragments sharing the same Pos are handled as a group of variants: a group
+// is represented by its best fragment according to strategy, and overlaps are
+// checked against its widest span. A kept group keeps all its variants.
+//
+// The selection rule depends on strategy:
+//
+//   - OverlapKeepConfident and OverlapKeepLonger rank the groups by their best
+//     fragment (see the strategy ordering) and accept them greedily, best
+//     first: a group is dropped when it overlaps an already accepted one.
+//   - OverlapMaxConfidence weighs each group with the Confidence of its best
+//     fragment and keeps the non-overlapping groups with the highest total
+//     weight (weighted interval scheduling, see maxWeightSchedule). Among
+//     equal totals, the selection keeping more groups wins.
+//
+// The kept fragments preserve their original order; the receiver is left
+// untouched.
+func (r Parcel) ResolveOverlaps(strategy OverlapStrategy) Parcel {
+	type group struct {
+		best Fragment
+		span Fragment // widest span of the group, used for overlap checks
+	}
+	better := func(a, b Fragment) bool {
+		if strategy == OverlapKeepLonger {
+			if a.Len != b.Len {
+				return a.Len > b.Len
+			}
+			return a.Confidence > b.Confidence
+		}
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		return a.Len > b.Len
+	}
+
+	var groups []*group
+	byPos := make(map[int]*group)
+	for _, f := range r.Fragments {
+		g, ok := byPos[f.Pos]
+		if !ok {
+			g = &group{best: f, span: f}
+			byPos[f.Pos] = g
+			groups = append(groups, g)
+			continue
+		}
+		if better(f, g.best) {
+			g.best = f
+		}
+		if f.Len > g.span.Len {
+			g.span = f
+		}
+	}
+
+	keep := make(map[int]bool) // Pos -> kept
+	if strategy == OverlapMaxConfidence {
+		spans := make([]Fragment, len(groups))
+		weights := make([]float64, len(groups))
+		for i, g := range groups {
+			spans[i], weights[i] = g.span, g.best.Confidence
+		}
+		for _, i := range maxWeightSchedule(spans, weights) {
+			keep[groups[i].best.Pos] = true
+		}
+		return r.keepFragments(keep)
+	}
+
+	ranked := append([]*group(nil), groups...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return better(ranked[i].best, ranked[j].best)
+	})
+	var accepted []*group
+	for _, g := range ranked {
+		ok := true
+		for _, a := range accepted {
+			if fragmentsOverlap(g.span, a.span) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			accepted = append(accepted, g)
+			keep[g.best.Pos] = true
+		}
+	}
+
+	return r.keepFragments(keep)
+}
+
+// keepFragments returns a copy of r with only the fragments whose Pos is in
+// keep, in their original order.
+func (r Parcel) keepFragments(keep map[int]bool) Parcel {
+	fragments := make([]Fragment, 0, len(r.Fragments))
+	for _, f := range r.Fragments {
+		if keep[f.Pos] {
+			fragments = append(fragments, f)
+		}
+	}
+	r.Fragments = fragments
+	return r
+}
+
+// maxWeightSchedule returns the indices, in increasing order, of the
+// non-overlapping spans with the highest total weight (weighted interval
+// scheduling). Among equal totals, the selection with more spans wins, so
+// zero-weight spans are kept when they overlap nothing.
+func maxWeightSchedule(spans []Fragment, weights []float64) []int {
+	type score struct {
+		weight float64
+		count  int
+	}
+	add := func(s score, w float64) score { return score{s.weight + w, s.count + 1} }
+	greater := func(a, b score) bool {
+		if a.weight != b.weight {
+			return a.weight > b.weight
+		}
+		return a.count > b.count
+	}
+
+	n := len(spans)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	end := func(i int) int { return spans[i].Pos + spans[i].Len }
+	sort.SliceStable(order, func(a, b int) bool { return end(order[a]) < end(order[b]) })
+
+	// best[k] is the best score using the first k spans of order; prev[k] is
+	// the number of spans of order ending before span order[k-1] starts.
+	best := make([]score, n+1)
+	prev := make([]int, n+1)
+	for k := 1; k <= n; k++ {
+		i := order[k-1]
+		prev[k] = sort.Search(k-1, func(j int) bool { return end(order[j]) > spans[i].Pos })
+		best[k] = best[k-1]
+		if with := add(best[prev[k]], weights[i]); greater(with, best[k]) {
+			best[k] = with
+		}
+	}
+
+	var picked []int
+	for k := n; k > 0; {
+		i := order[k-1]
+		if greater(add(best[prev[k]], weights[i]), best[k-1]) {
+			picked = append(picked, i)
+			k = prev[k]
+			continue
+		}
+		k--
+	}
+	sort.Ints(picked)
+	return picked
+}
+
+// fragmentsOverlap reports whether the rune ranges of a and b intersect.
+func fragmentsOverlap(a, b Fragment) bool {
+	return a.Pos < b.Pos+b.Len && b.Pos < a.Pos+a.Len
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParcel_ValidateAndResolveOverlaps(t *testing.T) {
+	const text = "abcdefghij"
+	cases := []struct {
+		name      string
+		fragments []Fragment
+		overlap   bool
+		confident UTF8String
+		longer    UTF8String
+	}{
+		{
+			name: "adjacent",
+			fragments: []Fragment{
+				{Transformed: "X", Pos: 0, Len: 3, Confidence: 0.5},
+				{Transformed: "Y", Pos: 3, Len: 2, Confidence: 0.9},
+			},
+			confident: "XYfghij",
+			longer:    "XYfghij",
+		},
+		{
+			name: "nested",
+			fragments: []Fragment{
+				{Transformed: "OUTER", Pos: 0, Len: 6, Confidence: 0.4},
+				{Transformed: "in", Pos: 2, Len: 2, Confidence: 0.8},
+			},
+			overlap:   true,
+			confident: "abinefghij",
+			longer:    "OUTERghij",
+		},
+		{
+			name: "partial",
+			fragments: []Fragment{
+				{Transformed: "L", Pos: 1, Len: 4, Confidence: 0.9},
+				{Transformed: "R", Pos: 3, Len: 5, Confidence: 0.3},
+			},
+			overlap:   true,
+			confident: "aLfghij",
+			longer:    "abcRij",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := Parcel{Text: text, Fragments: tc.fragments}
+			err := p.Validate()
+			if got := errors.Is(err, ErrFragmentOverlap); got != tc.overlap {
+				t.Fatalf("unexpected validation result: %v", err)
+			}
+			for _, c := range []struct {
+				strategy OverlapStrategy
+				want     UTF8String
+			}{{OverlapKeepConfident, tc.confident}, {OverlapKeepLonger, tc.longer}} {
+				resolved := p.ResolveOverlaps(c.strategy)
+				if err := resolved.Validate(); err != nil {
+					t.Fatalf("resolved parcel is invalid: %v", err)
+				}
+				if got := resolved.UTF8String(); got != c.want {
+					t.Fatalf("unexpected rendering (strategy %d): got %q want %q", c.strategy, got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParcel_ValidateReportsOutOfBounds(t *testing.T) {
+	p := Parcel{Text: "abc", Fragments: []Fragment{{Transformed: "x", Pos: 2, Len: 5}}}
+	if err := p.Validate(); !errors.Is(err, ErrFragmentOutOfBounds) {
+		t.Fatalf("expected ErrFragmentOutOfBounds, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+// VariantMode tells Parcel.SelectVariant which fragment to keep among the
+// candidates sharing the same Pos.
+//
+// Use VariantFirst, VariantBest or VariantByNumber(n).
+type VariantMode struct {
+	kind   variantKind
+	number int
+}
+
+type variantKind int
+
+const (
+	variantFirst variantKind = iota
+	variantBest
+	variantNumber
+)
+
+var (
+	// VariantFirst keeps the first fragment found for each Pos. This is what
+	// UTF8String renders on an unfiltered Parcel.
+	VariantFirst = VariantMode{kind: variantFirst}
+
+	// VariantBest keeps the fragment with the highest Confidence for each Pos
+	// (the first one wins ties).
+	VariantBest = VariantMode{kind: variantBest}
+)
+
+// VariantByNumber keeps, for each Pos, the fragment whose Variant is n. Spans
+// without such a variant are left raw.
+func VariantByNumber(n int) VariantMode {
+	return VariantMode{kind: variantNumber, number: n}
+}
+
+// SelectVariant returns a copy of r keeping at most one fragment per Pos,
+// chosen according to mode. UTF8String renders the filtered Parcel
+// deterministically.
+//
+// Fragments keep their relative order; the receiver is left untouched.
+func (r Parcel) SelectVariant(mode VariantMode) Parcel {
+	selected := make([]Fragment, 0, len(r.Fragments))
+	slot := make(map[int]int) // Pos -> index in selected
+	for _, f := range r.Fragments {
+		if mode.kind == variantNumber && f.Variant != mode.number {
+			continue
+		}
+		i, seen := slot[f.Pos]
+		if !seen {
+			slot[f.Pos] = len(selected)
+			selected = append(selected, f)
+			continue
+		}
+		if mode.kind == variantBest && f.Confidence > selected[i].Confidence {
+			selected[i] = f
+		}
+	}
+	r.Fragments = selected
+	return r
+}
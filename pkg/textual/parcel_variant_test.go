@@ -0,0 +1,51 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import "testing"
+
+func TestParcel_SelectVariant(t *testing.T) {
+	p := Parcel{
+		Text: "tomato soup",
+		Fragments: []Fragment{
+			{Transformed: "təˈmeɪtoʊ", Pos: 0, Len: 6, Confidence: 0.4, Variant: 0},
+			{Transformed: "təˈmɑːtəʊ", Pos: 0, Len: 6, Confidence: 0.9, Variant: 1},
+			{Transformed: "toˈmato", Pos: 0, Len: 6, Confidence: 0.2, Variant: 2},
+			{Transformed: "suːp", Pos: 7, Len: 4, Confidence: 1, Variant: 0},
+		},
+	}
+
+	cases := []struct {
+		name string
+		mode VariantMode
+		want UTF8String
+	}{
+		{"first", VariantFirst, "təˈmeɪtoʊ suːp"},
+		{"best", VariantBest, "təˈmɑːtəʊ suːp"},
+		{"by number", VariantByNumber(2), "toˈmato soup"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := p.SelectVariant(tc.mode)
+			if s := got.UTF8String(); s != tc.want {
+				t.Fatalf("unexpected rendering: got %q want %q", s, tc.want)
+			}
+		})
+	}
+
+	if len(p.Fragments) != 4 {
+		t.Fatalf("SelectVariant must not modify the receiver")
+	}
+}
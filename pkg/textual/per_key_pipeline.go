@@ -0,0 +1,134 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"container/list"
+	"context"
+	"runtime/debug"
+	"sync"
+)
+
+// NewPerKeyPipeline returns a Processor routing each item to a dedicated
+// sub-pipeline for its key, for stateful per-key processing (sessions,
+// per-user counters, ...).
+//
+// Sub-pipelines are created lazily with factory(key) on the first item of a
+// key; all their outputs are merged into the returned channel (fan-in), so
+// the output order across keys is not deterministic.
+//
+// At most maxKeys sub-pipelines are alive at once. When an item arrives for a
+// new key while the limit is reached, the least recently used sub-pipeline is
+// torn down: its input is closed, so it flushes and terminates, and its
+// remaining outputs are still merged. A later item of an evicted key starts a
+// fresh sub-pipeline (its state is lost). maxKeys <= 0 means no limit.
+//
+// Context handling mirrors Router: when ctx is canceled, the stage stops
+// reading from in, closes every sub-pipeline input, drains their outputs, then
+// closes the returned channel. A panic in keyOf, factory or a sub-pipeline
+// Apply is recorded in the context PanicStore and aborts the stage.
+//
+// If keyOf is nil, every item goes to the same sub-pipeline. A nil processor
+// returned by factory passes items through.
+func NewPerKeyPipeline[S Carrier[S]](keyOf func(S) string, factory func(key string) Processor[S], maxKeys int) ProcessorFunc[S] {
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		ctx, ps := EnsurePanicStore(ctx)
+		ctx, cancel := context.WithCancel(ctx)
+
+		type keyRoute struct {
+			in  chan S
+			lru *list.Element
+		}
+
+		out := make(chan S)
+		routes := make(map[string]*keyRoute)
+		// Front is the most recently used key.
+		lru := list.New()
+		var wg sync.WaitGroup
+
+		teardown := func(key string) {
+			r := routes[key]
+			safeCloseChan(ps, r.in)
+			lru.Remove(r.lru)
+			delete(routes, key)
+		}
+
+		start := func(key string) *keyRoute {
+			if maxKeys > 0 && len(routes) >= maxKeys {
+				teardown(lru.Back().Value.(string))
+			}
+			var p Processor[S]
+			if factory != nil {
+				p = factory(key)
+			}
+			ch := make(chan S)
+			outCh, ok := safeApplyProcessor(ctx, ps, p, ch)
+			if !ok {
+				cancel()
+			}
+			wg.Add(1)
+			go mergeRouteOutput(ctx, cancel, ps, &wg, outCh, out)
+
+			r := &keyRoute{in: ch, lru: lru.PushFront(key)}
+			routes[key] = r
+			return r
+		}
+
+		go func() {
+			defer func() {
+				for key := range routes {
+					teardown(key)
+				}
+				wg.Wait()
+				close(out)
+				cancel()
+			}()
+
+			defer func() {
+				if rcv := recover(); rcv != nil {
+					ps.Store(rcv, debug.Stack())
+					cancel()
+				}
+			}()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-in:
+					if !ok {
+						return
+					}
+					key := ""
+					if keyOf != nil {
+						key = keyOf(item)
+					}
+					r, started := routes[key]
+					if started {
+						lru.MoveToFront(r.lru)
+					} else {
+						r = start(key)
+					}
+					select {
+					case <-ctx.Done():
+						return
+					case r.in <- item:
+					}
+				}
+			}
+		}()
+		return out
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewPerKeyPipeline_IsolationAndTeardown(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		created  []string
+		tornDown []string
+	)
+	// Each sub-pipeline numbers the items of its key: its state is private.
+	factory := func(key string) Processor[StringCarrier] {
+		mu.Lock()
+		created = append(created, key)
+		mu.Unlock()
+		return ProcessorFunc[StringCarrier](func(ctx context.Context, in <-chan StringCarrier) <-chan StringCarrier {
+			count := 0
+			return asyncEmitter(ctx, in, func(_ context.Context, s StringCarrier, emit func(StringCarrier)) {
+				count++
+				s.Value = fmt.Sprintf("%s#%d", s.Value, count)
+				emit(s)
+			}, func(_ context.Context, _ func(StringCarrier)) {
+				mu.Lock()
+				tornDown = append(tornDown, key)
+				mu.Unlock()
+			})
+		})
+	}
+	keyOf := func(s StringCarrier) string { return s.Value }
+
+	// With two live keys at most, "c" evicts "b" (least recently used), then
+	// "b" evicts "a" and starts a fresh sub-pipeline.
+	input := []string{"a", "b", "a", "c", "b", "c"}
+	in := make(chan StringCarrier, len(input))
+	for i, w := range input {
+		in <- StringCarrier{Value: w, Index: i}
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewPerKeyPipeline(keyOf, factory, 2).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	sortByIndex(items)
+
+	want := []string{"a#1", "b#1", "a#2", "c#1", "b#1", "c#2"}
+	if len(items) != len(want) {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), len(want))
+	}
+	for i, w := range want {
+		if items[i].Value != w {
+			t.Fatalf("unexpected item %d: got %q want %q", i, items[i].Value, w)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got := strings.Join(created, ","); got != "a,b,c,b" {
+		t.Fatalf("unexpected sub-pipeline creations: %s", got)
+	}
+	if len(tornDown) != len(created) {
+		t.Fatalf("every sub-pipeline must be torn down: created %v, torn down %v", created, tornDown)
+	}
+}
@@ -1,7 +1,66 @@
 package textual
 
-import "context"
+import (
+	"context"
+	"regexp"
+	"strings"
+)
 
 // Predicate represents a function that evaluates whether a given item satisfies certain conditions.
 // It takes a context and an input of type S (a Carrier) and returns a boolean indicating acceptance.
 type Predicate[S Carrier[S]] func(ctx context.Context, item S) bool
+
+// And returns a Predicate matching the items matched by all of ps.
+//
+// Predicates are evaluated in order and the evaluation stops at the first
+// mismatch. As in Router and If, a nil predicate always matches, so And() with
+// no predicate always matches too.
+func And[S Carrier[S]](ps ...Predicate[S]) Predicate[S] {
+	return func(ctx context.Context, item S) bool {
+		for _, p := range ps {
+			if p != nil && !p(ctx, item) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a Predicate matching the items matched by at least one of ps.
+//
+// Predicates are evaluated in order and the evaluation stops at the first
+// match. A nil predicate always matches; Or() with no predicate never matches.
+func Or[S Carrier[S]](ps ...Predicate[S]) Predicate[S] {
+	return func(ctx context.Context, item S) bool {
+		for _, p := range ps {
+			if p == nil || p(ctx, item) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a Predicate matching the items p does not match.
+// A nil p always matches, so Not(nil) never matches.
+func Not[S Carrier[S]](p Predicate[S]) Predicate[S] {
+	return func(ctx context.Context, item S) bool {
+		return p != nil && !p(ctx, item)
+	}
+}
+
+// HasPrefix returns a Predicate matching the items whose UTF8String starts
+// with prefix.
+func HasPrefix[S Carrier[S]](prefix string) Predicate[S] {
+	return func(_ context.Context, item S) bool {
+		return strings.HasPrefix(string(item.UTF8String()), prefix)
+	}
+}
+
+// Matches returns a Predicate matching the items whose UTF8String contains a
+// match of re. A nil re matches nothing.
+func Matches[S Carrier[S]](re *regexp.Regexp) Predicate[S] {
+	return func(_ context.Context, item S) bool {
+		return re != nil && re.MatchString(string(item.UTF8String()))
+	}
+}
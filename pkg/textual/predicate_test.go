@@ -0,0 +1,82 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestPredicate_RouterNotHasErrorAndPrefix(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	router := NewRouter[StringCarrier](RoutingStrategyFirstMatch)
+	router.AddRoute(And(Not(HasError[StringCarrier]), HasPrefix[StringCarrier]("cmd:")), procSuffix("|cmd"))
+	router.AddProcessor(procSuffix("|other"))
+
+	errBoom := errors.New("boom")
+	in := make(chan StringCarrier, 3)
+	in <- StringCarrier{Value: "cmd:run", Index: 0}
+	in <- StringCarrier{Value: "cmd:fail", Index: 1, Error: errBoom}
+	in <- StringCarrier{Value: "text", Index: 2}
+	close(in)
+
+	out, err := collectWithContext(ctx, router.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sortByIndex(out)
+	want := []string{"cmd:run|cmd", "cmd:fail|other", "text|other"}
+	if len(out) != len(want) {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), len(want))
+	}
+	for i := range want {
+		if out[i].Value != want[i] {
+			t.Fatalf("unexpected item %d: got %q want %q", i, out[i].Value, want[i])
+		}
+	}
+}
+
+func TestPredicate_Combinators(t *testing.T) {
+	ctx := context.Background()
+	digits := Matches[StringCarrier](regexp.MustCompile(`\d+`))
+	hello := HasPrefix[StringCarrier]("hello")
+
+	cases := []struct {
+		name string
+		p    Predicate[StringCarrier]
+		in   string
+		want bool
+	}{
+		{"and both", And(hello, digits), "hello 42", true},
+		{"and one", And(hello, digits), "hello", false},
+		{"and empty", And[StringCarrier](), "x", true},
+		{"or one", Or(hello, digits), "42", true},
+		{"or none", Or(hello, digits), "bye", false},
+		{"or empty", Or[StringCarrier](), "x", false},
+		{"not", Not(digits), "bye", true},
+		{"not nil", Not[StringCarrier](nil), "x", false},
+		{"matches nil", Matches[StringCarrier](nil), "x", false},
+	}
+	for _, c := range cases {
+		if got := c.p(ctx, StringCarrier{Value: c.in}); got != c.want {
+			t.Fatalf("unexpected result for %s: got %v want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// NewPrefetch returns a Processor that runs source and keeps up to depth of its
+// results buffered ahead of the consumer.
+//
+// Stages are connected by unbuffered channels, so a slow source (network,
+// remote model, ...) only works on the next item while the consumer handles
+// the current one. With a prefetch depth, the source keeps producing while the
+// consumer has think-time, which smooths out latency spikes on either side.
+//
+// Items are forwarded unchanged and in order: NewPrefetch is source followed
+// by Buffer(depth), and shares its cancellation semantics. When ctx is
+// canceled, the stage stops reading the source and closes the output at once:
+// results already buffered can still be received, nothing else is forwarded.
+// The source observes the same ctx and is expected to stop on its own.
+//
+// If depth <= 0, source is returned as is. If source is nil, the input itself
+// is prefetched. A panic in source.Apply is recorded in the context PanicStore
+// and yields an empty stream.
+func NewPrefetch[S Carrier[S]](depth int, source Processor[S]) ProcessorFunc[S] {
+	if depth <= 0 {
+		if source == nil {
+			return passThroughProcessor[S]()
+		}
+		return source.Apply
+	}
+	buffer := Buffer[S](depth)
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		ctx, ps := EnsurePanicStore(ctx)
+		src, _ := safeApplyProcessor(ctx, ps, source, in)
+		return buffer.Apply(ctx, src)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// consumeWithThinkTime reads p's output, pausing after the first item as a
+// consumer with bursty think-time would, and returns the elapsed time.
+func consumeWithThinkTime(t *testing.T, p Processor[StringCarrier], n int, think time.Duration) time.Duration {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, n)
+	for i := 0; i < n; i++ {
+		in <- StringCarrier{Value: "x", Index: i}
+	}
+	close(in)
+
+	start := time.Now()
+	count := 0
+	for item := range p.Apply(ctx, in) {
+		if item.Index != count {
+			t.Fatalf("unexpected order: got index %d want %d", item.Index, count)
+		}
+		if count == 0 {
+			time.Sleep(think)
+		}
+		count++
+	}
+	if count != n {
+		t.Fatalf("unexpected output count: got %d want %d", count, n)
+	}
+	return time.Since(start)
+}
+
+func TestNewPrefetch_ImprovesThroughput(t *testing.T) {
+	const (
+		n     = 9
+		delay = 10 * time.Millisecond
+		think = 80 * time.Millisecond
+	)
+	slow := NewProcessorFunc(func(_ context.Context, s StringCarrier) StringCarrier {
+		time.Sleep(delay)
+		return s
+	})
+
+	// Without prefetch: the source idles during the think-time, so the 8
+	// remaining items are produced afterwards (~10 + 80 + 80ms).
+	plain := consumeWithThinkTime(t, slow, n, think)
+	// With prefetch: the remaining items are produced during the think-time
+	// (~10 + 80 + 10ms).
+	prefetched := consumeWithThinkTime(t, NewPrefetch[StringCarrier](n, slow), n, think)
+
+	if prefetched+30*time.Millisecond > plain {
+		t.Fatalf("prefetch should improve throughput: got %v with prefetch, %v without", prefetched, plain)
+	}
+}
+
+func TestNewPrefetch_CanceledClosesWithIdleSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan StringCarrier) // never closed, never fed
+	out := NewPrefetch[StringCarrier](2, nil).Apply(ctx, in)
+	cancel()
+
+	select {
+	case item, ok := <-out:
+		if ok {
+			t.Fatalf("unexpected item after cancellation: %#v", item)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("output not closed after cancellation")
+	}
+}
@@ -16,7 +16,9 @@ package textual
 
 import (
 	"context"
+	"fmt"
 	"runtime/debug"
+	"strings"
 )
 
 type Processors[S Carrier[S]] []Processor[S]
@@ -27,12 +29,81 @@ type Processors[S Carrier[S]] []Processor[S]
 //
 //	out := p3.Apply(ctx, p2.Apply(ctx, p1.Apply(ctx, in)))
 //
-// Nil processors are ignored.
+// Nil processors are ignored. Use NewChainOf to get a Chain that can be
+// inspected.
 func NewChain[S Carrier[S]](processors ...Processor[S]) ProcessorFunc[S] {
 	ps := Processors[S](processors)
 	return ps.ProcessorFunc()
 }
 
+// NewChainOf composes processors like NewChain, and returns a Chain that can
+// be inspected with Len, Stages and Describe. Nil processors are dropped.
+func NewChainOf[S Carrier[S]](processors ...Processor[S]) *Chain[S] {
+	stages := make(Processors[S], 0, len(processors))
+	for _, p := range processors {
+		if p != nil {
+			stages = append(stages, p)
+		}
+	}
+	return &Chain[S]{stages: stages}
+}
+
+// Named is an optional interface for processors that have a display name.
+// Chain.Describe uses it to render the shape of a pipeline.
+type Named interface {
+	Name() string
+}
+
+// Chain is the composite Processor built by NewChainOf. It is immutable.
+type Chain[S Carrier[S]] struct {
+	stages Processors[S]
+}
+
+// Apply runs the stages in sequence (see NewChain).
+func (c *Chain[S]) Apply(ctx context.Context, in <-chan S) <-chan S {
+	if c == nil {
+		return Processors[S](nil).Apply(ctx, in)
+	}
+	return c.stages.Apply(ctx, in)
+}
+
+// Len returns the number of stages, nil processors excluded.
+func (c *Chain[S]) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.stages)
+}
+
+// Stages returns a copy of the stages, in order.
+func (c *Chain[S]) Stages() []Processor[S] {
+	if c == nil {
+		return nil
+	}
+	return append([]Processor[S](nil), c.stages...)
+}
+
+// Describe renders the stages in order, separated by " -> ": the name of the
+// stages implementing Named, the Go type of the others, and the description of
+// nested chains between brackets. An empty chain renders as "(empty chain)".
+func (c *Chain[S]) Describe() string {
+	if c.Len() == 0 {
+		return "(empty chain)"
+	}
+	names := make([]string, len(c.stages))
+	for i, stage := range c.stages {
+		switch st := stage.(type) {
+		case Named:
+			names[i] = st.Name()
+		case *Chain[S]:
+			names[i] = "[" + st.Describe() + "]"
+		default:
+			names[i] = fmt.Sprintf("%T", stage)
+		}
+	}
+	return strings.Join(names, " -> ")
+}
+
 func (p Processors[C]) ProcessorFunc() ProcessorFunc[C] {
 	return func(ctx context.Context, in <-chan C) <-chan C {
 		return p.Apply(ctx, in)
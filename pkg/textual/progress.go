@@ -0,0 +1,56 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// Progress reports how much of a stream went through a NewProgress stage.
+type Progress struct {
+	Count int64 // Items forwarded so far.
+	Bytes int64 // Total len(UTF8String()) of the items forwarded so far.
+}
+
+// NewProgress returns a pass-through Processor that sends a Progress event to
+// sink every `every` items, so long jobs can drive a progress bar.
+//
+// Sending is non-blocking: when sink is full (or nobody reads it), the event is
+// dropped and the stream keeps flowing. Because each event carries cumulative
+// counters, a dropped event only delays the progress display. Give sink a small
+// buffer to avoid missing events when the reader is momentarily busy.
+//
+// sink is never closed by the stage. Items are forwarded unchanged. When
+// every <= 0 or sink is nil, items are passed through without progress events.
+func NewProgress[S Carrier[S]](every int, sink chan<- Progress) ProcessorFunc[S] {
+	if every <= 0 || sink == nil {
+		return passThroughProcessor[S]()
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		var progress Progress
+		return Async(ctx, in, func(ctx context.Context, item S) S {
+			progress.Count++
+			progress.Bytes += int64(len(item.UTF8String()))
+			if progress.Count%int64(every) == 0 {
+				select {
+				case sink <- progress:
+				default:
+					// Sink full: drop the event rather than stall the stream.
+				}
+			}
+			return item
+		})
+	}
+}
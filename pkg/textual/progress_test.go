@@ -0,0 +1,81 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func progressInput(values ...string) <-chan StringCarrier {
+	in := make(chan StringCarrier)
+	go func() {
+		defer close(in)
+		for i, v := range values {
+			in <- StringCarrier{Value: v, Index: i}
+		}
+	}()
+	return in
+}
+
+func TestNewProgress_EmitsAtIntervals(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	sink := make(chan Progress, 8)
+	out := NewProgress[StringCarrier](2, sink).Apply(ctx, progressInput("a", "bb", "ccc", "dddd", "e"))
+	items, err := collectWithContext(ctx, out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 5 {
+		t.Fatalf("unexpected item count: got %d want %d", len(items), 5)
+	}
+	close(sink)
+
+	var got []Progress
+	for p := range sink {
+		got = append(got, p)
+	}
+	want := []Progress{{Count: 2, Bytes: 3}, {Count: 4, Bytes: 10}}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected events: got %v want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected event %d: got %v want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNewProgress_FullSinkDoesNotBlock(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// Unbuffered and never read: every event must be dropped.
+	sink := make(chan Progress)
+	values := make([]string, 100)
+	for i := range values {
+		values[i] = "x"
+	}
+	items, err := collectWithContext(ctx, NewProgress[StringCarrier](1, sink).Apply(ctx, progressInput(values...)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != len(values) {
+		t.Fatalf("unexpected item count: got %d want %d", len(items), len(values))
+	}
+}
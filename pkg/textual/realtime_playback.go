@@ -0,0 +1,73 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"time"
+)
+
+// NewRealtimePlayback returns a Processor replaying a recorded stream with its
+// original timing, for tests and demos.
+//
+// The first item is emitted immediately. Every following item is emitted once
+// (tsOf(item) - tsOf(first)) / speed has elapsed on clock since the first
+// emission: speed 2 plays twice as fast, 0.5 twice as slow. Items whose
+// timestamp is not after the schedule (out of order, or late because the
+// consumer is slow) are emitted right away, so delays never accumulate.
+//
+// Items are not modified. Waiting stops when ctx is canceled.
+//
+// clock provides the current time (time.Now when nil). If tsOf is nil or
+// speed <= 0, items are passed through without delay.
+func NewRealtimePlayback[S Carrier[S]](tsOf func(S) time.Time, speed float64, clock func() time.Time) ProcessorFunc[S] {
+	return newRealtimePlayback(tsOf, speed, clock, time.After)
+}
+
+// newRealtimePlayback is NewRealtimePlayback with an injectable timer, so that
+// tests can drive a fake clock.
+func newRealtimePlayback[S Carrier[S]](tsOf func(S) time.Time, speed float64, clock func() time.Time, after func(time.Duration) <-chan time.Time) ProcessorFunc[S] {
+	if tsOf == nil || speed <= 0 {
+		return passThroughProcessor[S]()
+	}
+	if clock == nil {
+		clock = time.Now
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		var (
+			started bool
+			origin  time.Time // clock time of the first emission
+			first   time.Time // timestamp of the first item
+		)
+		return AsyncEmitter(ctx, in, func(ctx context.Context, item S, emit func(S)) {
+			ts := tsOf(item)
+			if !started {
+				started = true
+				origin, first = clock(), ts
+				emit(item)
+				return
+			}
+			target := origin.Add(time.Duration(float64(ts.Sub(first)) / speed))
+			if wait := target.Sub(clock()); wait > 0 {
+				select {
+				case <-after(wait):
+				case <-ctx.Done():
+					return
+				}
+			}
+			emit(item)
+		})
+	}
+}
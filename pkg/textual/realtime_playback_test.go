@@ -0,0 +1,116 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manual clock: waiting on it advances it instantly. Since the
+// stage may start waiting for the next item before the consumer looks at the
+// clock, the clock time reached while waiting for each item is recorded.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	current int               // index of the item being scheduled
+	reached map[int]time.Time // clock time after waiting for an item
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.reached[c.current] = c.now
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *fakeClock) schedule(index int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current = index
+}
+
+// emittedAt returns the clock time at which item index was emitted: the time
+// reached by the last wait scheduled for an item up to index.
+func (c *fakeClock) emittedAt(start time.Time, index int) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	at := start
+	for i := 0; i <= index; i++ {
+		if r, ok := c.reached[i]; ok {
+			at = r
+		}
+	}
+	return at
+}
+
+func TestNewRealtimePlayback_Spacing(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	// Item offsets in the recording; the third item is out of order.
+	offsets := []time.Duration{0, 2 * time.Second, 1 * time.Second, 6 * time.Second}
+
+	cases := []struct {
+		speed float64
+		want  []time.Duration // emission times relative to the first one
+	}{
+		{1, []time.Duration{0, 2 * time.Second, 2 * time.Second, 6 * time.Second}},
+		{2, []time.Duration{0, 1 * time.Second, 1 * time.Second, 3 * time.Second}},
+	}
+	for _, tc := range cases {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+
+		clock := &fakeClock{now: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), reached: map[int]time.Time{}}
+		start := clock.Now()
+		in := make(chan StringCarrier, len(offsets))
+		for i := range offsets {
+			in <- StringCarrier{Value: "x", Index: i}
+		}
+		close(in)
+
+		tsOf := func(s StringCarrier) time.Time {
+			clock.schedule(s.Index)
+			return base.Add(offsets[s.Index])
+		}
+		p := newRealtimePlayback[StringCarrier](tsOf, tc.speed, clock.Now, clock.After)
+
+		items, err := collectWithContext(ctx, p.Apply(ctx, in))
+		cancel()
+		if err != nil {
+			t.Fatalf("collect failed: %v", err)
+		}
+		if len(items) != len(offsets) {
+			t.Fatalf("unexpected output count: got %d want %d", len(items), len(offsets))
+		}
+		for i, item := range items {
+			if item.Index != i {
+				t.Fatalf("unexpected order: got %d want %d", item.Index, i)
+			}
+			if got := clock.emittedAt(start, i).Sub(start); got != tc.want[i] {
+				t.Fatalf("speed %v: item %d emitted at %v want %v", tc.speed, i, got, tc.want[i])
+			}
+		}
+	}
+}
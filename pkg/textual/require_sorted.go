@@ -0,0 +1,91 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrUnsorted is attached by NewRequireSorted to the first out-of-order item.
+var ErrUnsorted = errors.New("textual: input is not sorted")
+
+// NewRequireSorted returns a Processor that makes a sorted-input precondition
+// explicit, for stages such as merge joins that silently misbehave otherwise.
+//
+// Items are forwarded unchanged as long as each item is not less than the
+// previous one (less(item, previous) == false, so equal items are accepted).
+// The first item breaking the order is terminal:
+//
+//   - it is forwarded with an ErrUnsorted error attached,
+//   - the output is closed right after it, whatever the source,
+//   - the pipeline source is asked to stop reading (see StopSource), and the
+//     rest of the input is drained in the background, until it is closed or
+//     ctx is canceled, so upstream stages never block.
+//
+// A panic in less is recorded in the context PanicStore and ends the stream.
+// If less is nil, items are passed through unchanged.
+func NewRequireSorted[S Carrier[S]](less func(a, b S) bool) ProcessorFunc[S] {
+	if less == nil {
+		return passThroughProcessor[S]()
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		ctx, ps := EnsurePanicStore(ctx)
+		out := make(chan S)
+		go func() {
+			defer close(out)
+			defer func() {
+				if r := recover(); r != nil {
+					ps.Store(r, debug.Stack())
+					drainInBackground(ctx, ps, in)
+				}
+			}()
+			var (
+				prev    S
+				started bool
+			)
+			for {
+				var item S
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-in:
+					if !ok {
+						return
+					}
+					item = v
+				}
+				violated := started && less(item, prev)
+				if violated {
+					item = item.WithError(fmt.Errorf("%w: item %d is out of order after item %d", ErrUnsorted, item.GetIndex(), prev.GetIndex()))
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case out <- item:
+				}
+				if violated {
+					StopSource(ctx)
+					drainInBackground(ctx, ps, in)
+					return
+				}
+				prev, started = item, true
+			}
+		}()
+		return out
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func byValue(a, b StringCarrier) bool { return a.Value < b.Value }
+
+func TestNewRequireSorted_SortedInput(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 4)
+	for i, w := range []string{"a", "b", "b", "c"} {
+		in <- StringCarrier{Value: w, Index: i}
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewRequireSorted(byValue).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 4)
+	}
+	for _, it := range items {
+		if it.GetError() != nil {
+			t.Fatalf("unexpected error on %q: %v", it.Value, it.GetError())
+		}
+	}
+}
+
+func TestNewRequireSorted_UnsortedInput(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 5)
+	for i, w := range []string{"a", "c", "b", "d", "e"} {
+		in <- StringCarrier{Value: w, Index: i}
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewRequireSorted(byValue).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("items after the violation must be discarded: got %d want %d", len(items), 3)
+	}
+	if items[1].GetError() != nil {
+		t.Fatalf("unexpected error before the violation: %v", items[1].GetError())
+	}
+	if items[2].Value != "b" || !errors.Is(items[2].GetError(), ErrUnsorted) {
+		t.Fatalf("expected ErrUnsorted on %q, got %#v", "b", items[2])
+	}
+}
+
+func TestNewRequireSorted_ClosesAfterViolationWithOpenInput(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 3) // never closed
+	for i, w := range []string{"b", "a", "c"} {
+		in <- StringCarrier{Value: w, Index: i}
+	}
+
+	items, err := collectWithContext(ctx, NewRequireSorted(byValue).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 2 || !errors.Is(items[1].GetError(), ErrUnsorted) {
+		t.Fatalf("expected the stream to end on the violation, got %#v", items)
+	}
+}
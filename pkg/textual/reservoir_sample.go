@@ -0,0 +1,73 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// NewReservoirSample returns a Processor that keeps a uniform random sample of
+// k items of the whole stream (Algorithm R), using O(k) memory whatever the
+// stream length.
+//
+// Nothing is emitted while the input is open: the sample is emitted when the
+// input is closed, stably sorted by index. It is dropped when ctx is canceled.
+// Streams shorter than k are emitted entirely. Items are not modified.
+//
+// src drives the random choices. Passing a seeded source (rand.NewSource(42))
+// makes the sample deterministic, which is useful in tests. When src is nil, a
+// time-seeded source is used. The source is shared by every Apply call and
+// guarded by a mutex.
+//
+// When k <= 0, no item is emitted.
+func NewReservoirSample[S Carrier[S]](k int, src rand.Source) ProcessorFunc[S] {
+	if src == nil {
+		src = rand.NewSource(time.Now().UnixNano())
+	}
+	rnd := rand.New(src)
+	var mu sync.Mutex
+	intn := func(n int) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return rnd.Intn(n)
+	}
+
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		reservoir := make([]S, 0, max(k, 0))
+		seen := 0
+
+		return asyncEmitter(ctx, in, func(ctx context.Context, item S, emit func(S)) {
+			seen++
+			if k <= 0 {
+				return
+			}
+			if len(reservoir) < k {
+				reservoir = append(reservoir, item)
+				return
+			}
+			// Keep the item with probability k/seen.
+			if j := intn(seen); j < k {
+				reservoir[j] = item
+			}
+		}, func(ctx context.Context, emit func(S)) {
+			for _, item := range sortedByIndex(reservoir) {
+				emit(item)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func sampleOnce(t *testing.T, p Processor[StringCarrier], n int) []StringCarrier {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, n)
+	for i := 0; i < n; i++ {
+		in <- StringCarrier{Value: "x", Index: i}
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, p.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	return items
+}
+
+func TestNewReservoirSample_Uniform(t *testing.T) {
+	const (
+		n    = 10
+		k    = 3
+		runs = 3000
+	)
+	p := NewReservoirSample[StringCarrier](k, rand.NewSource(42))
+
+	counts := make([]int, n)
+	for r := 0; r < runs; r++ {
+		items := sampleOnce(t, p, n)
+		if len(items) != k {
+			t.Fatalf("unexpected sample size: got %d want %d", len(items), k)
+		}
+		for i, it := range items {
+			if i > 0 && items[i-1].Index >= it.Index {
+				t.Fatalf("sample must be sorted by index without duplicates: %#v", items)
+			}
+			counts[it.Index]++
+		}
+	}
+
+	// Each item is expected runs*k/n = 900 times.
+	for i, c := range counts {
+		if c < 800 || c > 1000 {
+			t.Fatalf("selection is not uniform: item %d selected %d times (counts %v)", i, c, counts)
+		}
+	}
+}
+
+func TestNewReservoirSample_ShortStream(t *testing.T) {
+	items := sampleOnce(t, NewReservoirSample[StringCarrier](5, rand.NewSource(1)), 3)
+	if len(items) != 3 {
+		t.Fatalf("unexpected sample size: got %d want %d", len(items), 3)
+	}
+	if items := sampleOnce(t, NewReservoirSample[StringCarrier](0, nil), 3); len(items) != 0 {
+		t.Fatalf("unexpected sample for k=0: %#v", items)
+	}
+}
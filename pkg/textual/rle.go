@@ -0,0 +1,84 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// RLECountTag is the Tagged key holding the repeat count set by NewRLE.
+const RLECountTag = "repeat"
+
+// NewRLE returns a Transcoder that applies run-length encoding to the stream:
+// consecutive identical items (compared by UTF8String) are collapsed into the
+// first item of the run, tagged with the run length under RLECountTag.
+//
+//   - Items carrying an error (GetError() != nil) are never merged: they are
+//     emitted as runs of 1, so errors are not lost.
+//   - The pending run is emitted when the input is closed. It is dropped when
+//     ctx is canceled.
+//
+// The emitted item keeps the index of the first item of its run.
+func NewRLE[S Carrier[S]]() TranscoderFunc[S, Tagged[S]] {
+	return func(ctx context.Context, in <-chan S) <-chan Tagged[S] {
+		var (
+			run     S
+			count   int
+			pending bool
+		)
+
+		emitRun := func(emit func(Tagged[S])) {
+			if !pending {
+				return
+			}
+			emit(TagItem(run).WithTag(RLECountTag, count))
+			pending = false
+			count = 0
+		}
+
+		return asyncEmitter(ctx, in, func(ctx context.Context, item S, emit func(Tagged[S])) {
+			if pending && item.GetError() == nil && run.GetError() == nil &&
+				item.UTF8String() == run.UTF8String() {
+				count++
+				return
+			}
+			emitRun(emit)
+			run, count, pending = item, 1, true
+		}, func(ctx context.Context, emit func(Tagged[S])) {
+			emitRun(emit)
+		})
+	}
+}
+
+// NewRLEDecode returns a Transcoder expanding the runs produced by NewRLE: each
+// tagged item is emitted RLECountTag times.
+//
+// Copies are re-indexed from the index of the tagged item (index, index+1, ...),
+// which restores the original indexes when the encoded stream was contiguously
+// indexed. Missing or non-positive counts are treated as 1.
+func NewRLEDecode[S Carrier[S]]() TranscoderFunc[Tagged[S], S] {
+	return func(ctx context.Context, in <-chan Tagged[S]) <-chan S {
+		return AsyncEmitter(ctx, in, func(ctx context.Context, t Tagged[S], emit func(S)) {
+			count, ok := t.TagInt(RLECountTag)
+			if !ok || count < 1 {
+				count = 1
+			}
+			base := t.GetIndex()
+			for i := 0; i < count; i++ {
+				emit(t.Item.WithIndex(base + i))
+			}
+		})
+	}
+}
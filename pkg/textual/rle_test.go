@@ -0,0 +1,81 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewRLE_CollapsesRuns(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	words := []string{"a", "a", "a", "b", "c", "c", "a"}
+	in := make(chan StringCarrier, len(words))
+	for i, w := range words {
+		in <- StringCarrier{Value: w, Index: i}
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewRLE[StringCarrier]().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+
+	type run struct {
+		value string
+		index int
+		count int
+	}
+	want := []run{{"a", 0, 3}, {"b", 3, 1}, {"c", 4, 2}, {"a", 6, 1}}
+	if len(items) != len(want) {
+		t.Fatalf("unexpected output count: got %d want %d items=%#v", len(items), len(want), items)
+	}
+	for i, it := range items {
+		count, _ := it.TagInt(RLECountTag)
+		got := run{it.Item.Value, it.GetIndex(), count}
+		if got != want[i] {
+			t.Fatalf("unexpected run %d: got %+v want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestNewRLE_RoundTrip(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	words := []string{"x", "x", "y", "y", "y", "y", "z", "x", "x"}
+	in := make(chan StringCarrier, len(words))
+	for i, w := range words {
+		in <- StringCarrier{Value: w, Index: i}
+	}
+	close(in)
+
+	encoded := NewRLE[StringCarrier]().Apply(ctx, in)
+	items, err := collectWithContext(ctx, NewRLEDecode[StringCarrier]().Apply(ctx, encoded))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != len(words) {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), len(words))
+	}
+	for i, it := range items {
+		if it.Value != words[i] || it.Index != i {
+			t.Fatalf("unexpected item %d: got %#v want %q@%d", i, it, words[i], i)
+		}
+	}
+}
@@ -45,9 +45,16 @@ const (
 	RoutingStrategyRandom
 )
 
+// RouteID identifies a route registered on a Router.
+//
+// It is returned by AddRoute / AddProcessor and can be passed to RemoveRoute.
+// The zero value never identifies a route.
+type RouteID uint64
+
 // route is an internal configuration element combining a Processor and its
 // selection predicate.
 type route[S Carrier[S]] struct {
+	id        RouteID
 	processor Processor[S]
 	predicate Predicate[S] // nil means "always eligible"
 }
@@ -70,15 +77,28 @@ type route[S Carrier[S]] struct {
 //     2) the strategy decides which subset of eligible routes receives the item,
 //     3) if no route is selected, the item is forwarded unchanged.
 //
-// Note: AddRoute/AddProcessor/SetStrategy are not concurrency-safe; configure
-// the router during pipeline construction, before calling Apply.
+// Dynamic configuration:
+//
+// AddRoute, AddProcessor, RemoveRoute and SetStrategy are concurrency-safe and
+// may be called while Apply is running (e.g. to scale workers up or down):
+//
+//   - the fan-out goroutine snapshots the route list for every item, so a new
+//     route becomes eligible for the next item;
+//   - a route's processor is started lazily, the first time an item is
+//     dispatched to it;
+//   - a removed route stops receiving items; its input channel is closed and
+//     its remaining outputs are still merged into the output channel.
 type Router[S Carrier[S]] struct {
+	mu       sync.Mutex // protects every field below
 	routes   []route[S]
 	strategy RoutingStrategy
+	nextID   RouteID
+	counter  uint64
+	rnd      *rand.Rand
 
-	mu      sync.Mutex // protects rnd and counter
-	counter uint64
-	rnd     *rand.Rand
+	// sessions holds one wake-up channel per running Apply. Configuration
+	// changes notify every session so removed routes are released promptly.
+	sessions map[chan struct{}]struct{}
 }
 
 // NewRouter constructs a new Router with the given strategy.
@@ -93,37 +113,107 @@ func NewRouter[S Carrier[S]](strategy RoutingStrategy, processors ...Processor[S
 		rnd:      rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 	for _, p := range processors {
-		if p == nil {
-			continue
-		}
-		r.routes = append(r.routes, route[S]{processor: p})
+		r.AddProcessor(p)
 	}
 	return r
 }
 
-// AddRoute registers a new route with an optional predicate.
+// AddRoute registers a new route with an optional predicate and returns its
+// identifier.
 //
 //   - ConditionalProc predicate is nil, the route is always considered eligible.
-//   - ConditionalProc processor is nil, the route is ignored.
-func (r *Router[S]) AddRoute(predicate Predicate[S], processor Processor[S]) {
+//   - ConditionalProc processor is nil, the route is ignored and the zero RouteID is returned.
+func (r *Router[S]) AddRoute(predicate Predicate[S], processor Processor[S]) RouteID {
 	if processor == nil {
-		return
+		return 0
 	}
+	r.mu.Lock()
+	r.nextID++
+	id := r.nextID
 	r.routes = append(r.routes, route[S]{
+		id:        id,
 		processor: processor,
 		predicate: predicate,
 	})
+	r.notifyLocked()
+	r.mu.Unlock()
+	return id
 }
 
 // AddProcessor is a convenience wrapper around AddRoute for routes that are
 // always eligible (predicate == nil).
-func (r *Router[S]) AddProcessor(processor Processor[S]) {
-	r.AddRoute(nil, processor)
+func (r *Router[S]) AddProcessor(processor Processor[S]) RouteID {
+	return r.AddRoute(nil, processor)
+}
+
+// RemoveRoute unregisters the route identified by id.
+//
+// Running Apply calls stop dispatching to that route, close its input channel
+// and keep merging whatever it still emits until its output is closed.
+//
+// RemoveRoute reports whether a route was removed.
+func (r *Router[S]) RemoveRoute(id RouteID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, rt := range r.routes {
+		if rt.id != id {
+			continue
+		}
+		// Copy on write: snapshots taken by running sessions stay valid.
+		routes := make([]route[S], 0, len(r.routes)-1)
+		routes = append(routes, r.routes[:i]...)
+		r.routes = append(routes, r.routes[i+1:]...)
+		r.notifyLocked()
+		return true
+	}
+	return false
 }
 
 // SetStrategy changes the routing strategy.
 func (r *Router[S]) SetStrategy(strategy RoutingStrategy) {
+	r.mu.Lock()
 	r.strategy = strategy
+	r.mu.Unlock()
+}
+
+// snapshot returns the current route list and strategy.
+//
+// The returned slice must not be mutated: AddRoute may append to the backing
+// array beyond its length, and RemoveRoute always allocates a new one.
+func (r *Router[S]) snapshot() ([]route[S], RoutingStrategy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.routes[:len(r.routes):len(r.routes)], r.strategy
+}
+
+// subscribe registers a wake-up channel for a running Apply.
+func (r *Router[S]) subscribe() chan struct{} {
+	wake := make(chan struct{}, 1)
+	r.mu.Lock()
+	if r.sessions == nil {
+		r.sessions = make(map[chan struct{}]struct{})
+	}
+	r.sessions[wake] = struct{}{}
+	r.mu.Unlock()
+	return wake
+}
+
+// unsubscribe removes a wake-up channel registered by subscribe.
+func (r *Router[S]) unsubscribe(wake chan struct{}) {
+	r.mu.Lock()
+	delete(r.sessions, wake)
+	r.mu.Unlock()
+}
+
+// notifyLocked wakes up every running Apply. r.mu must be held.
+func (r *Router[S]) notifyLocked() {
+	for wake := range r.sessions {
+		select {
+		case wake <- struct{}{}:
+		default:
+			// A notification is already pending.
+		}
+	}
 }
 
 // Apply implements the Processor interface.
@@ -151,87 +241,63 @@ func (r *Router[S]) Apply(ctx context.Context, in <-chan S) <-chan S {
 		return closedChan[S]()
 	}
 
-	// No routes: transparent pass-through Processor.
-	if len(r.routes) == 0 {
-		return passThroughProcessor[S]().Apply(ctx, in)
-	}
-
 	// Derive a cancellable child context so the router can stop its internal
 	// goroutines on fatal faults without canceling the parent.
 	ctx, cancel := context.WithCancel(ctx)
+	log := LoggerFromContext(ctx)
 
-	// Create one input channel per route and start each underlying Processor.
-	childIns := make([]chan S, len(r.routes))
-	childOuts := make([]<-chan S, len(r.routes))
+	out := make(chan S)
+	wake := r.subscribe()
 
-	for i, rt := range r.routes {
+	// Route inputs keyed by route id. Only the fan-out goroutine touches this
+	// map, so it needs no locking.
+	childIns := make(map[RouteID]chan S)
+
+	// Fan-in: one goroutine per started route merges its outputs into out.
+	// wg.Add is only called from the fan-out goroutine, before its final Wait.
+	var wg sync.WaitGroup
+
+	startRoute := func(rt route[S]) chan S {
 		ch := make(chan S)
-		childIns[i] = ch
+		childIns[rt.id] = ch
 
 		outCh, ok := safeApplyProcessor(ctx, ps, rt.processor, ch)
-		childOuts[i] = outCh
 
 		// A child stage that panicked (or returned a nil channel) is a fatal
 		// programming fault. Cancel the router context to abort promptly.
 		if !ok {
 			cancel()
 		}
-	}
-
-	out := make(chan S)
-
-	// Fan-in: merge all child outputs into the single out channel.
-	var wg sync.WaitGroup
-	wg.Add(len(childOuts))
 
-	for i := range childOuts {
-		go func(ch <-chan S) {
-			defer wg.Done()
+		wg.Add(1)
+		go mergeRouteOutput(ctx, cancel, ps, &wg, outCh, out)
+		return ch
+	}
 
-			defer func() {
-				if rcv := recover(); rcv != nil {
-					if ps != nil {
-						ps.Store(rcv, debug.Stack())
-					}
-					// Abort router on infrastructure panic.
-					cancel()
-					// Best-effort drain to avoid blocking child sends.
-					for range ch {
-					}
-				}
-			}()
-
-			for {
-				select {
-				case <-ctx.Done():
-					// Context canceled: drain remaining values from the child
-					// channel so that downstream processors are not blocked on
-					// send, but do not forward them anymore.
-					for range ch {
-					}
-					return
-				case item, ok := <-ch:
-					if !ok {
-						// Child processor closed its output.
-						return
-					}
-					// Normal operation: forward to the merged output.
-					select {
-					case out <- item:
-					case <-ctx.Done():
-						// Context canceled while sending: start draining.
-						for range ch {
-						}
-						return
-					}
-				}
+	// releaseRemovedRoutes closes the input of started routes that are no
+	// longer configured. Their outputs keep being merged until closed.
+	releaseRemovedRoutes := func() {
+		routes, _ := r.snapshot()
+		live := make(map[RouteID]struct{}, len(routes))
+		for _, rt := range routes {
+			live[rt.id] = struct{}{}
+		}
+		for id, ch := range childIns {
+			if _, ok := live[id]; !ok {
+				safeCloseChan(ps, ch)
+				delete(childIns, id)
 			}
-		}(childOuts[i])
+		}
 	}
 
 	// Fan-out: dispatch incoming items to the selected routes.
 	go func() {
+		// count is the number of items received from upstream (reported in
+		// debug logs).
+		count := 0
+
 		defer func() {
+			r.unsubscribe(wake)
 			// Signal downstream processors that no more input will arrive.
 			for _, ch := range childIns {
 				safeCloseChan(ps, ch)
@@ -246,6 +312,7 @@ func (r *Router[S]) Apply(ctx context.Context, in <-chan S) <-chan S {
 
 		defer func() {
 			if rcv := recover(); rcv != nil {
+				log.Warnf("textual: Router recovered a panic after %d item(s): %v", count, rcv)
 				if ps != nil {
 					ps.Store(rcv, debug.Stack())
 				}
@@ -258,16 +325,22 @@ func (r *Router[S]) Apply(ctx context.Context, in <-chan S) <-chan S {
 			select {
 			case <-ctx.Done():
 				// Stop reading from upstream when the context is canceled.
+				log.Debugf("textual: Router canceled after %d item(s) over %d route(s)", count, len(childIns))
 				return
+			case <-wake:
+				releaseRemovedRoutes()
 			case item, ok := <-in:
 				if !ok {
 					// Upstream closed; we're done.
+					log.Debugf("textual: Router input closed after %d item(s) over %d route(s)", count, len(childIns))
 					return
 				}
+				count++
 
 				// Resolve which routes should receive this item.
-				indices := r.selectRoutes(ctx, item)
-				if len(indices) == 0 {
+				routes, strategy := r.snapshot()
+				selected := r.selectRoutes(ctx, item, routes, strategy)
+				if len(selected) == 0 {
 					// No matching route: behave as pass-through.
 					select {
 					case <-ctx.Done():
@@ -277,17 +350,17 @@ func (r *Router[S]) Apply(ctx context.Context, in <-chan S) <-chan S {
 					continue
 				}
 
-				// Dispatch to every selected route.
-				for _, idx := range indices {
-					if idx < 0 || idx >= len(childIns) {
-						// Defensive bounds check; should never happen.
-						continue
+				// Dispatch to every selected route, wiring it on first use.
+				for _, rt := range selected {
+					ch, started := childIns[rt.id]
+					if !started {
+						ch = startRoute(rt)
 					}
 
 					select {
 					case <-ctx.Done():
 						return
-					case childIns[idx] <- item:
+					case ch <- item:
 					}
 				}
 			}
@@ -297,54 +370,99 @@ func (r *Router[S]) Apply(ctx context.Context, in <-chan S) <-chan S {
 	return out
 }
 
-// eligibleRoutes returns the indices of routes whose predicate matches the
-// given item (or all routes with nil predicates).
-func (r *Router[S]) eligibleRoutes(ctx context.Context, item S) []int {
-	indices := make([]int, 0, len(r.routes))
-	for i, rt := range r.routes {
+// mergeRouteOutput forwards every value of ch to out until ch is closed.
+//
+// When ctx is canceled, remaining values are drained (but not forwarded) so
+// that the route's processor is never blocked on send.
+func mergeRouteOutput[S Carrier[S]](ctx context.Context, cancel context.CancelFunc, ps *PanicStore, wg *sync.WaitGroup, ch <-chan S, out chan<- S) {
+	defer wg.Done()
+
+	defer func() {
+		if rcv := recover(); rcv != nil {
+			if ps != nil {
+				ps.Store(rcv, debug.Stack())
+			}
+			// Abort router on infrastructure panic.
+			cancel()
+			// Best-effort drain to avoid blocking child sends.
+			for range ch {
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Context canceled: drain remaining values from the child
+			// channel so that downstream processors are not blocked on
+			// send, but do not forward them anymore.
+			for range ch {
+			}
+			return
+		case item, ok := <-ch:
+			if !ok {
+				// Child processor closed its output.
+				return
+			}
+			// Normal operation: forward to the merged output.
+			select {
+			case out <- item:
+			case <-ctx.Done():
+				// Context canceled while sending: start draining.
+				for range ch {
+				}
+				return
+			}
+		}
+	}
+}
+
+// eligibleRoutes returns the routes whose predicate matches the given item (or
+// all routes with nil predicates).
+func (r *Router[S]) eligibleRoutes(ctx context.Context, item S, routes []route[S]) []route[S] {
+	eligible := make([]route[S], 0, len(routes))
+	for _, rt := range routes {
 		if rt.processor == nil {
 			continue
 		}
 		if rt.predicate == nil || rt.predicate(ctx, item) {
-			indices = append(indices, i)
+			eligible = append(eligible, rt)
 		}
 	}
-	return indices
+	return eligible
 }
 
 // selectRoutes picks one or more routes among the eligible ones according to
-// the configured routing strategy.
-func (r *Router[S]) selectRoutes(ctx context.Context, item S) []int {
-	eligible := r.eligibleRoutes(ctx, item)
+// the routing strategy.
+func (r *Router[S]) selectRoutes(ctx context.Context, item S, routes []route[S], strategy RoutingStrategy) []route[S] {
+	eligible := r.eligibleRoutes(ctx, item, routes)
 	if len(eligible) == 0 {
 		return nil
 	}
 
-	switch r.strategy {
+	switch strategy {
 	case RoutingStrategyBroadcast:
 		// Route to every matching route.
 		return eligible
 
 	case RoutingStrategyFirstMatch:
 		// Route only to the first matching route.
-		return []int{eligible[0]}
+		return eligible[:1]
 
 	case RoutingStrategyRandom:
 		// Route randomly to one among the matching routes.
 		r.mu.Lock()
 		idx := r.rnd.Intn(len(eligible))
-		chosen := eligible[idx]
 		r.mu.Unlock()
-		return []int{chosen}
+		return eligible[idx : idx+1]
 
 	case RoutingStrategyRoundRobin:
 		// Route to one among matching routes, balancing load equitably.
 		r.mu.Lock()
 		idx := int(r.counter % uint64(len(eligible)))
-		chosen := eligible[idx]
 		r.counter++
 		r.mu.Unlock()
-		return []int{chosen}
+		return eligible[idx : idx+1]
 
 	default:
 		// Fallback: behave like broadcast.
@@ -0,0 +1,170 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recvOne reads a single value from ch or fails the test on timeout.
+func recvOne[T any](t *testing.T, ctx context.Context, ch <-chan T) T {
+	t.Helper()
+	select {
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for a value: %v", ctx.Err())
+	case v, ok := <-ch:
+		if !ok {
+			t.Fatalf("channel closed while waiting for a value")
+		}
+		return v
+	}
+	panic("unreachable")
+}
+
+func TestRouter_AddRouteWhileRunning(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	router := NewRouter[StringCarrier](RoutingStrategyFirstMatch)
+
+	in := make(chan StringCarrier)
+	out := router.Apply(ctx, in)
+
+	in <- StringCarrier{Value: "a", Index: 0}
+	if got := recvOne(t, ctx, out).Value; got != "a" {
+		t.Fatalf("expected pass-through before any route: got %q", got)
+	}
+
+	id := router.AddProcessor(procSuffix("|r1"))
+	if id == 0 {
+		t.Fatalf("expected a non-zero RouteID")
+	}
+
+	in <- StringCarrier{Value: "b", Index: 1}
+	if got := recvOne(t, ctx, out).Value; got != "b|r1" {
+		t.Fatalf("expected routed item after AddRoute: got %q", got)
+	}
+
+	close(in)
+	if _, err := collectWithContext(ctx, out); err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+}
+
+func TestRouter_RemoveRouteClosesRouteInput(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	routeDone := make(chan struct{})
+	tracked := ProcessorFunc[StringCarrier](func(ctx context.Context, in <-chan StringCarrier) <-chan StringCarrier {
+		out := procSuffix("|tracked").Apply(ctx, in)
+		forwarded := make(chan StringCarrier)
+		go func() {
+			defer close(routeDone)
+			defer close(forwarded)
+			for v := range out {
+				forwarded <- v
+			}
+		}()
+		return forwarded
+	})
+
+	router := NewRouter[StringCarrier](RoutingStrategyFirstMatch)
+	id := router.AddProcessor(tracked)
+
+	in := make(chan StringCarrier)
+	out := router.Apply(ctx, in)
+
+	in <- StringCarrier{Value: "a", Index: 0}
+	if got := recvOne(t, ctx, out).Value; got != "a|tracked" {
+		t.Fatalf("unexpected routed value: got %q", got)
+	}
+
+	if !router.RemoveRoute(id) {
+		t.Fatalf("expected RemoveRoute to report a removal")
+	}
+	if router.RemoveRoute(id) {
+		t.Fatalf("expected a second RemoveRoute to be a no-op")
+	}
+
+	// The removed route must be released while the router is still running.
+	select {
+	case <-routeDone:
+	case <-ctx.Done():
+		t.Fatalf("removed route was not closed")
+	}
+
+	in <- StringCarrier{Value: "b", Index: 1}
+	if got := recvOne(t, ctx, out).Value; got != "b" {
+		t.Fatalf("expected pass-through after RemoveRoute: got %q", got)
+	}
+
+	close(in)
+	if _, err := collectWithContext(ctx, out); err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+}
+
+// TestRouter_ConcurrentReconfiguration is primarily meant to be run with the
+// race detector (go test -race).
+func TestRouter_ConcurrentReconfiguration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const n = 200
+
+	router := NewRouter[StringCarrier](RoutingStrategyRoundRobin, procSuffix("|base"))
+
+	in := make(chan StringCarrier)
+	out := router.Apply(ctx, in)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			id := router.AddProcessor(procSuffix(fmt.Sprintf("|w%d", i)))
+			router.SetStrategy(RoutingStrategyRoundRobin)
+			router.RemoveRoute(id)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			in <- StringCarrier{Value: "x", Index: i}
+		}
+		close(in)
+	}()
+
+	items, err := collectWithContext(ctx, out)
+	close(stop)
+	wg.Wait()
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != n {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), n)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// NewScan returns a Processor that emits the running aggregate of the stream
+// (a prefix scan): after each input item, acc = combine(acc, item) is emitted.
+//
+// The initial accumulator is the zero value of S. Unlike Aggregate, which
+// merges a whole set at once, NewScan is incremental and suits live running
+// totals or concatenations.
+//
+// Each emitted accumulator takes the index of the item that produced it;
+// combine decides how errors are propagated. Items are folded in arrival order,
+// so reorder upstream when the order matters. If combine is nil, items are
+// passed through unchanged.
+func NewScan[S Carrier[S]](combine func(acc, item S) S) ProcessorFunc[S] {
+	if combine == nil {
+		return passThroughProcessor[S]()
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		acc := *new(S)
+		return AsyncEmitter(ctx, in, func(ctx context.Context, item S, emit func(S)) {
+			acc = combine(acc, item).WithIndex(item.GetIndex())
+			emit(acc)
+		})
+	}
+}
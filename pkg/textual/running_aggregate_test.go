@@ -0,0 +1,74 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNewScan_RunningConcatenation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 3)
+	for i, w := range []string{"a", "b", "c"} {
+		in <- StringCarrier{Value: w, Index: i}
+	}
+	close(in)
+
+	concat := func(acc, item StringCarrier) StringCarrier {
+		acc.Value += item.Value
+		return acc
+	}
+	items, err := collectWithContext(ctx, NewScan(concat).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	for i, want := range []string{"a", "ab", "abc"} {
+		if items[i].Value != want || items[i].Index != i {
+			t.Fatalf("unexpected running value %d: got %q (index %d) want %q", i, items[i].Value, items[i].Index, want)
+		}
+	}
+}
+
+func TestNewScan_RunningTotal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 4)
+	for i, n := range []string{"3", "4", "10", "-2"} {
+		in <- StringCarrier{Value: n, Index: i}
+	}
+	close(in)
+
+	sum := func(acc, item StringCarrier) StringCarrier {
+		a, _ := strconv.Atoi(acc.Value)
+		b, _ := strconv.Atoi(item.Value)
+		acc.Value = strconv.Itoa(a + b)
+		return acc
+	}
+	items, err := collectWithContext(ctx, NewScan(sum).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	for i, want := range []string{"3", "7", "17", "15"} {
+		if items[i].Value != want {
+			t.Fatalf("unexpected running total %d: got %q want %q", i, items[i].Value, want)
+		}
+	}
+}
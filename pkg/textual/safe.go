@@ -47,6 +47,34 @@ func safeCloseChan[T any](ps *PanicStore, ch chan T) {
 	close(ch)
 }
 
+// drainInBackground consumes ch in a new goroutine until it is closed or ctx
+// is canceled, so that the producer of ch never blocks on send once a stage
+// stops forwarding. Panics are captured into ps.
+func drainInBackground[T any](ctx context.Context, ps *PanicStore, ch <-chan T) {
+	if ch == nil {
+		return
+	}
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				if ps != nil {
+					ps.Store(r, debug.Stack())
+				}
+			}
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-ch:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+}
+
 // safeApplyProcessor calls p.Apply(ctx, in) defensively:
 //
 //   - recovers panics and stores them into ps,
@@ -0,0 +1,75 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// SampleMode selects the items kept by Sample. Build it with SampleEveryN or
+// SampleRate.
+type SampleMode struct {
+	every  int     // keep 1 of every n items, by index (SampleEveryN)
+	rate   float64 // keep probability (SampleRate)
+	seed   int64
+	seeded bool
+}
+
+// SampleEveryN keeps the items whose index is a multiple of n: 1 of every n
+// items of a contiguously indexed stream. It is deterministic, so the same
+// items are kept across runs. n <= 1 keeps every item.
+func SampleEveryN(n int) SampleMode {
+	return SampleMode{every: max(n, 1)}
+}
+
+// SampleRate keeps each item independently with probability p. p <= 0 keeps
+// no item, p >= 1 keeps every item. The random source is time-seeded, like the
+// one of Router; use WithSeed for reproducible runs.
+func SampleRate(p float64) SampleMode {
+	return SampleMode{rate: min(max(p, 0), 1)}
+}
+
+// WithSeed returns a copy of m whose random source is seeded with seed, so
+// that every Apply call of the Sample keeps the same items for the same input.
+// It has no effect on SampleEveryN.
+func (m SampleMode) WithSeed(seed int64) SampleMode {
+	m.seed, m.seeded = seed, true
+	return m
+}
+
+// Sample returns a Processor forwarding a fraction of the items, selected by
+// mode, to thin out high-volume streams (telemetry, logs, ...). Kept items are
+// not modified, so their indices have gaps (see Filter); the others are
+// dropped. The stage is cancellation-aware.
+func Sample[S Carrier[S]](mode SampleMode) ProcessorFunc[S] {
+	if n := mode.every; n > 0 {
+		return Filter(func(_ context.Context, item S) bool {
+			return item.GetIndex()%n == 0
+		})
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		seed := mode.seed
+		if !mode.seeded {
+			seed = time.Now().UnixNano()
+		}
+		// Filter evaluates the predicate sequentially: the source needs no lock.
+		rnd := rand.New(rand.NewSource(seed))
+		return Filter(func(_ context.Context, _ S) bool {
+			return rnd.Float64() < mode.rate
+		}).Apply(ctx, in)
+	}
+}
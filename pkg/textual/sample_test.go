@@ -0,0 +1,80 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func sampleIndices(t *testing.T, mode SampleMode, n int) []int {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, n)
+	for i := 0; i < n; i++ {
+		in <- StringCarrier{Value: "x", Index: i}
+	}
+	close(in)
+
+	out, err := collectWithContext(ctx, Sample[StringCarrier](mode).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	indices := make([]int, len(out))
+	for i, item := range out {
+		indices[i] = item.Index
+	}
+	return indices
+}
+
+func TestSample_EveryN(t *testing.T) {
+	got := sampleIndices(t, SampleEveryN(10), 95)
+	if len(got) != 10 {
+		t.Fatalf("unexpected retained count: got %d want %d", len(got), 10)
+	}
+	for i, idx := range got {
+		if idx != i*10 {
+			t.Fatalf("unexpected retained index %d: got %d want %d", i, idx, i*10)
+		}
+	}
+}
+
+func TestSample_RateIsApproximateAndSeeded(t *testing.T) {
+	const n = 10000
+	mode := SampleRate(0.2).WithSeed(42)
+	first := sampleIndices(t, mode, n)
+	if len(first) < n*17/100 || len(first) > n*23/100 {
+		t.Fatalf("unexpected retained count: got %d want about %d", len(first), n/5)
+	}
+	second := sampleIndices(t, mode, n)
+	if len(second) != len(first) {
+		t.Fatalf("unexpected non-reproducible sample: got %d then %d items", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("unexpected non-reproducible sample at %d: got %d then %d", i, first[i], second[i])
+		}
+	}
+
+	if got := sampleIndices(t, SampleRate(0), 100); len(got) != 0 {
+		t.Fatalf("unexpected retained count for rate 0: got %d", len(got))
+	}
+	if got := sampleIndices(t, SampleRate(1), 100); len(got) != 100 {
+		t.Fatalf("unexpected retained count for rate 1: got %d", len(got))
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidUTF8 is attached by SanitizeUTF8 (with SanitizeMark) to the items
+// whose text had to be repaired.
+var ErrInvalidUTF8 = errors.New("textual: invalid UTF-8 repaired")
+
+// SanitizeMode selects how SanitizeUTF8 repairs invalid UTF-8. Modes are flags
+// and can be combined, e.g. SanitizeDrop | SanitizeMark.
+type SanitizeMode int
+
+const (
+	// SanitizeReplace replaces each run of invalid bytes with U+FFFD.
+	SanitizeReplace SanitizeMode = 1 << iota
+	// SanitizeDrop removes invalid bytes. It takes precedence over
+	// SanitizeReplace.
+	SanitizeDrop
+	// SanitizeMark attaches an ErrInvalidUTF8 error to repaired items. Used
+	// alone, it implies SanitizeReplace.
+	SanitizeMark
+)
+
+// SanitizeUTF8 returns a Processor that enforces the package UTF-8 invariant:
+// the UTF8String() representation of every item is checked and, when it holds
+// invalid byte sequences, the item is rebuilt with FromUTF8String from the
+// repaired text, preserving its index and error.
+//
+// Valid items are forwarded untouched, so the check is cheap on clean input.
+// Rebuilding from text drops carrier-specific state (e.g. Parcel fragments),
+// which is expected since positions computed on invalid text are meaningless.
+func SanitizeUTF8[S Carrier[S]](mode SanitizeMode) ProcessorFunc[S] {
+	replacement := "\uFFFD"
+	if mode&SanitizeDrop != 0 {
+		replacement = ""
+	}
+	return NewProcessorFunc(func(_ context.Context, item S) S {
+		text := item.UTF8String()
+		if utf8.ValidString(text) {
+			return item
+		}
+		res := item.FromUTF8String(UTF8String(strings.ToValidUTF8(text, replacement))).
+			WithIndex(item.GetIndex()).
+			WithError(item.GetError())
+		if mode&SanitizeMark != 0 {
+			res = res.WithError(fmt.Errorf("%w (index %d)", ErrInvalidUTF8, item.GetIndex()))
+		}
+		return res
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSanitizeUTF8(t *testing.T) {
+	cases := []struct {
+		name string
+		mode SanitizeMode
+		want []string
+		mark bool
+	}{
+		{"replace", SanitizeReplace, []string{"caf\uFFFD!", "ok"}, false},
+		{"drop", SanitizeDrop, []string{"caf!", "ok"}, false},
+		{"mark", SanitizeDrop | SanitizeMark, []string{"caf!", "ok"}, true},
+		{"mark alone replaces", SanitizeMark, []string{"caf\uFFFD!", "ok"}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			in := make(chan StringCarrier, 2)
+			in <- StringCarrier{Value: "caf\xc3\xff!", Index: 4}
+			in <- StringCarrier{Value: "ok", Index: 5}
+			close(in)
+
+			items, err := collectWithContext(ctx, SanitizeUTF8[StringCarrier](tc.mode).Apply(ctx, in))
+			if err != nil {
+				t.Fatalf("collect failed: %v", err)
+			}
+			sortByIndex(items)
+			if len(items) != 2 {
+				t.Fatalf("unexpected output count: got %d want %d", len(items), 2)
+			}
+			for i, w := range tc.want {
+				if items[i].Value != w {
+					t.Fatalf("unexpected value: got %q want %q", items[i].Value, w)
+				}
+			}
+			if items[0].Index != 4 {
+				t.Fatalf("index must be preserved: got %d want %d", items[0].Index, 4)
+			}
+			if got := errors.Is(items[0].GetError(), ErrInvalidUTF8); got != tc.mark {
+				t.Fatalf("unexpected mark: got %v want %v", got, tc.mark)
+			}
+			if items[1].GetError() != nil {
+				t.Fatalf("valid items must be untouched: %v", items[1].GetError())
+			}
+		})
+	}
+}
@@ -14,7 +14,12 @@
 
 package textual
 
-import "io"
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+)
 
 // ScanCSV is a bufio.SplitFunc that tokenizes an input stream into CSV records.
 //
@@ -27,7 +32,7 @@ import "io"
 //   - inside a quoted field, '""' represents an escaped quote
 //   - The returned token does NOT include the trailing record separator.
 //   - If atEOF is true and a quoted field is still open, ScanCSV returns
-//     io.ErrUnexpectedEOF.
+//     an error matching both ErrFraming and io.ErrUnexpectedEOF (errors.Is).
 //
 // This split func does not validate the full CSV dialect (delimiter choice,
 // comments, etc). It only provides robust record framing suitable for streaming.
@@ -41,6 +46,46 @@ import "io"
 //	    // ...
 //	}
 func ScanCSV(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	return scanCSVRecord(data, atEOF, []byte{'"'})
+}
+
+// ScanCSVWithDialect returns a bufio.SplitFunc framing CSV records like ScanCSV,
+// for dialects using another field delimiter or quote character (TSV,
+// semicolon-separated European CSV, ...).
+//
+// Only the quote rune affects framing: record separators inside quoted fields
+// do not end the record, and a doubled quote is an escaped quote. The comma is
+// validated but does not otherwise change framing, since field delimiters never
+// end a record. A quote of 0 disables quoting: every newline ends a record
+// (plain TSV).
+//
+// If comma or quote is a newline, an invalid rune, or if both are equal, the
+// returned split func always fails.
+//
+// Example (semicolon-separated values):
+//
+//	scanner.Split(textual.ScanCSVWithDialect(';', '"'))
+func ScanCSVWithDialect(comma, quote rune) bufio.SplitFunc {
+	invalid := func(r rune) bool {
+		return r == '\n' || r == '\r' || !utf8.ValidRune(r)
+	}
+	if invalid(comma) || (quote != 0 && invalid(quote)) || comma == quote {
+		return func(data []byte, atEOF bool) (int, []byte, error) {
+			return 0, nil, fmt.Errorf("textual: invalid CSV dialect (comma %q, quote %q)", comma, quote)
+		}
+	}
+	var q []byte
+	if quote != 0 {
+		q = utf8.AppendRune(nil, quote)
+	}
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		return scanCSVRecord(data, atEOF, q)
+	}
+}
+
+// scanCSVRecord frames one record, tracking quoted fields delimited by quote
+// (the UTF-8 encoding of the quote rune, or nil when quoting is disabled).
+func scanCSVRecord(data []byte, atEOF bool, quote []byte) (advance int, token []byte, err error) {
 	// No data and nothing more to read.
 	if atEOF && len(data) == 0 {
 		return 0, nil, nil
@@ -52,21 +97,21 @@ func ScanCSV(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	for i < len(data) {
 		b := data[i]
 
-		if b == '"' {
+		if len(quote) > 0 && bytes.HasPrefix(data[i:], quote) {
 			if inQuotes {
 				// Inside quotes, a doubled quote ("") is an escaped quote.
-				if i+1 < len(data) && data[i+1] == '"' {
-					i += 2
+				if bytes.HasPrefix(data[i+len(quote):], quote) {
+					i += 2 * len(quote)
 					continue
 				}
 				// Otherwise this closes the quoted field.
 				inQuotes = false
-				i++
+				i += len(quote)
 				continue
 			}
 			// Opening quote.
 			inQuotes = true
-			i++
+			i += len(quote)
 			continue
 		}
 
@@ -82,6 +127,10 @@ func ScanCSV(data []byte, atEOF bool) (advance int, token []byte, err error) {
 
 			case '\r':
 				// Record ends before '\r'. Support both '\r\n' and '\r'.
+				if i+1 == len(data) && !atEOF {
+					// Request more data: a '\n' may follow.
+					return 0, nil, nil
+				}
 				end := i
 				adv := i + 1
 				if i+1 < len(data) && data[i+1] == '\n' {
@@ -97,7 +146,7 @@ func ScanCSV(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	// No record delimiter found in current buffer.
 	if atEOF {
 		if inQuotes {
-			return 0, nil, io.ErrUnexpectedEOF
+			return 0, nil, errFramingEOF
 		}
 		// Last record at EOF: return the remainder (no delimiter).
 		return len(data), data, nil
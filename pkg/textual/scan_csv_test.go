@@ -0,0 +1,65 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestScanCSVWithDialect_TSV(t *testing.T) {
+	got := scanAll(t, "name\tnote\nada\t\"tab\tinside\"\nalan\tx\n", ScanCSVWithDialect('\t', '"'))
+	want := []string{"name\tnote", "ada\t\"tab\tinside\"", "alan\tx"}
+	assertTokens(t, got, want)
+
+	// Without quoting, quotes are plain bytes and every newline ends a record.
+	got = scanAll(t, "a\t\"b\nc\td\n", ScanCSVWithDialect('\t', 0))
+	assertTokens(t, got, []string{"a\t\"b", "c\td"})
+}
+
+func TestScanCSVWithDialect_Semicolon(t *testing.T) {
+	input := "id;text\r\n1;\"a;b\nc\"\r\n2;'single'\n3;\"x \"\"y\"\"\""
+	got := scanAll(t, input, ScanCSVWithDialect(';', '"'))
+	want := []string{"id;text", "1;\"a;b\nc\"", "2;'single'", "3;\"x \"\"y\"\"\""}
+	assertTokens(t, got, want)
+
+	// A quote rune that is multi-byte in UTF-8.
+	got = scanAll(t, "1;\u00a7a\nb\u00a7\n2;c", ScanCSVWithDialect(';', '\u00a7'))
+	assertTokens(t, got, []string{"1;\u00a7a\nb\u00a7", "2;c"})
+}
+
+func TestScanCSVWithDialect_Invalid(t *testing.T) {
+	scanner := bufio.NewScanner(strings.NewReader("a;b"))
+	scanner.Split(ScanCSVWithDialect(';', ';'))
+	if scanner.Scan() {
+		t.Fatalf("unexpected token %q", scanner.Text())
+	}
+	if scanner.Err() == nil {
+		t.Fatalf("expected an invalid dialect error")
+	}
+}
+
+func assertTokens(t *testing.T, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("unexpected token count: got %q want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected token %d: got %q want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bufio"
+	"fmt"
+)
+
+// ScanFixedWidth returns a bufio.SplitFunc that frames a stream into
+// fixed-width records, as found in legacy (e.g. mainframe) exports that have
+// no delimiters.
+//
+// Framing behaviour:
+//
+//   - Each token is exactly width *bytes* long (not runes): a multi-byte UTF-8
+//     sequence may be split across two records if the producer did not pad
+//     on byte boundaries.
+//   - More data is requested until width bytes are available.
+//   - At EOF, the remaining bytes (if any) are emitted as a final, shorter
+//     record.
+//
+// Record separators (if any) are not stripped: they are part of the record
+// width.
+//
+// If width <= 0, the returned split func always fails.
+func ScanFixedWidth(width int) bufio.SplitFunc {
+	if width <= 0 {
+		return func(data []byte, atEOF bool) (int, []byte, error) {
+			return 0, nil, fmt.Errorf("textual: ScanFixedWidth requires a positive width, got %d", width)
+		}
+	}
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if len(data) >= width {
+			return width, data[:width], nil
+		}
+		if atEOF && len(data) > 0 {
+			// Final short record.
+			return len(data), data, nil
+		}
+		// Request more data (or stop at EOF with no data).
+		return 0, nil, nil
+	}
+}
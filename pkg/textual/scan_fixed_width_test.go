@@ -0,0 +1,49 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScanFixedWidth_EmitsRecordsAndFinalShortRecord(t *testing.T) {
+	got := scanAll(t, "AAAA0001BBBB0002CC", ScanFixedWidth(8))
+	want := []string{"AAAA0001", "BBBB0002", "CC"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected tokens:\n got: %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestScanFixedWidth_WidthIsInBytes(t *testing.T) {
+	// "é" is 2 bytes long in UTF-8.
+	got := scanAll(t, "éé", ScanFixedWidth(2))
+	want := []string{"é", "é"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected tokens:\n got: %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestScanFixedWidth_NonPositiveWidthFails(t *testing.T) {
+	scanner := bufio.NewScanner(strings.NewReader("abc"))
+	scanner.Split(ScanFixedWidth(0))
+	for scanner.Scan() {
+	}
+	if scanner.Err() == nil {
+		t.Fatalf("expected an error for a non-positive width")
+	}
+}
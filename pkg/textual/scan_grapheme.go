@@ -0,0 +1,209 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// ScanGrapheme is a bufio.SplitFunc that tokenizes an input stream into
+// user-perceived characters (extended grapheme clusters, UAX #29).
+//
+// Each token is one cluster, so that a base letter and its combining accents,
+// an emoji and its skin tone modifier, a ZWJ emoji sequence or a flag (pair of
+// regional indicators) are never split. Concatenating all tokens in order
+// reconstructs the original byte stream.
+//
+// The implementation covers the common boundary rules:
+//
+//   - CR LF is one cluster; other control characters are clusters on their own.
+//   - Combining marks (Mn, Me), spacing marks (Mc), ZWJ, variation selectors
+//     and emoji modifiers extend the preceding cluster.
+//   - Extended pictographic characters joined by ZWJ form one cluster.
+//   - Regional indicators are paired.
+//   - Hangul syllable sequences (L, V, T, LV, LVT) form one cluster.
+//
+// Prepend characters and the Indic conjunct rules are not implemented: they
+// fall back to one cluster per rune.
+//
+// Since a cluster may always be extended by the next rune, a cluster is only
+// emitted once the following rune has been read (or at EOF).
+//
+// This is finer-grained than ScanExpression and is useful for typewriter-style
+// rendering.
+func ScanGrapheme(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	// No data and nothing more to read.
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if !atEOF && !utf8.FullRune(data) {
+		// Request more data to decode the first rune.
+		return 0, nil, nil
+	}
+
+	r, size := utf8.DecodeRune(data)
+	prev := graphemeClassOf(r)
+	state := graphemeState{}
+	state.update(prev)
+	pos := size
+
+	for pos < len(data) {
+		if !atEOF && !utf8.FullRune(data[pos:]) {
+			// Request more data to decode the next rune.
+			return 0, nil, nil
+		}
+		r, size = utf8.DecodeRune(data[pos:])
+		cur := graphemeClassOf(r)
+		if graphemeBreak(prev, cur, state) {
+			return pos, data[:pos], nil
+		}
+		state.update(cur)
+		prev = cur
+		pos += size
+	}
+
+	if atEOF {
+		return len(data), data, nil
+	}
+	// The cluster could still be extended by the next rune.
+	return 0, nil, nil
+}
+
+// graphemeClass is a simplified Grapheme_Cluster_Break property.
+type graphemeClass int
+
+const (
+	gcOther graphemeClass = iota
+	gcCR
+	gcLF
+	gcControl
+	gcExtend
+	gcZWJ
+	gcSpacingMark
+	gcRegionalIndicator
+	gcExtendedPictographic
+	gcL
+	gcV
+	gcT
+	gcLV
+	gcLVT
+)
+
+// graphemeState tracks the context needed by the rules looking further back
+// than the previous rune (GB11 and GB12/13).
+type graphemeState struct {
+	// pictographic is true while the cluster matches ExtPict Extend* (ZWJ)?.
+	pictographic bool
+	// regionalIndicators counts the regional indicators of the current run.
+	regionalIndicators int
+}
+
+func (s *graphemeState) update(c graphemeClass) {
+	switch c {
+	case gcExtendedPictographic:
+		s.pictographic = true
+	case gcExtend, gcZWJ:
+		// Keep the pictographic sequence alive.
+	default:
+		s.pictographic = false
+	}
+	if c == gcRegionalIndicator {
+		s.regionalIndicators++
+	} else {
+		s.regionalIndicators = 0
+	}
+}
+
+// graphemeBreak reports whether there is a cluster boundary between prev and cur.
+func graphemeBreak(prev, cur graphemeClass, s graphemeState) bool {
+	switch {
+	case prev == gcCR && cur == gcLF: // GB3
+		return false
+	case prev == gcCR || prev == gcLF || prev == gcControl: // GB4
+		return true
+	case cur == gcCR || cur == gcLF || cur == gcControl: // GB5
+		return true
+	case prev == gcL && (cur == gcL || cur == gcV || cur == gcLV || cur == gcLVT): // GB6
+		return false
+	case (prev == gcLV || prev == gcV) && (cur == gcV || cur == gcT): // GB7
+		return false
+	case (prev == gcLVT || prev == gcT) && cur == gcT: // GB8
+		return false
+	case cur == gcExtend || cur == gcZWJ || cur == gcSpacingMark: // GB9, GB9a
+		return false
+	case prev == gcZWJ && cur == gcExtendedPictographic && s.pictographic: // GB11
+		return false
+	case prev == gcRegionalIndicator && cur == gcRegionalIndicator: // GB12, GB13
+		return s.regionalIndicators%2 == 0
+	}
+	return true // GB999
+}
+
+func graphemeClassOf(r rune) graphemeClass {
+	switch {
+	case r == '\r':
+		return gcCR
+	case r == '\n':
+		return gcLF
+	case r == 0x200D:
+		return gcZWJ
+	case r == 0x200C, r >= 0x1F3FB && r <= 0x1F3FF, r >= 0xE0020 && r <= 0xE007F:
+		// ZWNJ, emoji modifiers (skin tones) and tag characters.
+		return gcExtend
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return gcRegionalIndicator
+	case unicode.Is(unicode.Mn, r), unicode.Is(unicode.Me, r):
+		return gcExtend
+	case unicode.Is(unicode.Mc, r):
+		return gcSpacingMark
+	case unicode.IsControl(r), r == 0x2028, r == 0x2029, unicode.Is(unicode.Cf, r) && r != 0x200D:
+		return gcControl
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return gcL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return gcV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return gcT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return gcLV
+		}
+		return gcLVT
+	case isExtendedPictographic(r):
+		return gcExtendedPictographic
+	}
+	return gcOther
+}
+
+// isExtendedPictographic approximates the Extended_Pictographic property with
+// the blocks where emoji live.
+func isExtendedPictographic(r rune) bool {
+	switch {
+	case r == 0x00A9, r == 0x00AE, r == 0x203C, r == 0x2049, r == 0x2122, r == 0x2139,
+		r == 0x24C2, r == 0x3030, r == 0x303D, r == 0x3297, r == 0x3299:
+		return true
+	case r >= 0x2194 && r <= 0x21AA,
+		r >= 0x2300 && r <= 0x23FF,
+		r >= 0x25AA && r <= 0x25FE,
+		r >= 0x2600 && r <= 0x27BF,
+		r >= 0x2934 && r <= 0x2935,
+		r >= 0x2B05 && r <= 0x2B55,
+		r >= 0x1F000 && r <= 0x1FAFF,
+		r >= 0x1FC00 && r <= 0x1FFFD:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScanGrapheme_KeepsClustersTogether(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"decomposed accent", "cafe\u0301!", []string{"c", "a", "f", "e\u0301", "!"}},
+		{"flags", "\U0001F1EB\U0001F1F7\U0001F1EF\U0001F1F5", []string{"\U0001F1EB\U0001F1F7", "\U0001F1EF\U0001F1F5"}},
+		{"emoji modifier", "\U0001F44D\U0001F3FD ok", []string{"\U0001F44D\U0001F3FD", " ", "o", "k"}},
+		{"zwj sequence", "\U0001F469\u200d\U0001F4BB.", []string{"\U0001F469\u200d\U0001F4BB", "."}},
+		{"crlf", "a\r\nb", []string{"a", "\r\n", "b"}},
+		{"hangul jamo", "\u1100\u1161\u11a8x", []string{"\u1100\u1161\u11a8", "x"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := scanAll(t, tc.input, ScanGrapheme)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected tokens:\n got: %q\nwant: %q", got, tc.want)
+			}
+			if strings.Join(got, "") != tc.input {
+				t.Fatalf("tokens do not reconstruct the input")
+			}
+		})
+	}
+}
@@ -14,11 +14,6 @@
 
 package textual
 
-import (
-	"fmt"
-	"io"
-)
-
 // ScanJSON is a bufio.SplitFunc that tokenizes an input stream into top-level
 // JsonCarrier values (objects `{...}` or arrays `[...]`).
 //
@@ -32,7 +27,8 @@ import (
 //   - JsonCarrier strings are recognized; braces/brackets inside strings do not affect
 //     nesting. Basic escape handling is implemented so `\"` does not end a string.
 //   - If atEOF is true and a JsonCarrier value is still open, ScanJSON returns
-//     io.ErrUnexpectedEOF.
+//     an error matching both ErrFraming and io.ErrUnexpectedEOF (errors.Is).
+//     Unbalanced closing brackets are reported as ErrFraming too.
 //
 // This split func does NOT fully validate JsonCarrier; it only provides robust framing
 // suitable for streaming.
@@ -76,7 +72,30 @@ func ScanJSON(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	// we must be able to return a complete token even when it is preceded by
 	// ignored bytes.
 
-	// data[start] is '{' or '['.
+	end, err := scanJSONComposite(data, start)
+	if err != nil {
+		return 0, nil, err
+	}
+	if end > 0 {
+		return end, data[start:end], nil
+	}
+
+	// Buffer ended before we found the matching closing delimiter.
+	if atEOF {
+		return 0, nil, errFramingEOF
+	}
+	// If we had to skip leading noise, consume it now so the scanner doesn't
+	// keep growing its buffer indefinitely while waiting for more bytes.
+	if start > 0 {
+		return start, nil, nil
+	}
+	return 0, nil, nil
+}
+
+// scanJSONComposite scans the object or array opening at data[start] ('{' or
+// '['), tracking nesting and strings. It returns the index right after the
+// matching closing delimiter, or 0 when the buffer ends before it.
+func scanJSONComposite(data []byte, start int) (end int, err error) {
 	stack := make([]byte, 0, 8)
 	stack = append(stack, data[start])
 
@@ -112,30 +131,19 @@ func ScanJSON(data []byte, atEOF bool) (advance int, token []byte, err error) {
 
 		case '}', ']':
 			if len(stack) == 0 {
-				return 0, nil, fmt.Errorf("scanJSON: unexpected closing %q at byte %d", b, i)
+				return 0, framingErrorf("scanJSON: unexpected closing %q at byte %d", b, i)
 			}
 			top := stack[len(stack)-1]
 			matches := (b == '}' && top == '{') || (b == ']' && top == '[')
 			if !matches {
-				return 0, nil, fmt.Errorf("scanJSON: mismatched closing %q for %q at byte %d", b, top, i)
+				return 0, framingErrorf("scanJSON: mismatched closing %q for %q at byte %d", b, top, i)
 			}
 			// Pop.
 			stack = stack[:len(stack)-1]
 			if len(stack) == 0 {
-				end := i + 1
-				return end, data[start:end], nil
+				return i + 1, nil
 			}
 		}
 	}
-
-	// Buffer ended before we found the matching closing delimiter.
-	if atEOF {
-		return 0, nil, io.ErrUnexpectedEOF
-	}
-	// If we had to skip leading noise, consume it now so the scanner doesn't
-	// keep growing its buffer indefinitely while waiting for more bytes.
-	if start > 0 {
-		return start, nil, nil
-	}
-	return 0, nil, nil
+	return 0, nil
 }
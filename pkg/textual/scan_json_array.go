@@ -0,0 +1,132 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bufio"
+	"bytes"
+)
+
+// ScanJSONArrayElements returns a bufio.SplitFunc that streams the elements of
+// top-level JSON arrays: after an opening `[`, each element (object, array,
+// string, number, true, false or null) is returned as a separate token, so a
+// large array is processed without buffering it whole.
+//
+// Framing behaviour:
+//
+//   - Any bytes before the opening `[` are ignored (consumed), like ScanJSON.
+//   - Inside the array, white space and the commas separating elements are
+//     skipped; the closing `]` ends the array and the split func then looks
+//     for the next `[`.
+//   - Objects and arrays are framed like ScanJSON, strings with their escapes:
+//     commas and brackets inside strings do not split elements.
+//   - If atEOF is true while an array is still open, the split func returns
+//     an error matching both ErrFraming and io.ErrUnexpectedEOF (errors.Is).
+//
+// Like ScanJSON, it frames values without validating them. The returned split
+// func tracks whether it is inside an array: use a new one for each scanner.
+//
+// Example:
+//
+//	scanner := bufio.NewScanner(r)
+//	scanner.Split(textual.ScanJSONArrayElements())
+//	for scanner.Scan() {
+//	    element := scanner.Bytes() // one array element
+//	    // ...
+//	}
+func ScanJSONArrayElements() bufio.SplitFunc {
+	inArray := false
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		// Brackets and separators are consumed in the same call as the next
+		// element: at EOF, bufio.Scanner stops on the first nil token.
+		pos := 0
+		for {
+			if !inArray {
+				open := bytes.IndexByte(data[pos:], '[')
+				if open == -1 {
+					// No array in the current buffer: consume the leading noise.
+					return len(data), nil, nil
+				}
+				pos += open + 1
+				inArray = true
+			}
+
+			// Skip separators.
+			for pos < len(data) && (data[pos] == ',' || isJSONSpace(data[pos])) {
+				pos++
+			}
+			if pos == len(data) {
+				if atEOF {
+					return 0, nil, errFramingEOF
+				}
+				return pos, nil, nil
+			}
+
+			start, end := pos, 0
+			switch data[start] {
+			case ']':
+				inArray = false
+				pos++
+				continue
+			case '{', '[':
+				if end, err = scanJSONComposite(data, start); err != nil {
+					return 0, nil, err
+				}
+			case '}':
+				return 0, nil, framingErrorf("scanJSONArrayElements: unexpected closing %q at byte %d", data[start], start)
+			case '"':
+				end = scanJSONStringEnd(data, start)
+			default:
+				// Scalar: runs until a separator. At the end of the buffer, it
+				// may continue in the next read.
+				end = start
+				for end < len(data) && data[end] != ',' && data[end] != ']' && !isJSONSpace(data[end]) {
+					end++
+				}
+				if end == len(data) {
+					end = 0
+				}
+			}
+			if end > 0 {
+				return end, data[start:end], nil
+			}
+			if atEOF {
+				return 0, nil, errFramingEOF
+			}
+			return start, nil, nil
+		}
+	}
+}
+
+// scanJSONStringEnd returns the index right after the closing quote of the
+// string opening at data[start], or 0 when the buffer ends before it.
+func scanJSONStringEnd(data []byte, start int) int {
+	escaped := false
+	for i := start + 1; i < len(data); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case data[i] == '\\':
+			escaped = true
+		case data[i] == '"':
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func isJSONSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func scanJSONArrayElements(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(ScanJSONArrayElements())
+	var tokens []string
+	for scanner.Scan() {
+		tokens = append(tokens, scanner.Text())
+	}
+	return tokens, scanner.Err()
+}
+
+func TestScanJSONArrayElements_StreamsElements(t *testing.T) {
+	cases := []struct {
+		input string
+		want  []string
+	}{
+		{`[{"a":1},{"b":2},"x"]`, []string{`{"a":1}`, `{"b":2}`, `"x"`}},
+		{" noise [ 1 , -2.5e3,true,null , [\"a,]\", {\"c\":\"}\\\",\"}] ,\"q\\\"],\" ]\n[]\n[false]", []string{`1`, `-2.5e3`, `true`, `null`, `["a,]", {"c":"}\","}]`, `"q\"],"`, `false`}},
+	}
+	for _, c := range cases {
+		for _, oneByte := range []bool{false, true} {
+			var r io.Reader = strings.NewReader(c.input)
+			if oneByte {
+				r = iotest.OneByteReader(r)
+			}
+			tokens, err := scanJSONArrayElements(r)
+			if err != nil {
+				t.Fatalf("scanner error for %q: %v", c.input, err)
+			}
+			if len(tokens) != len(c.want) {
+				t.Fatalf("unexpected token count for %q: got %d want %d tokens=%#v", c.input, len(tokens), len(c.want), tokens)
+			}
+			for i := range c.want {
+				if tokens[i] != c.want[i] {
+					t.Fatalf("token %d mismatch: got %q want %q", i, tokens[i], c.want[i])
+				}
+			}
+		}
+	}
+}
+
+func TestScanJSONArrayElements_UnexpectedEOF(t *testing.T) {
+	for _, input := range []string{`[{"a":1},`, `[1,2`, `["abc`} {
+		_, err := scanJSONArrayElements(strings.NewReader(input))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("unexpected error for %q: got %v want %v", input, err, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+func TestScanJSONArrayElements_UnexpectedClosingBrace(t *testing.T) {
+	_, err := scanJSONArrayElements(strings.NewReader(`[1,}]`))
+	if !errors.Is(err, ErrFraming) || !strings.Contains(err.Error(), "scanJSONArrayElements:") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bufio"
+	"errors"
+	"regexp"
+	"unicode/utf8"
+)
+
+// ScanRegex returns a bufio.SplitFunc that splits the stream on matches of re.
+//
+// Framing behaviour:
+//
+//   - Tokens are the bytes found between two matches; the delimiter itself is
+//     not part of any token.
+//   - Consecutive delimiters yield empty tokens (like strings.Split). A
+//     pattern such as `\n{2,}` naturally merges runs of delimiters.
+//   - Zero-length matches are ignored (they cannot delimit anything).
+//   - The bytes after the last match are returned as a final token at EOF
+//     (unless they are empty).
+//
+// Incremental matching caveat:
+//
+// The pattern is evaluated against the scanner's *current buffer*, not against
+// the whole stream. When a match touches the end of the buffer and more data
+// may follow, ScanRegex requests more data, because the match could still
+// extend (e.g. `\n{2,}` followed by more newlines). A delimiter is therefore
+// never split across two reads. However, patterns relying on look-around
+// semantics that depend on bytes far beyond the match, or anchors such as `$`
+// without (?m), may behave differently than on a fully buffered input.
+//
+// A record is only emitted once its closing delimiter has been read, so very
+// large records require a larger buffer: use bufio.Scanner.Buffer to raise the
+// maximum token size (the default is bufio.MaxScanTokenSize).
+//
+// Example (paragraphs):
+//
+//	scanner := bufio.NewScanner(r)
+//	scanner.Split(textual.ScanRegex(regexp.MustCompile(`\n{2,}`)))
+//
+// If re is nil, the returned split func always fails.
+func ScanRegex(re *regexp.Regexp) bufio.SplitFunc {
+	if re == nil {
+		return func(data []byte, atEOF bool) (int, []byte, error) {
+			return 0, nil, errors.New("textual: ScanRegex requires a non-nil regexp")
+		}
+	}
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		// No data and nothing more to read.
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		// Find the first non-empty match, one match at a time: collecting all
+		// matches of a large buffer up front would be wasted work.
+		for off := 0; off <= len(data); {
+			loc := re.FindIndex(data[off:])
+			if loc == nil {
+				break
+			}
+			start, end := off+loc[0], off+loc[1]
+			if start == end {
+				// Skip past the empty match.
+				_, size := utf8.DecodeRune(data[end:])
+				off = end + max(size, 1)
+				continue
+			}
+			if end == len(data) && !atEOF {
+				// The delimiter touches the end of the buffer: it could
+				// still extend with more data.
+				return 0, nil, nil
+			}
+			return end, data[:start], nil
+		}
+
+		// No delimiter in the current buffer.
+		if atEOF {
+			return len(data), data, nil
+		}
+
+		// Request more data.
+		return 0, nil, nil
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bufio"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func scanAll(t *testing.T, input string, split bufio.SplitFunc) []string {
+	t.Helper()
+	// OneByteReader forces the split func to work on partial buffers.
+	scanner := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(input)))
+	scanner.Split(split)
+	var tokens []string
+	for scanner.Scan() {
+		tokens = append(tokens, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+	return tokens
+}
+
+func TestScanRegex_Paragraphs(t *testing.T) {
+	input := "First paragraph,\nstill first.\n\n\nSecond one.\n\nThird."
+
+	got := scanAll(t, input, ScanRegex(regexp.MustCompile(`\n{2,}`)))
+	want := []string{"First paragraph,\nstill first.", "Second one.", "Third."}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected tokens:\n got: %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestScanRegex_DocumentSeparator(t *testing.T) {
+	input := "title: a\nbody\n---\ntitle: b\n---\ntitle: c\n"
+
+	got := scanAll(t, input, ScanRegex(regexp.MustCompile(`(?m)^---\n`)))
+	want := []string{"title: a\nbody\n", "title: b\n", "title: c\n"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected tokens:\n got: %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestScanRegex_NilRegexpFails(t *testing.T) {
+	scanner := bufio.NewScanner(strings.NewReader("x"))
+	scanner.Split(ScanRegex(nil))
+	for scanner.Scan() {
+	}
+	if scanner.Err() == nil {
+		t.Fatalf("expected an error for a nil regexp")
+	}
+}
+
+func TestScanRegex_SkipsEmptyMatches(t *testing.T) {
+	input := "abxxcé;xd"
+
+	got := scanAll(t, input, ScanRegex(regexp.MustCompile(`x*`)))
+	want := []string{"ab", "cé;", "d"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected tokens:\n got: %#v\nwant: %#v", got, want)
+	}
+}
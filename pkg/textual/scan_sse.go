@@ -0,0 +1,119 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bytes"
+)
+
+// ScanSSE is a bufio.SplitFunc that frames a Server-Sent Events stream
+// (text/event-stream) into one token per event.
+//
+// Framing behaviour:
+//
+//   - Lines (terminated by "\n", "\r\n" or "\r") are accumulated until a blank
+//     line, which dispatches the event.
+//   - The token is the event data: the values of its "data:" fields joined
+//     with "\n" (a single leading space after the colon is removed).
+//   - "event:", "id:" and "retry:" fields are recognized but not part of the
+//     token; unknown fields are ignored.
+//   - Comment lines (starting with ":"), such as keep-alive pings, are skipped.
+//   - Events without data are not emitted.
+//   - Sentinels such as "[DONE]" are emitted as-is, so downstream stages can
+//     detect the end of the stream.
+//   - At EOF, a pending event with data is emitted even if the final blank line
+//     is missing.
+//
+// Since tokens only carry the payload, ScanSSE is typically combined with a
+// JSON carrier when the server sends one JSON object per event.
+func ScanSSE(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	// No data and nothing more to read.
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	var payload [][]byte
+	hasData := false
+	pos := 0
+	for pos < len(data) {
+		end := bytes.IndexAny(data[pos:], "\r\n")
+		if end < 0 {
+			// Incomplete line.
+			break
+		}
+		end += pos
+		next := end + 1
+		if data[end] == '\r' {
+			if next == len(data) && !atEOF {
+				// Could be the first half of "\r\n".
+				break
+			}
+			if next < len(data) && data[next] == '\n' {
+				next++
+			}
+		}
+		line := data[pos:end]
+		pos = next
+
+		if len(line) == 0 {
+			// Blank line: dispatch the event.
+			if !hasData {
+				// Nothing to emit (comments only): consume and go on.
+				return pos, nil, nil
+			}
+			return pos, bytes.Join(payload, []byte("\n")), nil
+		}
+		if value, ok := sseData(line); ok {
+			payload = append(payload, value)
+			hasData = true
+		}
+	}
+
+	if atEOF {
+		if pos < len(data) {
+			// Last line without terminator.
+			if value, ok := sseData(data[pos:]); ok {
+				payload = append(payload, value)
+				hasData = true
+			}
+		}
+		if hasData {
+			return len(data), bytes.Join(payload, []byte("\n")), nil
+		}
+		return len(data), nil, nil
+	}
+
+	// Request more data.
+	return 0, nil, nil
+}
+
+// sseData parses a non-blank SSE line and returns the value of a "data" field.
+//
+// ok is false for comments and for other fields ("event", "id", "retry" and
+// unknown fields are not part of the token).
+func sseData(line []byte) (value []byte, ok bool) {
+	if line[0] == ':' {
+		// Comment.
+		return nil, false
+	}
+	field := line
+	if i := bytes.IndexByte(line, ':'); i >= 0 {
+		field, value = line[:i], bytes.TrimPrefix(line[i+1:], []byte(" "))
+	}
+	if string(field) != "data" {
+		return nil, false
+	}
+	return value, true
+}
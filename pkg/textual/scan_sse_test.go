@@ -0,0 +1,46 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScanSSE_FramesEvents(t *testing.T) {
+	input := ": keep-alive\n\n" +
+		"event: response.output_text.delta\n" +
+		"id: 1\n" +
+		"data: {\"delta\":\"Hel\"}\n\n" +
+		"data: first line\r\n" +
+		"data:second line\r\n\r\n" +
+		": ping\n" +
+		"event: ignored-without-data\n\n" +
+		"data: [DONE]\n\n"
+
+	got := scanAll(t, input, ScanSSE)
+	want := []string{`{"delta":"Hel"}`, "first line\nsecond line", "[DONE]"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected tokens:\n got: %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestScanSSE_EmitsPendingEventAtEOF(t *testing.T) {
+	got := scanAll(t, "data: a\n\ndata: b\ndata: c", ScanSSE)
+	want := []string{"a", "b\nc"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected tokens:\n got: %#v\nwant: %#v", got, want)
+	}
+}
@@ -16,8 +16,7 @@ package textual
 
 import (
 	"bytes"
-	"fmt"
-	"io"
+	"unicode/utf8"
 )
 
 // ScanXML is a bufio.SplitFunc that tokenizes an input stream into top-level XML
@@ -29,6 +28,11 @@ import (
 //     This includes whitespace, XML declarations (`<?xml ...?>`), processing instructions,
 //     comments, or doctype declarations.
 //   - Nesting is tracked by pushing start element names and popping on matching end tags.
+//     Names are qualified names (`local` or `prefix:local`): an end tag must repeat the
+//     prefix and the local name of its start tag, as XML requires, so `<a:b></c:b>` is
+//     rejected even if both prefixes are bound to the same namespace. Namespace
+//     declarations are not resolved. A malformed qualified name (`<a:>`, `<a:b:c>`) is
+//     reported as an error.
 //   - The split func understands and skips:
 //   - comments:        <!-- ... -->
 //   - CDATA sections:  <![CDATA[ ... ]]>
@@ -36,7 +40,9 @@ import (
 //   - directives:      <! ... >   (doctype / declarations), with basic bracket/quote handling
 //   - The returned token begins at the '<' of the start element and ends right after the
 //     matching end tag (or the '/>' of a self-closing root element).
-//   - If atEOF is true and an element is still open, ScanXML returns io.ErrUnexpectedEOF.
+//   - If atEOF is true and an element is still open, ScanXML returns an error
+//     matching both ErrFraming and io.ErrUnexpectedEOF (errors.Is). Mismatched
+//     tags and invalid names are reported as ErrFraming too.
 //
 // This split func is a robust framing helper for streaming pipelines.
 // It does NOT aim to be a fully validating XML parser.
@@ -59,7 +65,11 @@ func ScanXML(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	if start == -1 {
 		// No element start found in the current buffer. Since we explicitly
 		// ignore leading noise, we can safely consume the whole buffer to
-		// avoid unbounded growth.
+		// avoid unbounded growth. A trailing '<' is kept: it may open the
+		// element once more data is read.
+		if !atEOF && data[len(data)-1] == '<' {
+			return len(data) - 1, nil, nil
+		}
 		return len(data), nil, nil
 	}
 
@@ -77,7 +87,7 @@ func ScanXML(data []byte, atEOF bool) (advance int, token []byte, err error) {
 		// We need at least one byte after '<'.
 		if i+1 >= len(data) {
 			if atEOF {
-				return 0, nil, io.ErrUnexpectedEOF
+				return 0, nil, errFramingEOF
 			}
 			if start > 0 {
 				return start, nil, nil
@@ -90,7 +100,7 @@ func ScanXML(data []byte, atEOF bool) (advance int, token []byte, err error) {
 			end, ok := indexAfter(data, i+len(xmlCommentOpen), xmlCommentClose)
 			if !ok {
 				if atEOF {
-					return 0, nil, io.ErrUnexpectedEOF
+					return 0, nil, errFramingEOF
 				}
 				if start > 0 {
 					return start, nil, nil
@@ -106,7 +116,7 @@ func ScanXML(data []byte, atEOF bool) (advance int, token []byte, err error) {
 			end, ok := indexAfter(data, i+len(xmlCDATAOpen), xmlCDATAClose)
 			if !ok {
 				if atEOF {
-					return 0, nil, io.ErrUnexpectedEOF
+					return 0, nil, errFramingEOF
 				}
 				if start > 0 {
 					return start, nil, nil
@@ -122,7 +132,7 @@ func ScanXML(data []byte, atEOF bool) (advance int, token []byte, err error) {
 			end, ok := indexAfter(data, i+2, xmlPIClose) // search after "<?"
 			if !ok {
 				if atEOF {
-					return 0, nil, io.ErrUnexpectedEOF
+					return 0, nil, errFramingEOF
 				}
 				if start > 0 {
 					return start, nil, nil
@@ -138,7 +148,7 @@ func ScanXML(data []byte, atEOF bool) (advance int, token []byte, err error) {
 			end, ok := scanDirectiveEnd(data, i+2) // after "<!"
 			if !ok {
 				if atEOF {
-					return 0, nil, io.ErrUnexpectedEOF
+					return 0, nil, errFramingEOF
 				}
 				if start > 0 {
 					return start, nil, nil
@@ -151,10 +161,13 @@ func ScanXML(data []byte, atEOF bool) (advance int, token []byte, err error) {
 
 		// 5) End tag: </name>
 		if data[i+1] == '/' {
-			name, nameEnd, ok := scanName(data, i+2)
+			name, nameEnd, ok, err := scanName(data, i+2)
+			if err != nil {
+				return 0, nil, err
+			}
 			if !ok {
 				if atEOF {
-					return 0, nil, io.ErrUnexpectedEOF
+					return 0, nil, errFramingEOF
 				}
 				if start > 0 {
 					return start, nil, nil
@@ -165,7 +178,7 @@ func ScanXML(data []byte, atEOF bool) (advance int, token []byte, err error) {
 			closeIdx, ok := scanTagClose(data, nameEnd)
 			if !ok {
 				if atEOF {
-					return 0, nil, io.ErrUnexpectedEOF
+					return 0, nil, errFramingEOF
 				}
 				if start > 0 {
 					return start, nil, nil
@@ -174,11 +187,11 @@ func ScanXML(data []byte, atEOF bool) (advance int, token []byte, err error) {
 			}
 
 			if len(stack) == 0 {
-				return 0, nil, fmt.Errorf("scanXML: unexpected closing tag </%s> at byte %d", name, i)
+				return 0, nil, framingErrorf("scanXML: unexpected closing tag </%s> at byte %d", name, i)
 			}
 			top := stack[len(stack)-1]
 			if top != name {
-				return 0, nil, fmt.Errorf("scanXML: mismatched closing tag </%s> for <%s> at byte %d", name, top, i)
+				return 0, nil, framingErrorf("scanXML: mismatched closing tag </%s> for <%s> at byte %d", name, top, i)
 			}
 			stack = stack[:len(stack)-1]
 
@@ -192,11 +205,25 @@ func ScanXML(data []byte, atEOF bool) (advance int, token []byte, err error) {
 		}
 
 		// 6) Start tag: <name ...> or <name .../>
-		if isXMLNameStart(data[i+1]) {
-			name, nameEnd, ok := scanName(data, i+1)
+		r, size := decodeXMLRune(data, i+1)
+		if size == 0 {
+			// The first rune of the name is split across reads.
+			if atEOF {
+				return 0, nil, errFramingEOF
+			}
+			if start > 0 {
+				return start, nil, nil
+			}
+			return 0, nil, nil
+		}
+		if isXMLNameStart(r) {
+			name, nameEnd, ok, err := scanName(data, i+1)
+			if err != nil {
+				return 0, nil, err
+			}
 			if !ok {
 				if atEOF {
-					return 0, nil, io.ErrUnexpectedEOF
+					return 0, nil, errFramingEOF
 				}
 				if start > 0 {
 					return start, nil, nil
@@ -207,7 +234,7 @@ func ScanXML(data []byte, atEOF bool) (advance int, token []byte, err error) {
 			closeIdx, selfClosing, ok := scanStartTagClose(data, nameEnd)
 			if !ok {
 				if atEOF {
-					return 0, nil, io.ErrUnexpectedEOF
+					return 0, nil, errFramingEOF
 				}
 				if start > 0 {
 					return start, nil, nil
@@ -239,7 +266,7 @@ func ScanXML(data []byte, atEOF bool) (advance int, token []byte, err error) {
 
 	// Buffer ended before we closed the root element.
 	if atEOF {
-		return 0, nil, io.ErrUnexpectedEOF
+		return 0, nil, errFramingEOF
 	}
 	if start > 0 {
 		return start, nil, nil
@@ -289,45 +316,121 @@ func findFirstStartElement(data []byte) int {
 		if i+1 >= len(data) {
 			return -1
 		}
-		n := data[i+1]
-		if n == '/' || n == '!' || n == '?' {
-			continue
-		}
-		if isXMLNameStart(n) {
+		r, size := decodeXMLRune(data, i+1)
+		if size == 0 || isXMLNameStart(r) {
+			// A rune split across reads may start a name: let ScanXML wait
+			// for more data.
 			return i
 		}
 	}
 	return -1
 }
 
-func isXMLNameStart(b byte) bool {
-	// XML NameStartChar is much broader (unicode), but for framing purposes
-	// we accept the common ASCII subset.
-	return (b >= 'A' && b <= 'Z') ||
-		(b >= 'a' && b <= 'z') ||
-		b == '_' || b == ':'
+// isXMLNameStart reports whether r is an XML 1.0 NameStartChar. The ':'
+// separating a prefix from the local name is handled by scanName.
+func isXMLNameStart(r rune) bool {
+	switch {
+	case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r == '_':
+		return true
+	case r < 0xC0:
+		return false
+	}
+	return (r <= 0xD6) ||
+		(r >= 0xD8 && r <= 0xF6) ||
+		(r >= 0xF8 && r <= 0x2FF) ||
+		(r >= 0x370 && r <= 0x37D) ||
+		(r >= 0x37F && r <= 0x1FFF) ||
+		(r >= 0x200C && r <= 0x200D) ||
+		(r >= 0x2070 && r <= 0x218F) ||
+		(r >= 0x2C00 && r <= 0x2FEF) ||
+		(r >= 0x3001 && r <= 0xD7FF) ||
+		(r >= 0xF900 && r <= 0xFDCF) ||
+		(r >= 0xFDF0 && r <= 0xFFFD) ||
+		(r >= 0x10000 && r <= 0xEFFFF)
 }
 
-func isXMLNameChar(b byte) bool {
-	return isXMLNameStart(b) ||
-		(b >= '0' && b <= '9') ||
-		b == '-' || b == '.'
+// isXMLNameChar reports whether r is an XML 1.0 NameChar (':' excepted).
+func isXMLNameChar(r rune) bool {
+	return isXMLNameStart(r) ||
+		(r >= '0' && r <= '9') ||
+		r == '-' || r == '.' || r == 0xB7 ||
+		(r >= 0x300 && r <= 0x36F) ||
+		(r >= 0x203F && r <= 0x2040)
 }
 
-// scanName parses an XML name starting at offset `from`.
-// It returns the parsed name and the index of the first byte after the name.
-func scanName(data []byte, from int) (name string, next int, ok bool) {
-	if from >= len(data) {
-		return "", 0, false
+// decodeXMLRune decodes the rune at data[i]. It returns size 0 when the rune
+// is incomplete and more data is needed; an invalid UTF-8 byte decodes as -1
+// (size 1), which is not a name character (unlike utf8.RuneError).
+func decodeXMLRune(data []byte, i int) (r rune, size int) {
+	if i >= len(data) || !utf8.FullRune(data[i:]) {
+		return -1, 0
 	}
-	i := from
-	for i < len(data) && isXMLNameChar(data[i]) {
-		i++
+	r, size = utf8.DecodeRune(data[i:])
+	if r == utf8.RuneError && size == 1 {
+		return -1, 1
 	}
+	return r, size
+}
+
+// scanName parses an XML qualified name (`local` or `prefix:local`) starting at
+// offset `from`. It returns the parsed name and the index of the first byte
+// after the name, ok=false if more data is needed, or an error when the name is
+// malformed (empty prefix or local name, more than one ':'). The error offset
+// is relative to data.
+func scanName(data []byte, from int) (name string, next int, ok bool, err error) {
+	// scanPart scans a name without ':'. It stops before an incomplete rune:
+	// the caller then waits for more data.
+	scanPart := func(at int) int {
+		r, size := decodeXMLRune(data, at)
+		if size == 0 || !isXMLNameStart(r) {
+			return at
+		}
+		i := at + size
+		for {
+			r, size = decodeXMLRune(data, i)
+			if size == 0 || !isXMLNameChar(r) {
+				return i
+			}
+			i += size
+		}
+	}
+	// invalid reports the malformed name once it is complete, so that the
+	// error quotes it whole; ok=false until then.
+	invalid := func(end int) (string, int, bool, error) {
+		for end < len(data) && data[end] != '>' && data[end] != '/' && !isXMLSpace(data[end]) {
+			end++
+		}
+		if end == len(data) {
+			return "", 0, false, nil
+		}
+		return "", 0, false, framingErrorf("scanXML: invalid qualified name %q at byte %d", data[from:end], from)
+	}
+
+	i := scanPart(from)
 	if i == from {
-		return "", 0, false
+		if from >= len(data) {
+			return "", 0, false, nil
+		}
+		return invalid(from)
 	}
-	return string(data[from:i]), i, true
+	if i < len(data) && data[i] == ':' {
+		local := i + 1
+		i = scanPart(local)
+		if i == local {
+			if local >= len(data) {
+				return "", 0, false, nil
+			}
+			return invalid(i)
+		}
+		if i < len(data) && data[i] == ':' {
+			return invalid(i)
+		}
+	}
+	return string(data[from:i]), i, true, nil
+}
+
+func isXMLSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
 }
 
 // scanTagClose scans forward until it finds '>' and returns its index.
@@ -0,0 +1,108 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// scanXMLTokens splits input with ScanXML through a small-buffered scanner, so
+// that names are also split across reads.
+func scanXMLTokens(input string) ([]string, error) {
+	scanner := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(input)))
+	scanner.Split(ScanXML)
+	var tokens []string
+	for scanner.Scan() {
+		tokens = append(tokens, scanner.Text())
+	}
+	return tokens, scanner.Err()
+}
+
+func TestScanXML_NamespacedElements(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"prefixed root", `<ns:root><ns:a>1</ns:a></ns:root>`, []string{`<ns:root><ns:a>1</ns:a></ns:root>`}},
+		{"mixed prefixes", `<a:b xmlns:a="u1"><c:b xmlns:c="u2"/><b>x</b></a:b>`, []string{`<a:b xmlns:a="u1"><c:b xmlns:c="u2"/><b>x</b></a:b>`}},
+		{"default namespace shift", `<b xmlns="u1"><a:b xmlns:a="u2"><b xmlns="u3"/></a:b></b>`, []string{`<b xmlns="u1"><a:b xmlns:a="u2"><b xmlns="u3"/></a:b></b>`}},
+		{"self-closing prefixed roots", `<?xml version="1.0"?><x:r a="1"/> <y:r/>`, []string{`<x:r a="1"/>`, `<y:r/>`}},
+		{"same local, sibling roots", `<a:item>1</a:item><c:item>2</c:item>`, []string{`<a:item>1</a:item>`, `<c:item>2</c:item>`}},
+		{"closing tag with spaces", `<p:x >v</p:x >`, []string{`<p:x >v</p:x >`}},
+	}
+	for _, c := range cases {
+		tokens, err := scanXMLTokens(c.input)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", c.name, err)
+		}
+		if len(tokens) != len(c.want) {
+			t.Fatalf("unexpected token count for %s: got %d want %d tokens=%#v", c.name, len(tokens), len(c.want), tokens)
+		}
+		for i := range c.want {
+			if tokens[i] != c.want[i] {
+				t.Fatalf("token %d mismatch for %s: got %q want %q", i, c.name, tokens[i], c.want[i])
+			}
+		}
+	}
+}
+
+func TestScanXML_RejectsMismatchedAndMalformedNames(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"different prefix", `<a:b>x</c:b>`, "mismatched closing tag </c:b> for <a:b>"},
+		{"missing prefix", `<a:b>x</b>`, "mismatched closing tag </b> for <a:b>"},
+		{"extra prefix", `<b>x</a:b>`, "mismatched closing tag </a:b> for <b>"},
+		{"empty local name", `<a:>x</a:>`, "invalid qualified name \"a:\""},
+		{"two colons", `<a:b:c/>`, "invalid qualified name \"a:b:c\""},
+		{"empty prefix in end tag", `<a>x</:a>`, "invalid qualified name \":a\""},
+	}
+	for _, c := range cases {
+		_, err := scanXMLTokens(c.input)
+		if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+			t.Fatalf("unexpected error for %s: got %v want %q", c.name, err, c.wantErr)
+		}
+	}
+}
+
+func TestScanXML_UnicodeElementNames(t *testing.T) {
+	input := "noise <café type=\"é\"><crème_brûlée>x</crème_brûlée><名前/></café> <données:résumé/>"
+	tokens, err := scanXMLTokens(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"<café type=\"é\"><crème_brûlée>x</crème_brûlée><名前/></café>",
+		"<données:résumé/>",
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("unexpected token count: got %d want %d tokens=%#v", len(tokens), len(want), tokens)
+	}
+	for i := range want {
+		if tokens[i] != want[i] {
+			t.Fatalf("token %d mismatch: got %q want %q", i, tokens[i], want[i])
+		}
+	}
+
+	if _, err := scanXMLTokens("<café>x</cafe>"); err == nil || !strings.Contains(err.Error(), "mismatched closing tag </cafe> for <café>") {
+		t.Fatalf("unexpected error for a mismatched unicode name: %v", err)
+	}
+}
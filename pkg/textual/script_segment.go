@@ -0,0 +1,105 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"sort"
+	"unicode"
+)
+
+// NewScriptSegment returns a Transcoder that splits the text of each item into
+// runs of a single Unicode script (Latin, Cyrillic, Han, Arabic, ...).
+//
+// Each run becomes a Fragment of the resulting Parcel:
+//
+//   - Transformed is the run text itself, so UTF8String renders the original
+//     text unchanged,
+//   - Label is the script name, as found in unicode.Scripts ("Latin", "Han", ...),
+//   - Confidence is 1.
+//
+// Characters shared between scripts (spaces, punctuation, digits: script
+// "Common") and combining marks ("Inherited") are attached to the current run,
+// or to the first run when they lead the text. A text made only of such
+// characters produces a single "Common" run.
+//
+// The Parcel keeps the index and the per-item error of the input item.
+func NewScriptSegment[S Carrier[S]]() TranscoderFunc[S, Parcel] {
+	return NewTranscoderFunc(func(_ context.Context, item S) Parcel {
+		text := item.UTF8String()
+		p := ParcelFrom(text).WithIndex(item.GetIndex()).WithError(item.GetError())
+
+		runes := []rune(string(text))
+		start, script := 0, ""
+		for i, r := range runes {
+			name := scriptOf(r)
+			if name == "Common" || name == "Inherited" || name == script {
+				continue
+			}
+			if script == "" {
+				// Leading shared characters join the first run.
+				script = name
+				continue
+			}
+			p.Fragments = append(p.Fragments, scriptFragment(runes, start, i, script))
+			start, script = i, name
+		}
+		if len(runes) > 0 {
+			if script == "" {
+				script = "Common"
+			}
+			p.Fragments = append(p.Fragments, scriptFragment(runes, start, len(runes), script))
+		}
+		return p
+	})
+}
+
+func scriptFragment(runes []rune, start, end int, script string) Fragment {
+	return Fragment{
+		Transformed: UTF8String(runes[start:end]),
+		Pos:         start,
+		Len:         end - start,
+		Confidence:  1,
+		Label:       script,
+	}
+}
+
+// scriptNames lists unicode.Scripts keys, the most frequent scripts first so
+// that the common cases are resolved quickly.
+var scriptNames = func() []string {
+	first := []string{"Latin", "Common", "Inherited", "Han", "Cyrillic", "Arabic", "Greek", "Hebrew", "Hiragana", "Katakana", "Hangul", "Devanagari", "Thai"}
+	seen := make(map[string]bool, len(first))
+	for _, n := range first {
+		seen[n] = true
+	}
+	rest := make([]string, 0, len(unicode.Scripts))
+	for n := range unicode.Scripts {
+		if !seen[n] {
+			rest = append(rest, n)
+		}
+	}
+	sort.Strings(rest)
+	return append(first, rest...)
+}()
+
+// scriptOf returns the Unicode script of r, or "Common" when it is unknown.
+func scriptOf(r rune) string {
+	for _, name := range scriptNames {
+		if unicode.Is(unicode.Scripts[name], r) {
+			return name
+		}
+	}
+	return "Common"
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewScriptSegment_LatinAndHan(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "« Hello 世界! » ok", Index: 0}
+	in <- StringCarrier{Value: "123 !", Index: 1}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewScriptSegment[StringCarrier]().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	sortByIndex(items)
+	if len(items) != 2 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 2)
+	}
+
+	type run struct {
+		text   UTF8String
+		pos    int
+		script string
+	}
+	want := []run{{"« Hello ", 0, "Latin"}, {"世界! » ", 8, "Han"}, {"ok", 14, "Latin"}}
+	p := items[0]
+	if len(p.Fragments) != len(want) {
+		t.Fatalf("unexpected fragments: %#v", p.Fragments)
+	}
+	for i, f := range p.Fragments {
+		got := run{f.Transformed, f.Pos, f.Label}
+		if got != want[i] {
+			t.Fatalf("unexpected run %d: got %+v want %+v", i, got, want[i])
+		}
+	}
+	if p.UTF8String() != "« Hello 世界! » ok" {
+		t.Fatalf("rendering should be unchanged, got %q", p.UTF8String())
+	}
+
+	if f := items[1].Fragments; len(f) != 1 || f[0].Label != "Common" {
+		t.Fatalf("unexpected fragments for a script-less text: %#v", f)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// NewSentenceParcels returns a Transcoder that splits the text of each item
+// into sentences and emits one Parcel per sentence, for sentence-level
+// processing that can be aggregated back afterwards.
+//
+// Each Parcel has:
+//
+//   - Text: the sentence, trimmed of surrounding white space,
+//   - Fragments: empty; fragments added downstream use rune positions relative
+//     to the sentence, not to the input item,
+//   - Index: the sentence number in the stream (0, 1, 2, ...), so the
+//     sentences sort back in their original order,
+//   - Error: the per-item error of the input item, if any.
+//
+// A sentence ends with a terminator ('.', '!', '?', '…' or their CJK
+// counterparts '。', '！', '？'), possibly followed by closing quotes or
+// brackets, then white space or the end of the text. Trailing text without a
+// terminator is a sentence of its own. Sentences never span input items: feed
+// whole paragraphs or documents, not arbitrary chunks.
+func NewSentenceParcels() TranscoderFunc[StringCarrier, Parcel] {
+	return func(ctx context.Context, in <-chan StringCarrier) <-chan Parcel {
+		n := 0
+		return AsyncEmitter(ctx, in, func(_ context.Context, item StringCarrier, emit func(Parcel)) {
+			for _, sentence := range splitSentences(item.Value) {
+				emit(ParcelFrom(UTF8String(sentence)).WithIndex(n).WithError(item.Error))
+				n++
+			}
+		})
+	}
+}
+
+// splitSentences splits s into trimmed, non-empty sentences.
+func splitSentences(s string) []string {
+	var sentences []string
+	runes := []rune(s)
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		if !isSentenceTerminator(runes[i]) {
+			continue
+		}
+		// Consume repeated terminators ("?!", "...") and closing punctuation.
+		end := i + 1
+		for end < len(runes) && (isSentenceTerminator(runes[end]) || isSentenceCloser(runes[end])) {
+			end++
+		}
+		if end < len(runes) && !unicode.IsSpace(runes[end]) && !isCJKTerminator(runes[i]) {
+			// "3.14", "e.g.x": not a sentence boundary.
+			i = end - 1
+			continue
+		}
+		if sentence := strings.TrimSpace(string(runes[start:end])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start, i = end, end-1
+	}
+	if sentence := strings.TrimSpace(string(runes[start:])); sentence != "" {
+		sentences = append(sentences, sentence)
+	}
+	return sentences
+}
+
+func isSentenceTerminator(r rune) bool {
+	switch r {
+	case '.', '!', '?', '…':
+		return true
+	}
+	return isCJKTerminator(r)
+}
+
+// isCJKTerminator reports whether r is a full-width terminator, which is not
+// followed by a space in CJK texts.
+func isCJKTerminator(r rune) bool {
+	switch r {
+	case '。', '！', '？':
+		return true
+	}
+	return false
+}
+
+func isSentenceCloser(r rune) bool {
+	switch r {
+	case '"', '\'', ')', ']', '»', '”', '’', '」', '』':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestSentenceParcels_RelativePositions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	errBoom := errors.New("boom")
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "Héllo wörld. Pi is 3.14, right?  He said \"stop!\" Then left", Index: 0}
+	in <- StringCarrier{Value: "Second item.", Index: 1, Error: errBoom}
+	close(in)
+
+	out, err := collectWithContext(ctx, NewSentenceParcels().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"Héllo wörld.", "Pi is 3.14, right?", "He said \"stop!\"", "Then left", "Second item."}
+	if len(out) != len(want) {
+		t.Fatalf("unexpected sentence count: got %d want %d (%v)", len(out), len(want), out)
+	}
+	for i, p := range out {
+		if string(p.Text) != want[i] || p.Index != i || len(p.Fragments) != 0 {
+			t.Fatalf("unexpected parcel %d: got %q (index %d, %d fragments) want %q", i, p.Text, p.Index, len(p.Fragments), want[i])
+		}
+	}
+	if out[3].Error != nil || !errors.Is(out[4].Error, errBoom) {
+		t.Fatalf("unexpected errors: got %v, %v", out[3].Error, out[4].Error)
+	}
+
+	// Fragment positions are relative to the sentence.
+	p := out[0]
+	p.Fragments = append(p.Fragments, Fragment{Transformed: "WORLD", Pos: 6, Len: utf8.RuneCountInString("wörld")})
+	if err := p.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	if got := string(p.UTF8String()); got != "Héllo WORLD." {
+		t.Fatalf("unexpected rendering: got %q want %q", got, "Héllo WORLD.")
+	}
+}
+
+func TestSplitSentences_CJK(t *testing.T) {
+	got := splitSentences("今天很好。你呢？好！")
+	want := []string{"今天很好。", "你呢？", "好！"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected sentence count: got %d want %d (%q)", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected sentence %d: got %q want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// NewShuffleWindow returns a Processor that shuffles items within a bounded
+// window, without buffering the whole stream.
+//
+// The stage keeps a reservoir of up to window items. Once the reservoir is
+// full, every new item replaces a randomly chosen one, which is emitted. When
+// the input is closed, the remaining items are emitted in random order. They
+// are dropped when ctx is canceled.
+//
+// An item can therefore move backwards by at most window-1 positions, while it
+// may be delayed arbitrarily. Items are not modified: their Index is preserved,
+// so the original order can be restored downstream by sorting on GetIndex().
+//
+// src drives the random choices. Passing a seeded source (rand.NewSource(42))
+// makes the output deterministic, which is useful in tests. When src is nil, a
+// time-seeded source is used. The source is shared by every Apply call and
+// guarded by a mutex.
+//
+// When window <= 1, items are passed through unchanged.
+func NewShuffleWindow[S Carrier[S]](window int, src rand.Source) ProcessorFunc[S] {
+	if window <= 1 {
+		return passThroughProcessor[S]()
+	}
+	if src == nil {
+		src = rand.NewSource(time.Now().UnixNano())
+	}
+	rnd := rand.New(src)
+	var mu sync.Mutex
+	intn := func(n int) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return rnd.Intn(n)
+	}
+
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		reservoir := make([]S, 0, window)
+
+		return asyncEmitter(ctx, in, func(ctx context.Context, item S, emit func(S)) {
+			if len(reservoir) < window {
+				reservoir = append(reservoir, item)
+				return
+			}
+			j := intn(window)
+			emit(reservoir[j])
+			reservoir[j] = item
+		}, func(ctx context.Context, emit func(S)) {
+			for len(reservoir) > 0 {
+				j := intn(len(reservoir))
+				emit(reservoir[j])
+				last := len(reservoir) - 1
+				reservoir[j] = reservoir[last]
+				reservoir = reservoir[:last]
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func shuffleIndexes(t *testing.T, n, window int, seed int64) []int {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, n)
+	for i := 0; i < n; i++ {
+		in <- StringCarrier{Value: strconv.Itoa(i), Index: i}
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewShuffleWindow[StringCarrier](window, rand.NewSource(seed)).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	indexes := make([]int, len(items))
+	for i, it := range items {
+		if it.Value != strconv.Itoa(it.Index) {
+			t.Fatalf("item was modified: %#v", it)
+		}
+		indexes[i] = it.Index
+	}
+	return indexes
+}
+
+func TestNewShuffleWindow_EmitsEveryItemOnceInADifferentOrder(t *testing.T) {
+	const n, window = 50, 8
+	got := shuffleIndexes(t, n, window, 42)
+
+	if len(got) != n {
+		t.Fatalf("unexpected output count: got %d want %d", len(got), n)
+	}
+	seen := make(map[int]int, n)
+	ordered := true
+	for i, idx := range got {
+		seen[idx]++
+		if idx != i {
+			ordered = false
+		}
+		// An item can only move backwards by less than window positions.
+		if i < idx-(window-1) {
+			t.Fatalf("item %d emitted too early at position %d", idx, i)
+		}
+	}
+	for i := 0; i < n; i++ {
+		if seen[i] != 1 {
+			t.Fatalf("item %d seen %d times", i, seen[i])
+		}
+	}
+	if ordered {
+		t.Fatalf("expected the order to differ from the input order")
+	}
+}
+
+func TestNewShuffleWindow_IsDeterministicForASeed(t *testing.T) {
+	a := shuffleIndexes(t, 30, 5, 7)
+	b := shuffleIndexes(t, 30, 5, 7)
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("expected identical orders for the same seed:\n a: %v\n b: %v", a, b)
+	}
+}
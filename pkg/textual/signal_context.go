@@ -0,0 +1,54 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// NewSignalContext returns a context canceled when one of signals is received,
+// plus a function releasing the signal registration (see signal.NotifyContext).
+// With no signals, it listens to os.Interrupt and SIGTERM, the usual shutdown
+// requests of a service.
+//
+// To shut down gracefully, do not use the signal context as the pipeline
+// context: canceling it would abort the items in flight. Watch it instead and
+// Drain the IO adapter, so it stops reading and lets queued items finish
+// within a grace period:
+//
+//	sigCtx, stop := NewSignalContext()
+//	defer stop()
+//
+//	p := NewIOReaderProcessor(myProcessor, reader)
+//	p.SetContext(context.Background())
+//	out := p.Start()
+//
+//	go func() {
+//	    <-sigCtx.Done()
+//	    p.Drain(5 * time.Second) // grace period
+//	}()
+//
+//	for item := range out {
+//	    // write item
+//	}
+func NewSignalContext(signals ...os.Signal) (context.Context, context.CancelFunc) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	return signal.NotifyContext(context.Background(), signals...)
+}
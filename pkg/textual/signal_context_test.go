@@ -0,0 +1,90 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestNewSignalContext_CanceledOnSignal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sending signals to the current process is not supported on windows")
+	}
+	sigCtx, stop := NewSignalContext(os.Interrupt)
+	defer stop()
+
+	self, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := self.Signal(os.Interrupt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case <-sigCtx.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatalf("unexpected: signal context not canceled")
+	}
+}
+
+func TestNewSignalContext_CancelThenDrain(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	sigCtx, stop := NewSignalContext()
+	defer stop()
+
+	slow := ProcessorFunc[StringCarrier](func(ctx context.Context, in <-chan StringCarrier) <-chan StringCarrier {
+		return Async(ctx, in, func(ctx context.Context, s StringCarrier) StringCarrier {
+			time.Sleep(time.Millisecond)
+			return s
+		})
+	})
+	p := NewIOReaderProcessor[StringCarrier](slow, endlessLines{})
+	p.SetContext(ctx)
+	out := p.Start()
+
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		<-sigCtx.Done()
+		p.Drain(time.Second)
+	}()
+
+	// Simulate the shutdown request once a few items went through.
+	count := 0
+	for item := range out {
+		if item.Index != count {
+			t.Fatalf("unexpected index: got %d want %d", item.Index, count)
+		}
+		count++
+		if count == 5 {
+			stop()
+		}
+	}
+	<-drained
+
+	if count < 5 {
+		t.Fatalf("unexpected output count: got %d want at least %d", count, 5)
+	}
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
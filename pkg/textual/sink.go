@@ -0,0 +1,134 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"io"
+	"sort"
+)
+
+// WriteSink drains out into w, writing the UTF8String() of each item followed
+// by sep. It is the counterpart of IOReaderProcessor / IOReaderTranscoder at the
+// end of a pipeline, and returns once out is closed.
+//
+// Items are written in arrival order. On the first write error, WriteSink stops
+// writing, drains out so that upstream stages are never blocked on send, and
+// returns the error. When ctx is canceled, it returns ctx.Err() right away and
+// keeps draining out in the background. Item errors are not reported: filter
+// or inspect them upstream (see NewErrorReport).
+func WriteSink[S Carrier[S]](ctx context.Context, out <-chan S, w io.Writer, sep string) error {
+	return writeSink(ctx, out, func(item S) error {
+		return writeSinkItem(w, item, sep)
+	}, nil)
+}
+
+// WriteSinkOrdered is WriteSink writing items by increasing Index.
+//
+// Items are buffered until the next expected index (starting at 0) has been
+// written, so a stream produced out of order (Router, per-key stages, ...) is
+// restored as long as its indices are contiguous. Items whose index is missing
+// from the sequence (filtered out, re-indexed, ...) are written sorted once out
+// is closed; this buffers the rest of the stream in memory. Items sharing an
+// index keep their arrival order.
+func WriteSinkOrdered[S Carrier[S]](ctx context.Context, out <-chan S, w io.Writer, sep string) error {
+	pending := make(map[int][]S)
+	next := 0
+	write := func(item S) error {
+		if idx := item.GetIndex(); idx != next {
+			if idx < next {
+				// Late duplicate or negative index: its slot has passed.
+				return writeSinkItem(w, item, sep)
+			}
+			pending[idx] = append(pending[idx], item)
+			return nil
+		}
+		if err := writeSinkItem(w, item, sep); err != nil {
+			return err
+		}
+		next++
+		for items, ok := pending[next]; ok; items, ok = pending[next] {
+			delete(pending, next)
+			for _, it := range items {
+				if err := writeSinkItem(w, it, sep); err != nil {
+					return err
+				}
+			}
+			next++
+		}
+		return nil
+	}
+	flush := func() error {
+		indices := make([]int, 0, len(pending))
+		for idx := range pending {
+			indices = append(indices, idx)
+		}
+		sort.Ints(indices)
+		for _, idx := range indices {
+			for _, it := range pending[idx] {
+				if err := writeSinkItem(w, it, sep); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return writeSink(ctx, out, write, flush)
+}
+
+// writeSink implements WriteSink and WriteSinkOrdered: write is called for each
+// item, then flush (when non-nil) once out is closed.
+func writeSink[S Carrier[S]](ctx context.Context, out <-chan S, write func(S) error, flush func() error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// drain keeps consuming out in the background, so that upstream stages are
+	// not blocked on send, and the error is returned right away.
+	drain := func() {
+		go func() {
+			for range out {
+			}
+		}()
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			drain()
+			return ctx.Err()
+		case item, ok := <-out:
+			if !ok {
+				if flush != nil {
+					return flush()
+				}
+				return nil
+			}
+			if err := write(item); err != nil {
+				drain()
+				return err
+			}
+		}
+	}
+}
+
+func writeSinkItem[S Carrier[S]](w io.Writer, item S, sep string) error {
+	if _, err := io.WriteString(w, item.UTF8String()); err != nil {
+		return err
+	}
+	if sep == "" {
+		return nil
+	}
+	_, err := io.WriteString(w, sep)
+	return err
+}
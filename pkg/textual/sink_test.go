@@ -0,0 +1,131 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func sinkInput(items ...StringCarrier) <-chan StringCarrier {
+	in := make(chan StringCarrier)
+	go func() {
+		defer close(in)
+		for _, item := range items {
+			in <- item
+		}
+	}()
+	return in
+}
+
+func TestWriteSink_WritesWithSeparator(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	out := MapString(func(s string) string { return "<" + s + ">" }).Apply(ctx, sinkInput(
+		StringCarrier{Value: "a", Index: 0},
+		StringCarrier{Value: "b", Index: 1},
+	))
+	var buf bytes.Buffer
+	if err := WriteSink(ctx, out, &buf, "\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := buf.String(), "<a>\n<b>\n"; got != want {
+		t.Fatalf("unexpected output: got %q want %q", got, want)
+	}
+}
+
+func TestWriteSinkOrdered_RestoresIndexOrder(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	out := sinkInput(
+		StringCarrier{Value: "c", Index: 2},
+		StringCarrier{Value: "a", Index: 0},
+		StringCarrier{Value: "f", Index: 5}, // 3 and 4 never arrive
+		StringCarrier{Value: "b", Index: 1},
+		StringCarrier{Value: "g", Index: 6},
+	)
+	var buf bytes.Buffer
+	if err := WriteSinkOrdered(ctx, out, &buf, ","); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := buf.String(), "a,b,c,f,g,"; got != want {
+		t.Fatalf("unexpected output: got %q want %q", got, want)
+	}
+}
+
+// failingWriter fails every write after the first n bytes.
+type failingWriter struct{ n int }
+
+var errSinkFull = errors.New("sink full")
+
+func (w *failingWriter) Write(b []byte) (int, error) {
+	if len(b) > w.n {
+		return 0, errSinkFull
+	}
+	w.n -= len(b)
+	return len(b), nil
+}
+
+func TestWriteSink_ReturnsFirstErrorAndDrains(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer close(in)
+		for i := 0; i < 10; i++ {
+			in <- StringCarrier{Value: "x", Index: i}
+		}
+	}()
+
+	if err := WriteSink(ctx, in, &failingWriter{n: 3}, "\n"); !errors.Is(err, errSinkFull) {
+		t.Fatalf("unexpected error: got %v want %v", err, errSinkFull)
+	}
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Fatalf("unexpected: upstream blocked after a write error")
+	}
+}
+
+func TestWriteSink_ReturnsErrorWithEndlessUpstream(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier)
+	go func() {
+		for i := 0; ctx.Err() == nil; i++ {
+			select {
+			case in <- StringCarrier{Value: "x", Index: i}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	err := WriteSink(ctx, in, &failingWriter{n: 3}, "\n")
+	if !errors.Is(err, errSinkFull) {
+		t.Fatalf("unexpected error: got %v want %v", err, errSinkFull)
+	}
+	if ctx.Err() != nil {
+		t.Fatalf("WriteSink returned only after the context deadline")
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// NewSpellCheck returns a Processor that flags unknown words of a Parcel and
+// proposes corrections as Fragments.
+//
+// Words are runs of letters (and combining marks) found in Parcel.Text. A word
+// is known when it belongs to dict, as is or lower-cased. For each unknown
+// word, suggest(word) is called and every suggestion becomes a Fragment over
+// the word span:
+//
+//   - the first suggestion is Variant 0 (the one rendered by UTF8String),
+//   - the following suggestions are Variants 1, 2, ...,
+//   - Confidence decreases with the rank: 1/(rank+1).
+//
+// Known words, and unknown words without suggestions, stay raw. Existing
+// fragments are kept; new fragments are appended after them.
+//
+// If suggest is nil, unknown words are left raw.
+func NewSpellCheck(dict map[string]struct{}, suggest func(word string) []string) ProcessorFunc[Parcel] {
+	known := func(w string) bool {
+		if _, ok := dict[w]; ok {
+			return true
+		}
+		_, ok := dict[strings.ToLower(w)]
+		return ok
+	}
+	return NewProcessorFunc(func(_ context.Context, p Parcel) Parcel {
+		if suggest == nil {
+			return p
+		}
+		runes := []rune(string(p.Text))
+		fragments := append(make([]Fragment, 0, len(p.Fragments)), p.Fragments...)
+		for i := 0; i < len(runes); {
+			if !isWordRune(runes[i]) {
+				i++
+				continue
+			}
+			start := i
+			for i < len(runes) && isWordRune(runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+			if known(word) {
+				continue
+			}
+			for rank, s := range suggest(word) {
+				fragments = append(fragments, Fragment{
+					Transformed: UTF8String(s),
+					Pos:         start,
+					Len:         i - start,
+					Confidence:  1 / float64(rank+1),
+					Variant:     rank,
+				})
+			}
+		}
+		p.Fragments = fragments
+		return p
+	})
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.Is(unicode.Mn, r)
+}
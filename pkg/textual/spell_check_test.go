@@ -0,0 +1,83 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewSpellCheck_SuggestsCorrectionsForUnknownWords(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	dict := map[string]struct{}{"le": {}, "chat": {}, "dort": {}}
+	suggest := func(word string) []string {
+		if word == "chta" {
+			return []string{"chat", "chta"}
+		}
+		return nil
+	}
+
+	in := make(chan Parcel, 1)
+	in <- ParcelFrom("Le chta dort.").WithIndex(0)
+	close(in)
+
+	items, err := collectWithContext(ctx, NewSpellCheck(dict, suggest).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 1)
+	}
+	p := items[0]
+	if got, want := p.UTF8String(), UTF8String("Le chat dort."); got != want {
+		t.Fatalf("unexpected correction: got %q want %q", got, want)
+	}
+	if len(p.Fragments) != 2 {
+		t.Fatalf("unexpected fragments: %#v", p.Fragments)
+	}
+	top, alt := p.Fragments[0], p.Fragments[1]
+	if top.Pos != 3 || top.Len != 4 || top.Variant != 0 || top.Confidence != 1 {
+		t.Fatalf("unexpected top suggestion: %#v", top)
+	}
+	if alt.Transformed != "chta" || alt.Variant != 1 || alt.Confidence >= top.Confidence {
+		t.Fatalf("unexpected alternative: %#v", alt)
+	}
+}
+
+func TestNewSpellCheck_KeepsKnownWordsRaw(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	called := false
+	suggest := func(string) []string {
+		called = true
+		return []string{"x"}
+	}
+
+	in := make(chan Parcel, 1)
+	in <- ParcelFrom("Chat").WithIndex(0)
+	close(in)
+
+	items, err := collectWithContext(ctx, NewSpellCheck(map[string]struct{}{"chat": {}}, suggest).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if called || len(items) != 1 || len(items[0].Fragments) != 0 || items[0].UTF8String() != "Chat" {
+		t.Fatalf("unexpected output: %#v (suggest called=%v)", items, called)
+	}
+}
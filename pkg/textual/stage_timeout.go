@@ -0,0 +1,85 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"time"
+)
+
+// WithStageTimeout bounds the whole processing of the stream by inner.
+//
+// inner is applied with a context derived from ctx with a d timeout. Once d has
+// elapsed, that context is canceled and the output is closed, even if inner has
+// not finished draining. This is useful to bound buffering stages (an
+// Aggregator, a Window, ...) that only emit at end of stream.
+//
+// After the deadline, the outputs of inner and the remaining input are drained
+// and discarded, until they are closed or ctx is canceled, so that upstream
+// stages and inner never block on send. Since Go
+// cannot stop a goroutine from the outside, inner must watch its context to
+// actually stop working.
+//
+// This is distinct from AsyncTimeout, which bounds every item. When d <= 0,
+// inner is returned as is; a nil inner is a pass-through.
+func WithStageTimeout[S Carrier[S]](d time.Duration, inner Processor[S]) Processor[S] {
+	if inner == nil {
+		return IdentityProcessor[S]()
+	}
+	if d <= 0 {
+		return inner
+	}
+	return ProcessorFunc[S](func(ctx context.Context, in <-chan S) <-chan S {
+		ctx, ps := EnsurePanicStore(ctx)
+		stageCtx, cancel := context.WithTimeout(ctx, d)
+
+		innerOut, _ := safeApplyProcessor(stageCtx, ps, inner, in)
+		out := make(chan S)
+		go func() {
+			defer close(out)
+			defer cancel()
+			for {
+				select {
+				case <-stageCtx.Done():
+					discardOnDeadline(ctx, ps, in, innerOut)
+					return
+				case item, ok := <-innerOut:
+					if !ok {
+						return
+					}
+					select {
+					case out <- item:
+					case <-stageCtx.Done():
+						discardOnDeadline(ctx, ps, in, innerOut)
+						return
+					}
+				}
+			}
+		}()
+		return out
+	})
+}
+
+// discardOnDeadline drains in and innerOut in the background once a stage
+// deadline has elapsed, until they are closed or the parent ctx is canceled.
+// Nothing is drained when the parent ctx is already canceled: every stage
+// stops on its own in that case.
+func discardOnDeadline[S any](ctx context.Context, ps *PanicStore, in, innerOut <-chan S) {
+	if ctx.Err() != nil {
+		return
+	}
+	drainInBackground(ctx, ps, innerOut)
+	drainInBackground(ctx, ps, in)
+}
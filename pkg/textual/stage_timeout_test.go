@@ -0,0 +1,108 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithStageTimeout_InnerNeverCloses(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// inner forwards the first item, then never closes its output.
+	inner := ProcessorFunc[StringCarrier](func(_ context.Context, in <-chan StringCarrier) <-chan StringCarrier {
+		out := make(chan StringCarrier)
+		go func() {
+			out <- <-in
+		}()
+		return out
+	})
+	stage := WithStageTimeout[StringCarrier](50*time.Millisecond, inner)
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "a", Index: 0}
+	in <- StringCarrier{Value: "b", Index: 1}
+
+	start := time.Now()
+	items, err := collectWithContext(ctx, stage.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	elapsed := time.Since(start)
+	if elapsed < 50*time.Millisecond {
+		t.Fatalf("stage terminated before its deadline: %v", elapsed)
+	}
+	if len(items) != 1 || items[0].Value != "a" {
+		t.Fatalf("unexpected output: got %+v", items)
+	}
+	close(in)
+}
+
+func TestWithStageTimeout_CompletesInTime(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	stage := WithStageTimeout[StringCarrier](time.Second, procSuffix("!"))
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "a", Index: 0}
+	in <- StringCarrier{Value: "b", Index: 1}
+	close(in)
+
+	items, err := collectWithContext(ctx, stage.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 2)
+	}
+	for i, want := range []string{"a!", "b!"} {
+		if got := items[i].Value; got != want {
+			t.Fatalf("unexpected item %d: got %q want %q", i, got, want)
+		}
+	}
+}
+
+func TestWithStageTimeout_DrainStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// inner neither reads its input nor closes its output.
+	inner := ProcessorFunc[StringCarrier](func(context.Context, <-chan StringCarrier) <-chan StringCarrier {
+		return make(chan StringCarrier)
+	})
+	in := make(chan StringCarrier) // never closed
+	out := WithStageTimeout[StringCarrier](10*time.Millisecond, inner).Apply(ctx, in)
+	if _, ok := <-out; ok {
+		t.Fatalf("expected the output to be closed after the deadline")
+	}
+
+	// The input is drained after the deadline, until ctx is canceled.
+	in <- StringCarrier{Value: "a"}
+	cancel()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case in <- StringCarrier{Value: "b"}:
+		case <-time.After(50 * time.Millisecond):
+			return // nobody is reading anymore
+		case <-deadline:
+			t.Fatalf("input still drained after cancellation")
+		}
+	}
+}
@@ -16,6 +16,7 @@ package textual
 
 import (
 	"errors"
+	"strings"
 )
 
 // StringCarrier is a simple Carrier implementation.
@@ -71,3 +72,19 @@ func (s StringCarrier) WithError(err error) StringCarrier {
 func (s StringCarrier) GetError() error {
 	return s.Error
 }
+
+// Aggregate concatenates the values of items after stably sorting them by Index.
+//
+// The result carries the first index and the joined per-item errors.
+func (s StringCarrier) Aggregate(items []StringCarrier) StringCarrier {
+	if len(items) == 0 {
+		return StringCarrier{}
+	}
+	sorted := sortedByIndex(items)
+	var b strings.Builder
+	for _, it := range sorted {
+		b.WriteString(it.Value)
+	}
+	res := StringCarrier{Value: UTF8String(b.String()), Index: sorted[0].Index}
+	return withJoinedErrors(res, sorted)
+}
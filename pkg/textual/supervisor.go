@@ -0,0 +1,132 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+)
+
+// NewSupervisor returns a Processor running independent children with
+// fail-fast semantics (structured concurrency): every input item is broadcast
+// to all children, and their outputs are merged.
+//
+// Each child runs with its own PanicStore. As soon as a child records a fault
+// (detected when its output closes, or when it emits an item), the supervisor:
+//
+//   - copies the first fault into its own PanicStore (the one of ctx, or an
+//     internal one), so the caller checks a single store at the boundary,
+//   - cancels the context of every child,
+//   - stops reading from in, drains the children outputs and closes the
+//     returned channel.
+//
+// Without fault, the output is closed once in is closed and every child has
+// terminated. The merge order across children is not deterministic. Items are
+// broadcast by value: children must not mutate shared state such as Parcel
+// fragments in place.
+//
+// With no child, items are passed through unchanged. Nil children pass items
+// through.
+func NewSupervisor[S Carrier[S]](children ...Processor[S]) ProcessorFunc[S] {
+	if len(children) == 0 {
+		return passThroughProcessor[S]()
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		ctx, ps := EnsurePanicStore(ctx)
+		ctx, cancel := context.WithCancel(ctx)
+
+		// escalate surfaces the fault of a child, if any, and aborts everything.
+		escalate := func(cps *PanicStore) bool {
+			info, faulted := cps.Load()
+			if faulted {
+				ps.Store(info.Value, info.Stack)
+				cancel()
+			}
+			return faulted
+		}
+
+		out := make(chan S)
+		ins := make([]chan S, len(children))
+		var wg sync.WaitGroup
+		for i, child := range children {
+			childCtx, cps := WithPanicStore(ctx)
+			ins[i] = make(chan S)
+			childOut, _ := safeApplyProcessor(childCtx, cps, child, ins[i])
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer escalate(cps)
+				forwardSupervised(ctx, childOut, out, func() bool { return escalate(cps) })
+				// Drain so that the child is never blocked on send.
+				for range childOut {
+				}
+			}()
+		}
+
+		// Broadcast.
+		go func() {
+			defer func() {
+				for _, ch := range ins {
+					safeCloseChan(ps, ch)
+				}
+				wg.Wait()
+				close(out)
+				cancel()
+			}()
+			defer func() {
+				if rcv := recover(); rcv != nil {
+					ps.Store(rcv, debug.Stack())
+					cancel()
+				}
+			}()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-in:
+					if !ok {
+						return
+					}
+					for _, ch := range ins {
+						select {
+						case <-ctx.Done():
+							return
+						case ch <- item:
+						}
+					}
+				}
+			}
+		}()
+		return out
+	}
+}
+
+// forwardSupervised forwards items from ch to out until ch is closed, ctx is
+// canceled or faulted reports a fault.
+func forwardSupervised[S any](ctx context.Context, ch <-chan S, out chan<- S, faulted func() bool) {
+	for item := range ch {
+		if faulted() {
+			return
+		}
+		select {
+		case out <- item:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
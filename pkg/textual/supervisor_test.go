@@ -0,0 +1,98 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewSupervisor_MergesChildren(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ctx, ps := WithPanicStore(ctx)
+
+	upper := NewProcessorFunc(func(_ context.Context, s StringCarrier) StringCarrier {
+		s.Value = strings.ToUpper(s.Value)
+		return s
+	})
+	identity := NewProcessorFunc(func(_ context.Context, s StringCarrier) StringCarrier { return s })
+
+	in := make(chan StringCarrier, 2)
+	in <- StringCarrier{Value: "a", Index: 0}
+	in <- StringCarrier{Value: "b", Index: 1}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewSupervisor[StringCarrier](upper, identity).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 4)
+	}
+	if info, ok := ps.Load(); ok {
+		t.Fatalf("unexpected fault: %v", info.Value)
+	}
+}
+
+func TestNewSupervisor_FailsFast(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ctx, ps := WithPanicStore(ctx)
+
+	var healthySeen atomic.Int64
+	healthy := NewProcessorFunc(func(_ context.Context, s StringCarrier) StringCarrier {
+		healthySeen.Add(1)
+		return s
+	})
+	faulty := NewProcessorFunc(func(_ context.Context, s StringCarrier) StringCarrier {
+		if s.Index == 2 {
+			panic("boom")
+		}
+		return s
+	})
+
+	// Endless source: only cancellation can stop the pipeline.
+	in := make(chan StringCarrier)
+	go func() {
+		defer close(in)
+		for i := 0; ; i++ {
+			select {
+			case in <- StringCarrier{Value: "x", Index: i}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	out := NewSupervisor[StringCarrier](healthy, faulty).Apply(ctx, in)
+	if _, err := collectWithContext(ctx, out); err != nil {
+		t.Fatalf("the supervisor did not stop after the fault: %v", err)
+	}
+
+	info, ok := ps.Load()
+	if !ok {
+		t.Fatalf("expected the child fault to be surfaced")
+	}
+	if info.Value != "boom" {
+		t.Fatalf("unexpected fault: got %v want %q", info.Value, "boom")
+	}
+	if n := healthySeen.Load(); n > 100 {
+		t.Fatalf("healthy child should have been canceled early: saw %d items", n)
+	}
+}
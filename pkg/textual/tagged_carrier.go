@@ -0,0 +1,96 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+// Tagged is a Carrier that wraps another carrier and attaches free-form
+// metadata ("tags") to it.
+//
+// It is typically produced by transcoders that compute per-item annotations
+// (token estimates, repeat counts, ...) without altering the wrapped item:
+//
+//	Transcoder[S, Tagged[S]]
+//
+// Index and Error are delegated to the wrapped Item, so a Tagged value keeps the
+// ordering hint and the per-item error of the carrier it wraps. UTF8String
+// renders the wrapped Item; tags are not rendered.
+//
+// Tags are treated as immutable: WithTag returns a copy with a fresh map, so a
+// Tagged value can be safely shared between stages.
+type Tagged[S Carrier[S]] struct {
+	Item S              `json:"item"`
+	Tags map[string]any `json:"tags,omitempty"`
+}
+
+// TagItem wraps item into a Tagged carrier without tags.
+func TagItem[S Carrier[S]](item S) Tagged[S] {
+	return Tagged[S]{Item: item}
+}
+
+func (t Tagged[S]) UTF8String() UTF8String {
+	return t.Item.UTF8String()
+}
+
+func (t Tagged[S]) FromUTF8String(s UTF8String) Tagged[S] {
+	proto := *new(S)
+	return Tagged[S]{Item: proto.FromUTF8String(s)}
+}
+
+func (t Tagged[S]) WithIndex(idx int) Tagged[S] {
+	t.Item = t.Item.WithIndex(idx)
+	return t
+}
+
+func (t Tagged[S]) GetIndex() int {
+	return t.Item.GetIndex()
+}
+
+func (t Tagged[S]) WithError(err error) Tagged[S] {
+	if err == nil {
+		return t
+	}
+	t.Item = t.Item.WithError(err)
+	return t
+}
+
+func (t Tagged[S]) GetError() error {
+	return t.Item.GetError()
+}
+
+// WithTag returns a copy of t where key is associated with value.
+//
+// The tag map is copied so the receiver is left untouched.
+func (t Tagged[S]) WithTag(key string, value any) Tagged[S] {
+	tags := make(map[string]any, len(t.Tags)+1)
+	for k, v := range t.Tags {
+		tags[k] = v
+	}
+	tags[key] = value
+	t.Tags = tags
+	return t
+}
+
+// Tag returns the value associated with key, if any.
+func (t Tagged[S]) Tag(key string) (any, bool) {
+	v, ok := t.Tags[key]
+	return v, ok
+}
+
+// TagInt returns the value associated with key when it is an int.
+//
+// ok is false when the tag is missing or holds another type.
+func (t Tagged[S]) TagInt(key string) (int, bool) {
+	v, ok := t.Tags[key].(int)
+	return v, ok
+}
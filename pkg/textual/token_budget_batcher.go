@@ -0,0 +1,91 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrTokenBudgetExceeded is attached (as a per-item warning) to a batch made of
+// a single item whose token estimate is larger than the batcher budget.
+var ErrTokenBudgetExceeded = errors.New("textual: item exceeds the token budget")
+
+// NewTokenBudgetBatcher returns a Processor that packs consecutive items into
+// groups whose total token count stays under maxTokens, and emits each group as
+// a single carrier built with Aggregate.
+//
+// tokensOf returns the token count of an item. When nil, EstimateTokens is
+// applied to the item's UTF8String(). Negative counts are treated as 0.
+//
+// Batching rules:
+//
+//   - Items are never reordered and never split.
+//   - A group is emitted as soon as the next item would push it over maxTokens.
+//   - An item that exceeds maxTokens on its own is emitted alone, carrying an
+//     ErrTokenBudgetExceeded warning (via WithError).
+//   - The pending group is emitted when the input is closed. It is dropped when
+//     ctx is canceled.
+//   - Every group, including a group of one item, goes through Aggregate so
+//     that outputs have a consistent shape (e.g. always a JSON array for
+//     JsonCarrier).
+//
+// maxTokens values lower than 1 are treated as 1.
+func NewTokenBudgetBatcher[S Carrier[S]](maxTokens int, tokensOf func(S) int) ProcessorFunc[S] {
+	if maxTokens < 1 {
+		maxTokens = 1
+	}
+	if tokensOf == nil {
+		tokensOf = func(item S) int {
+			return EstimateTokens(item.UTF8String())
+		}
+	}
+	return func(ctx context.Context, in <-chan S) <-chan S {
+		var group []S
+		total := 0
+
+		emitGroup := func(emit func(S)) {
+			if len(group) == 0 {
+				return
+			}
+			emit(Aggregate(group))
+			group = nil
+			total = 0
+		}
+
+		return asyncEmitter(ctx, in, func(ctx context.Context, item S, emit func(S)) {
+			n := tokensOf(item)
+			if n < 0 {
+				n = 0
+			}
+
+			if n > maxTokens {
+				emitGroup(emit)
+				warn := fmt.Errorf("%w: %d > %d tokens (index %d)", ErrTokenBudgetExceeded, n, maxTokens, item.GetIndex())
+				emit(Aggregate([]S{item}).WithError(warn))
+				return
+			}
+
+			if total+n > maxTokens {
+				emitGroup(emit)
+			}
+			group = append(group, item)
+			total += n
+		}, func(ctx context.Context, emit func(S)) {
+			emitGroup(emit)
+		})
+	}
+}
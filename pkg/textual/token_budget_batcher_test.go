@@ -0,0 +1,91 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewTokenBudgetBatcher_PacksUnderBudget(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// One token per byte keeps the expectations readable.
+	tokensOf := func(s StringCarrier) int { return len(s.Value) }
+
+	values := []string{"aa", "bbb", "c", "dddddddddddd", "ee", "ffff", "g"}
+	in := make(chan StringCarrier, len(values))
+	for i, v := range values {
+		in <- StringCarrier{Value: v, Index: i}
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewTokenBudgetBatcher[StringCarrier](6, tokensOf).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+
+	got := make([]string, len(items))
+	for i, it := range items {
+		got[i] = it.Value
+	}
+	want := []string{"aabbbc", "dddddddddddd", "eeffff", "g"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected batches:\n got: %#v\nwant: %#v", got, want)
+	}
+
+	for i, it := range items {
+		isOversized := it.Value == "dddddddddddd"
+		if gotErr := errors.Is(it.GetError(), ErrTokenBudgetExceeded); gotErr != isOversized {
+			t.Fatalf("batch %d: unexpected budget warning: %v", i, it.GetError())
+		}
+	}
+
+	wantIdx := []int{0, 3, 4, 6}
+	for i, it := range items {
+		if it.Index != wantIdx[i] {
+			t.Fatalf("batch %d: unexpected index: got %d want %d", i, it.Index, wantIdx[i])
+		}
+	}
+}
+
+func TestNewTokenBudgetBatcher_AggregatesJSON(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan JsonCarrier, 3)
+	in <- JsonCarrier{Value: []byte(`{"a":1}`), Index: 0}
+	in <- JsonCarrier{Value: []byte(`{"b":2}`), Index: 1}
+	in <- JsonCarrier{Value: []byte(`{"c":3}`), Index: 2}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewTokenBudgetBatcher[JsonCarrier](2, func(JsonCarrier) int { return 1 }).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("unexpected batch count: got %d want %d", len(items), 2)
+	}
+	if got, want := string(items[0].Value), `[{"a":1},{"b":2}]`; got != want {
+		t.Fatalf("unexpected first batch: got %s want %s", got, want)
+	}
+	if got, want := string(items[1].Value), `[{"c":3}]`; got != want {
+		t.Fatalf("unexpected second batch: got %s want %s", got, want)
+	}
+}
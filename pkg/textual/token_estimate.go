@@ -0,0 +1,92 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"unicode"
+)
+
+// TokenEstimateTag is the Tagged key under which NewTokenEstimate stores the
+// estimated token count (an int).
+const TokenEstimateTag = "tokens"
+
+// TokenEstimator returns an approximate number of LLM tokens for s.
+//
+// Estimators are expected to be cheap and deterministic. They are used for
+// budgeting (packing requests under a limit), not for exact accounting.
+type TokenEstimator func(s UTF8String) int
+
+// EstimateTokens is the default TokenEstimator.
+//
+// It implements a small "BPE-lite" heuristic that is close enough to common
+// byte-pair encoders for budgeting purposes:
+//
+//   - runs of letters / digits count as one token per 4 runes (rounded up),
+//   - every punctuation or symbol rune counts as one token,
+//   - every Han / Hiragana / Katakana / Hangul rune counts as one token,
+//   - whitespace is free (it is usually merged into the following token).
+//
+// The estimate never decreases when text is appended.
+func EstimateTokens(s UTF8String) int {
+	tokens := 0
+	run := 0
+	flush := func() {
+		tokens += (run + 3) / 4
+		run = 0
+	}
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Han, r),
+			unicode.Is(unicode.Hiragana, r),
+			unicode.Is(unicode.Katakana, r),
+			unicode.Is(unicode.Hangul, r):
+			flush()
+			tokens++
+		case unicode.IsLetter(r), unicode.IsDigit(r), unicode.IsMark(r):
+			run++
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			tokens++
+		}
+	}
+	flush()
+	return tokens
+}
+
+// NewTokenEstimate returns a Transcoder that wraps every item into a Tagged
+// carrier holding an approximate token count under TokenEstimateTag.
+//
+// The default estimator (EstimateTokens) is used. See NewTokenEstimateWith to
+// plug a different one (for instance a real tokenizer).
+//
+// Items are not modified; index and per-item error are preserved by Tagged.
+func NewTokenEstimate[S Carrier[S]]() TranscoderFunc[S, Tagged[S]] {
+	return NewTokenEstimateWith[S](EstimateTokens)
+}
+
+// NewTokenEstimateWith is like NewTokenEstimate but uses the provided estimator.
+//
+// If estimator is nil, EstimateTokens is used.
+func NewTokenEstimateWith[S Carrier[S]](estimator TokenEstimator) TranscoderFunc[S, Tagged[S]] {
+	if estimator == nil {
+		estimator = EstimateTokens
+	}
+	return NewTranscoderFunc(func(_ context.Context, item S) Tagged[S] {
+		return TagItem(item).WithTag(TokenEstimateTag, estimator(item.UTF8String()))
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEstimateTokens_MonotonicOnPrefixes(t *testing.T) {
+	const text = "The quick brown fox jumps over the lazy dog, then naps. 東京は晴れ。"
+
+	runes := []rune(text)
+	prev := 0
+	for i := 1; i <= len(runes); i++ {
+		got := EstimateTokens(UTF8String(string(runes[:i])))
+		if got < prev {
+			t.Fatalf("estimate decreased at prefix %d: got %d previous %d", i, got, prev)
+		}
+		prev = got
+	}
+
+	short := EstimateTokens("hello")
+	long := EstimateTokens(UTF8String(strings.Repeat("hello world ", 20)))
+	if long <= short {
+		t.Fatalf("expected longer text to have more tokens: short=%d long=%d", short, long)
+	}
+}
+
+func TestNewTokenEstimate_TagsItemsAndPreservesIndex(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 3)
+	in <- StringCarrier{Value: "a", Index: 0}
+	in <- StringCarrier{Value: "a somewhat longer sentence", Index: 1}
+	in <- StringCarrier{Value: "an even longer sentence, with punctuation and more words", Index: 2, Error: errors.New("kept")}
+	close(in)
+
+	items, err := collectWithContext(ctx, NewTokenEstimate[StringCarrier]().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 3)
+	}
+
+	prev := 0
+	for i, it := range items {
+		if got := it.GetIndex(); got != i {
+			t.Fatalf("unexpected index: got %d want %d", got, i)
+		}
+		n, ok := it.TagInt(TokenEstimateTag)
+		if !ok {
+			t.Fatalf("item %d has no %q tag: %#v", i, TokenEstimateTag, it.Tags)
+		}
+		if n <= prev {
+			t.Fatalf("expected strictly increasing estimates: item %d got %d previous %d", i, n, prev)
+		}
+		prev = n
+	}
+	if items[2].GetError() == nil {
+		t.Fatalf("expected per-item error to be preserved")
+	}
+}
+
+func TestNewTokenEstimateWith_UsesCustomEstimator(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 1)
+	in <- StringCarrier{Value: "abc"}
+	close(in)
+
+	est := func(s UTF8String) int { return len(s) * 10 }
+	items, err := collectWithContext(ctx, NewTokenEstimateWith[StringCarrier](est).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if n, _ := items[0].TagInt(TokenEstimateTag); n != 30 {
+		t.Fatalf("unexpected estimate: got %d want %d", n, 30)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// NewTopK returns a Processor that forwards every JSON value unchanged while
+// keeping the k values with the greatest number at path, an RFC 6901 JSON
+// Pointer ("/stats/score"; "" for a value that is itself a number). Memory is
+// O(k) whatever the stream length.
+//
+// When the input is closed, the top-k values are emitted after the forwarded
+// ones, greatest first (the earliest value wins a tie), as copies indexed
+// after the highest index seen: the best one gets that index + 1, the next one
+// + 2, and so on. The copies carry no error. Nothing more is emitted when ctx
+// is canceled.
+//
+// Values carrying an error, invalid JSON and values without a number at path
+// are forwarded but not ranked. When k <= 0, items are passed through.
+// NewTopK panics if path is not a valid JSON Pointer.
+func NewTopK(path string, k int) ProcessorFunc[JsonCarrier] {
+	tokens, err := parseJSONPointer(path)
+	if err != nil {
+		panic(fmt.Sprintf("textual: NewTopK: %v", err))
+	}
+	if k <= 0 {
+		return passThroughProcessor[JsonCarrier]()
+	}
+	return func(ctx context.Context, in <-chan JsonCarrier) <-chan JsonCarrier {
+		top := make(topKHeap, 0, k)
+		seq := 0
+		maxIndex, seen := 0, false
+
+		return asyncEmitter(ctx, in, func(ctx context.Context, item JsonCarrier, emit func(JsonCarrier)) {
+			if idx := item.GetIndex(); !seen || idx > maxIndex {
+				maxIndex, seen = idx, true
+			}
+			if score, ok := jsonNumberAt(item, tokens); ok {
+				e := topKEntry{score: score, seq: seq, item: item}
+				seq++
+				switch {
+				case len(top) < k:
+					heap.Push(&top, e)
+				case top.less(top[0], e):
+					top[0] = e
+					heap.Fix(&top, 0)
+				}
+			}
+			emit(item)
+		}, func(ctx context.Context, emit func(JsonCarrier)) {
+			sort.Slice(top, func(i, j int) bool { return top.less(top[j], top[i]) })
+			for rank, e := range top {
+				emit(JsonCarrier{Value: e.item.Value, Index: maxIndex + 1 + rank})
+			}
+		})
+	}
+}
+
+// topKEntry is a ranked value of NewTopK; seq is its arrival order.
+type topKEntry struct {
+	score float64
+	seq   int
+	item  JsonCarrier
+}
+
+// topKHeap is a min-heap of topKEntry: its root is the worst kept value.
+type topKHeap []topKEntry
+
+// less reports whether a ranks below b: a lower score, or the same score
+// arriving later.
+func (h topKHeap) less(a, b topKEntry) bool {
+	if a.score != b.score {
+		return a.score < b.score
+	}
+	return a.seq > b.seq
+}
+
+func (h topKHeap) Len() int           { return len(h) }
+func (h topKHeap) Less(i, j int) bool { return h.less(h[i], h[j]) }
+func (h topKHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *topKHeap) Push(x any)        { *h = append(*h, x.(topKEntry)) }
+func (h *topKHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTopK_KnownSequence(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	scores := []string{"5", "1", "9", "3", "9", "7", `"8"`, "2"}
+	in := make(chan JsonCarrier, len(scores)+1)
+	for i, s := range scores {
+		in <- JsonCarrier{Value: json.RawMessage(fmt.Sprintf(`{"id":%d,"stats":{"score":%s}}`, i, s)), Index: i}
+	}
+	in <- JsonCarrier{Value: json.RawMessage(`{"id":99}`), Index: len(scores)}
+	close(in)
+
+	out, err := collectWithContext(ctx, NewTopK("/stats/score", 3).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != len(scores)+1+3 {
+		t.Fatalf("unexpected output count: got %d want %d", len(out), len(scores)+1+3)
+	}
+	for i := 0; i <= len(scores); i++ {
+		if out[i].Index != i {
+			t.Fatalf("unexpected forwarded item %d: got index %d", i, out[i].Index)
+		}
+	}
+	// 9 (id 2) before 9 (id 4): the earliest wins ties. "8" is a string.
+	wantIDs := []int{2, 4, 5}
+	for rank, id := range wantIDs {
+		got := out[len(scores)+1+rank]
+		var v struct {
+			ID int `json:"id"`
+		}
+		if err := json.Unmarshal(got.Value, &v); err != nil {
+			t.Fatalf("unexpected unmarshal error: %v", err)
+		}
+		if v.ID != id || got.Index != len(scores)+1+rank {
+			t.Fatalf("unexpected rank %d: got id %d (index %d) want id %d (index %d)", rank, v.ID, got.Index, id, len(scores)+1+rank)
+		}
+	}
+}
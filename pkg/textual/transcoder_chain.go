@@ -0,0 +1,46 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+)
+
+// ChainTranscoders composes two transcoders end-to-end.
+//
+// The resulting transcoder behaves like:
+//
+//	out := t2.Apply(ctx, t1.Apply(ctx, in))
+//
+// Go generics cannot express a variadic chain of changing types: use
+// ChainTranscoders3 for three stages, or nest ChainTranscoders calls.
+//
+// Since the result is a TranscoderFunc, a panic raised while wiring the stages
+// (including a nil t1 or t2) is recorded into the PanicStore and a closed
+// channel is returned.
+func ChainTranscoders[A Carrier[A], B Carrier[B], C Carrier[C]](t1 Transcoder[A, B], t2 Transcoder[B, C]) TranscoderFunc[A, C] {
+	return func(ctx context.Context, in <-chan A) <-chan C {
+		return t2.Apply(ctx, t1.Apply(ctx, in))
+	}
+}
+
+// ChainTranscoders3 composes three transcoders end-to-end.
+//
+// The resulting transcoder behaves like:
+//
+//	out := t3.Apply(ctx, t2.Apply(ctx, t1.Apply(ctx, in)))
+func ChainTranscoders3[A Carrier[A], B Carrier[B], C Carrier[C], D Carrier[D]](t1 Transcoder[A, B], t2 Transcoder[B, C], t3 Transcoder[C, D]) TranscoderFunc[A, D] {
+	return ChainTranscoders[A, C, D](ChainTranscoders[A, B, C](t1, t2), t3)
+}
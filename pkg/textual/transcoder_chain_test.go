@@ -0,0 +1,95 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestChainTranscoders_StringToJsonToParcel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	toJson := NewTranscoderFunc(func(_ context.Context, s StringCarrier) JsonCarrier {
+		b, _ := json.Marshal(map[string]string{"word": s.Value})
+		return JsonCarrier{Value: b, Index: s.Index}
+	})
+	toParcel := NewTranscoderFunc(func(_ context.Context, j JsonCarrier) Parcel {
+		var v map[string]string
+		if err := json.Unmarshal(j.Value, &v); err != nil {
+			return Parcel{}.FromUTF8String("").WithIndex(j.Index).WithError(err)
+		}
+		return Parcel{}.FromUTF8String(UTF8String(v["word"] + "!")).WithIndex(j.Index)
+	})
+
+	words := []string{"un", "deux", "trois"}
+	in := make(chan StringCarrier, len(words))
+	for i, w := range words {
+		in <- StringCarrier{Value: w, Index: i + 10}
+	}
+	close(in)
+
+	chain := ChainTranscoders[StringCarrier, JsonCarrier, Parcel](toJson, toParcel)
+	items, err := collectWithContext(ctx, chain.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	sortByIndex(items)
+	if len(items) != len(words) {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), len(words))
+	}
+	for i, it := range items {
+		if it.GetError() != nil {
+			t.Fatalf("unexpected error on item %d: %v", i, it.GetError())
+		}
+		if got, want := string(it.UTF8String()), words[i]+"!"; got != want {
+			t.Fatalf("unexpected value: got %q want %q", got, want)
+		}
+		if it.GetIndex() != i+10 {
+			t.Fatalf("unexpected index: got %d want %d", it.GetIndex(), i+10)
+		}
+	}
+}
+
+func TestChainTranscoders3_ComposesThreeStages(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	toParcel := NewTranscoderFunc(func(_ context.Context, s StringCarrier) Parcel {
+		return Parcel{}.FromUTF8String(UTF8String(s.Value)).WithIndex(s.Index)
+	})
+	toJson := NewTranscoderFunc(func(_ context.Context, p Parcel) JsonCarrier {
+		b, _ := json.Marshal(string(p.UTF8String()))
+		return JsonCarrier{Value: b, Index: p.Index}
+	})
+	toString := NewTranscoderFunc(func(_ context.Context, j JsonCarrier) StringCarrier {
+		return StringCarrier{Value: string(j.Value), Index: j.Index}
+	})
+
+	in := make(chan StringCarrier, 1)
+	in <- StringCarrier{Value: "a", Index: 3}
+	close(in)
+
+	items, err := collectWithContext(ctx, ChainTranscoders3[StringCarrier, Parcel, JsonCarrier, StringCarrier](toParcel, toJson, toString).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Value != `"a"` || items[0].Index != 3 {
+		t.Fatalf("unexpected items: %#v", items)
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"golang.org/x/text/transform"
+)
+
+// Nature describes a text format at a pipeline boundary: its Dialect (a free
+// label such as "plain", "json" or "csv") and the byte encoding it uses.
+type Nature struct {
+	Dialect    string     `json:"dialect"`
+	EncodingID EncodingID `json:"encodingID"`
+}
+
+// Transformation binds a Processor to the natures of its input and output, so
+// that a whole document can be decoded, processed and encoded in one call (see
+// Process).
+type Transformation[S Carrier[S]] struct {
+	Name      string
+	Processor Processor[S]
+	From      Nature
+	To        Nature
+
+	// Validator, when set, checks the decoded input before processing and
+	// rejects text that does not conform to From.Dialect (for example, a JSON
+	// dialect validator rejecting non-JSON input). nil skips validation.
+	Validator func(UTF8String) error
+}
+
+// NewTransformation returns a Transformation named name applying processor to
+// text read in the from nature and written in the to nature.
+func NewTransformation[S Carrier[S]](name string, processor Processor[S], from, to Nature) *Transformation[S] {
+	return &Transformation[S]{
+		Name:      name,
+		Processor: processor,
+		From:      from,
+		To:        to,
+	}
+}
+
+// DecodeText reads all of r and decodes it from t.From.EncodingID into UTF-8.
+func (t *Transformation[S]) DecodeText(r io.Reader) (UTF8String, error) {
+	return ReaderToUTF8(r, t.From.EncodingID)
+}
+
+// Process decodes r (see DecodeText), validates the text with t.Validator when
+// set, applies t.Processor to it as a single item with index 0, and writes the
+// UTF8String() of each output, encoded in t.To.EncodingID, to w.
+//
+// r and w are always closed before Process returns. Carrier errors are not
+// interpreted: the processor (or the consumer of w) decides what to do with
+// them. Process returns the first decoding, validation, encoding or write
+// error, ctx.Err() when ctx is canceled, and an error when a stage panicked
+// (see PanicStore). A nil Processor passes the text through.
+func (t *Transformation[S]) Process(ctx context.Context, r io.ReadCloser, w io.WriteCloser) (err error) {
+	defer func() {
+		err = errors.Join(err, r.Close(), w.Close())
+	}()
+
+	text, err := t.DecodeText(r)
+	if err != nil {
+		return fmt.Errorf("transformation %q: decode: %w", t.Name, err)
+	}
+	if t.Validator != nil {
+		if err := t.Validator(text); err != nil {
+			return fmt.Errorf("transformation %q: invalid %s input: %w", t.Name, t.From.Dialect, err)
+		}
+	}
+
+	ctx, ps := EnsurePanicStore(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	in := make(chan S, 1)
+	in <- (*new(S)).FromUTF8String(text).WithIndex(0)
+	close(in)
+
+	out, _ := safeApplyProcessor(ctx, ps, t.Processor, in)
+	for res := range out {
+		if err != nil {
+			continue // drain
+		}
+		if werr := FromUTF8ToWriter(res.UTF8String(), t.To.EncodingID, w); werr != nil {
+			err = fmt.Errorf("transformation %q: encode: %w", t.Name, werr)
+			cancel()
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if info, ok := ps.Load(); ok {
+		return fmt.Errorf("transformation %q: panic: %v", t.Name, info.Value)
+	}
+	return ctx.Err()
+}
+
+// ProcessStream is the memory-bounded counterpart of Process for large inputs:
+// instead of decoding the whole reader into one item, it scans r into tokens
+// with split (ScanLines when nil) through an internal IOReaderProcessor, and
+// encodes each output of t.Processor to w as soon as it arrives.
+//
+// Decoding and encoding are streamed: r is decoded from t.From.EncodingID by a
+// single decoder before tokenization (see NewUTF8Reader), and outputs go
+// through a single t.To.EncodingID encoder, so multi-byte and stateful
+// encodings are safe even when a character straddles two reads or two items.
+//
+// When t.Validator is set, it is applied to each token rather than to the whole
+// input, and the first rejected token stops the stream. Outputs are written in
+// arrival order, so a processor that reorders items (Router, ...) reorders the
+// output too.
+//
+// r and w are always closed before ProcessStream returns. It returns the first
+// read, validation, encoding or write error, ctx.Err() when ctx is canceled,
+// and an error when a stage panicked.
+func (t *Transformation[S]) ProcessStream(ctx context.Context, r io.ReadCloser, w io.WriteCloser, split bufio.SplitFunc) (err error) {
+	defer func() {
+		err = errors.Join(err, r.Close(), w.Close())
+	}()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	decoded, err := NewUTF8Reader(r, t.From.EncodingID)
+	if err != nil {
+		return fmt.Errorf("transformation %q: decode: %w", t.Name, err)
+	}
+	enc, err := GetEncoding(t.To.EncodingID)
+	if err != nil {
+		return fmt.Errorf("transformation %q: encode: %w", t.Name, err)
+	}
+	encoded := transform.NewWriter(w, enc.NewEncoder())
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// firstErr keeps the first failure reported from a pipeline goroutine.
+	var (
+		mu       sync.Mutex
+		firstErr error
+	)
+	fail := func(e error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = e
+		}
+		mu.Unlock()
+		cancel()
+	}
+
+	var proc Processor[S] = passThroughProcessor[S]()
+	if t.Processor != nil {
+		proc = t.Processor
+	}
+	if t.Validator != nil {
+		validate := NewProcessorFunc(func(_ context.Context, item S) S {
+			if verr := t.Validator(item.UTF8String()); verr != nil {
+				fail(fmt.Errorf("transformation %q: invalid %s input (token %d): %w", t.Name, t.From.Dialect, item.GetIndex(), verr))
+			}
+			return item
+		})
+		proc = NewChain[S](validate, proc)
+	}
+
+	source := &readErrRecorder{r: decoded}
+	iop := NewIOReaderProcessor[S](proc, source)
+	iop.SetContext(ctx)
+	if split != nil {
+		iop.SetSplitFunc(split)
+	}
+	for res := range iop.Start() {
+		if ctx.Err() != nil {
+			continue // drain
+		}
+		if _, werr := io.WriteString(encoded, res.UTF8String()); werr != nil {
+			fail(fmt.Errorf("transformation %q: encode: %w", t.Name, werr))
+		}
+	}
+
+	mu.Lock()
+	err = firstErr
+	mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if source.err != nil {
+		return fmt.Errorf("transformation %q: decode: %w", t.Name, source.err)
+	}
+	if info, ok := iop.PanicStore().Load(); ok {
+		return fmt.Errorf("transformation %q: panic: %v", t.Name, info.Value)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	// Flush the encoder.
+	if err := encoded.Close(); err != nil {
+		return fmt.Errorf("transformation %q: encode: %w", t.Name, err)
+	}
+	return nil
+}
+
+// readErrRecorder is an io.Reader keeping the first error other than io.EOF
+// returned by r, which bufio.Scanner would otherwise hide from its caller.
+type readErrRecorder struct {
+	r   io.Reader
+	err error
+}
+
+func (e *readErrRecorder) Read(b []byte) (int, error) {
+	n, err := e.r.Read(b)
+	if err != nil && err != io.EOF && e.err == nil {
+		e.err = err
+	}
+	return n, err
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// closingBuffer is a bytes.Buffer recording whether it was closed.
+type closingBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *closingBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestTransformation_Process(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	tr := NewTransformation[StringCarrier](
+		"upper",
+		MapString(strings.ToUpper),
+		Nature{Dialect: "plain", EncodingID: UTF8},
+		Nature{Dialect: "plain", EncodingID: ISO8859_1},
+	)
+	var w closingBuffer
+	if err := tr.Process(ctx, io.NopCloser(strings.NewReader("café")), &w); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := w.String(), "CAF\xc9"; got != want {
+		t.Fatalf("unexpected output: got %q want %q", got, want)
+	}
+	if !w.closed {
+		t.Fatalf("unexpected: writer not closed")
+	}
+}
+
+func TestTransformation_ValidatorRejectsInput(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	errEmpty := errors.New("empty input")
+	tr := NewTransformation[StringCarrier](
+		"non-empty",
+		MapString(strings.ToUpper),
+		Nature{Dialect: "plain", EncodingID: UTF8},
+		Nature{Dialect: "plain", EncodingID: UTF8},
+	)
+	tr.Validator = func(text UTF8String) error {
+		if strings.TrimSpace(text) == "" {
+			return errEmpty
+		}
+		return nil
+	}
+
+	var w closingBuffer
+	err := tr.Process(ctx, io.NopCloser(strings.NewReader("  \n")), &w)
+	if !errors.Is(err, errEmpty) {
+		t.Fatalf("unexpected error: got %v want %v", err, errEmpty)
+	}
+	if w.Len() != 0 || !w.closed {
+		t.Fatalf("unexpected writer state: len %d closed %v", w.Len(), w.closed)
+	}
+
+	w = closingBuffer{}
+	if err := tr.Process(ctx, io.NopCloser(strings.NewReader("ok")), &w); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := w.String(), "OK"; got != want {
+		t.Fatalf("unexpected output: got %q want %q", got, want)
+	}
+}
+
+func TestTransformation_ProcessStream(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	tr := NewTransformation[StringCarrier](
+		"upper",
+		MapString(strings.ToUpper),
+		Nature{Dialect: "plain", EncodingID: ISO8859_1},
+		Nature{Dialect: "plain", EncodingID: UTF8},
+	)
+	input := strings.Repeat("caf\xe9\n", 1000)
+	var w closingBuffer
+	if err := tr.ProcessStream(ctx, io.NopCloser(strings.NewReader(input)), &w, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := w.String(), strings.Repeat("CAFÉ\n", 1000); got != want {
+		t.Fatalf("unexpected output: got %d bytes want %d", len(got), len(want))
+	}
+	if !w.closed {
+		t.Fatalf("unexpected: writer not closed")
+	}
+}
+
+func TestTransformation_ProcessStream_ValidatorStops(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	errBad := errors.New("bad token")
+	tr := NewTransformation[StringCarrier](
+		"lines",
+		nil,
+		Nature{Dialect: "plain", EncodingID: UTF8},
+		Nature{Dialect: "plain", EncodingID: UTF8},
+	)
+	tr.Validator = func(text UTF8String) error {
+		if strings.HasPrefix(text, "bad") {
+			return errBad
+		}
+		return nil
+	}
+
+	var w closingBuffer
+	err := tr.ProcessStream(ctx, io.NopCloser(strings.NewReader("ok\nbad\nok\n")), &w, nil)
+	if !errors.Is(err, errBad) {
+		t.Fatalf("unexpected error: got %v want %v", err, errBad)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import "context"
+
+// IdentityProcessor returns a Processor that leaves the stream untouched:
+// Apply returns its input channel as is.
+func IdentityProcessor[S Carrier[S]]() ProcessorFunc[S] {
+	return ProcessorFunc[S](func(_ context.Context, in <-chan S) <-chan S {
+		return in
+	})
+}
+
+// When includes inner in a pipeline only if enabled is true, and an
+// IdentityProcessor otherwise. It is meant for configuration known at build
+// time (feature flags), without the per-item overhead of If:
+//
+//	chain := NewChain(tokenize, When(cfg.Uppercase, upper), count)
+//
+// A nil inner is treated as an IdentityProcessor.
+func When[S Carrier[S]](enabled bool, inner Processor[S]) Processor[S] {
+	if !enabled || inner == nil {
+		return IdentityProcessor[S]()
+	}
+	return inner
+}
+
+// WhenFunc is like When, but enabledFn is evaluated once per Apply call, so the
+// same pipeline value can follow a configuration changing between runs.
+// A nil enabledFn disables the stage.
+func WhenFunc[S Carrier[S]](enabledFn func() bool, inner Processor[S]) Processor[S] {
+	return ProcessorFunc[S](func(ctx context.Context, in <-chan S) <-chan S {
+		enabled := enabledFn != nil && enabledFn()
+		return When(enabled, inner).Apply(ctx, in)
+	})
+}
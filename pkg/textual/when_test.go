@@ -0,0 +1,75 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func runWhen(t *testing.T, p Processor[StringCarrier]) string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan StringCarrier, 1)
+	in <- StringCarrier{Value: "x", Index: 0}
+	close(in)
+
+	items, err := collectWithContext(ctx, p.Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), 1)
+	}
+	return items[0].Value
+}
+
+func TestWhen_EnabledAndDisabled(t *testing.T) {
+	if got, want := runWhen(t, When[StringCarrier](true, procSuffix("!"))), "x!"; got != want {
+		t.Fatalf("unexpected enabled output: got %q want %q", got, want)
+	}
+	if got, want := runWhen(t, When[StringCarrier](false, procSuffix("!"))), "x"; got != want {
+		t.Fatalf("unexpected disabled output: got %q want %q", got, want)
+	}
+	if got, want := runWhen(t, When[StringCarrier](true, nil)), "x"; got != want {
+		t.Fatalf("unexpected nil inner output: got %q want %q", got, want)
+	}
+}
+
+func TestWhenFunc_EvaluatedAtApply(t *testing.T) {
+	enabled := false
+	calls := 0
+	p := WhenFunc[StringCarrier](func() bool {
+		calls++
+		return enabled
+	}, procSuffix("!"))
+
+	if got, want := runWhen(t, p), "x"; got != want {
+		t.Fatalf("unexpected disabled output: got %q want %q", got, want)
+	}
+	enabled = true
+	if got, want := runWhen(t, p), "x!"; got != want {
+		t.Fatalf("unexpected enabled output: got %q want %q", got, want)
+	}
+	if calls != 2 {
+		t.Fatalf("unexpected enabledFn calls: got %d want %d", calls, 2)
+	}
+	if got, want := runWhen(t, WhenFunc[StringCarrier](nil, procSuffix("!"))), "x"; got != want {
+		t.Fatalf("unexpected nil enabledFn output: got %q want %q", got, want)
+	}
+}
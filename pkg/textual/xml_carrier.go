@@ -16,8 +16,19 @@ package textual
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
 )
 
+// XmlDefaultContainer is the container element name XmlCarrier.Aggregate uses
+// when none is configured.
+const XmlDefaultContainer = "items"
+
+// ErrInvalidXMLName is attached by XmlCarrier.WithContainer and
+// XmlCarrier.Aggregate when the container name is not a valid XML name.
+var ErrInvalidXMLName = errors.New("textual: invalid XML name")
+
 // XmlCarrier is a minimal Carrier implementation that transports an
 // opaque XML fragment.
 //
@@ -31,15 +42,17 @@ import (
 //
 // Aggregate concatenates multiple XmlCarrier fragments into one well-formed XML
 // document by wrapping them inside a container element "<items>...</items>"
-// after stably sorting by Index.
+// after stably sorting by Index. Use WithContainer to name the container
+// differently.
 //
 // Important:
 //   - Aggregation assumes inputs are elements (not full documents with XML declarations).
 //   - No additional whitespace is inserted between elements.
 type XmlCarrier struct {
-	Value UTF8String `json:"value"`
-	Index int        `json:"index,omitempty"`
-	Error error      `json:"error,omitempty"`
+	Value     UTF8String `json:"value"`
+	Index     int        `json:"index,omitempty"`
+	Error     error      `json:"error,omitempty"`
+	Container string     `json:"container,omitempty"` // Aggregate container element name; XmlDefaultContainer when empty.
 }
 
 func (s XmlCarrier) UTF8String() UTF8String {
@@ -79,3 +92,73 @@ func (s XmlCarrier) WithError(err error) XmlCarrier {
 func (s XmlCarrier) GetError() error {
 	return s.Error
 }
+
+// WithContainer sets the name of the container element Aggregate wraps the
+// fragments in. An invalid XML name is ignored: the default container is kept
+// and an ErrInvalidXMLName error is attached.
+func (s XmlCarrier) WithContainer(name string) XmlCarrier {
+	if !isXMLName(name) {
+		s.Container = ""
+		return s.WithError(fmt.Errorf("%w: container %q", ErrInvalidXMLName, name))
+	}
+	s.Container = name
+	return s
+}
+
+// Aggregate wraps the fragments of items inside a container element after
+// stably sorting them by Index.
+//
+// The container is named after the Container of the first item that has one
+// (or of the receiver when items is empty), "<items>...</items>" by default.
+// An invalid name falls back to the default with an ErrInvalidXMLName error.
+//
+// The result carries the first index, the container name and the joined
+// per-item errors.
+func (s XmlCarrier) Aggregate(items []XmlCarrier) XmlCarrier {
+	container := s.Container
+	sorted := sortedByIndex(items)
+	for _, it := range sorted {
+		if it.Container != "" {
+			container = it.Container
+			break
+		}
+	}
+	var err error
+	if container == "" {
+		container = XmlDefaultContainer
+	} else if !isXMLName(container) {
+		err = fmt.Errorf("%w: container %q", ErrInvalidXMLName, container)
+		container = XmlDefaultContainer
+	}
+
+	var b strings.Builder
+	b.WriteString("<" + container + ">")
+	for _, it := range sorted {
+		b.WriteString(it.Value)
+	}
+	b.WriteString("</" + container + ">")
+	res := XmlCarrier{Value: UTF8String(b.String()), Container: container}
+	if len(sorted) > 0 {
+		res.Index = sorted[0].Index
+		res = withJoinedErrors(res, sorted)
+	}
+	return res.WithError(err)
+}
+
+// isXMLName reports whether name is a valid XML element name: a
+// NameStartChar or ':' followed by NameChars or ':' (see isXMLNameStart).
+func isXMLName(name string) bool {
+	if name == "" || !utf8.ValidString(name) {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == ':':
+		case i == 0 && isXMLNameStart(r):
+		case i > 0 && isXMLNameChar(r):
+		default:
+			return false
+		}
+	}
+	return true
+}
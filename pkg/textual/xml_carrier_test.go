@@ -0,0 +1,59 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestXmlCarrier_AggregateCustomContainer(t *testing.T) {
+	items := []XmlCarrier{
+		XmlCarrier{Value: "<record>b</record>", Index: 1}.WithContainer("records"),
+		XmlCarrier{Value: "<record>a</record>", Index: 0}.WithContainer("records"),
+	}
+	got := Aggregate(items)
+	if want := "<records><record>a</record><record>b</record></records>"; string(got.Value) != want {
+		t.Fatalf("unexpected aggregate: got %q want %q", got.Value, want)
+	}
+	if got.Error != nil {
+		t.Fatalf("unexpected error: %v", got.Error)
+	}
+
+	// Without container, the default is used.
+	got = Aggregate([]XmlCarrier{{Value: "<a/>"}})
+	if want := "<items><a/></items>"; string(got.Value) != want {
+		t.Fatalf("unexpected default aggregate: got %q want %q", got.Value, want)
+	}
+}
+
+func TestXmlCarrier_InvalidContainerFallsBack(t *testing.T) {
+	item := XmlCarrier{Value: "<a/>"}.WithContainer("1 bad>")
+	if !errors.Is(item.Error, ErrInvalidXMLName) || item.Container != "" {
+		t.Fatalf("unexpected carrier: container %q, error %v", item.Container, item.Error)
+	}
+	got := Aggregate([]XmlCarrier{{Value: "<a/>", Container: "bad name"}})
+	if want := "<items><a/></items>"; string(got.Value) != want {
+		t.Fatalf("unexpected aggregate: got %q want %q", got.Value, want)
+	}
+	if !errors.Is(got.Error, ErrInvalidXMLName) {
+		t.Fatalf("unexpected error: got %v want %v", got.Error, ErrInvalidXMLName)
+	}
+	for _, name := range []string{"records", "ns:rec", "_x-1.2", "café"} {
+		if !isXMLName(name) {
+			t.Fatalf("unexpected invalid name %q", name)
+		}
+	}
+}
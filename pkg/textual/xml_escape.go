@@ -0,0 +1,235 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// NewXMLEscape returns a Processor making XmlCarrier fragments safe to parse
+// before they leave the pipeline.
+//
+// It walks each fragment, leaving the markup (tags, comments, CDATA sections,
+// processing instructions) as-is, and fixes what would make a parser fail:
+//
+//   - in text content, '<' that does not open markup becomes "&lt;", the '>'
+//     of "]]>" (forbidden outside CDATA sections) becomes "&gt;", and '&' that
+//     does not start a character or entity reference becomes "&amp;"; any
+//     other '>' is legal and left alone;
+//   - in quoted attribute values, '<' and bare '&' are escaped the same way;
+//   - declarations (<!DOCTYPE ...>) are copied as-is, including an internal
+//     subset between brackets, whose markup may contain '>';
+//   - characters that XML 1.0 forbids everywhere (control characters other than
+//     tab, CR and LF, U+FFFE, U+FFFF) and invalid UTF-8, which includes
+//     encoded unpaired surrogates, are replaced with U+FFFD. They cannot be
+//     escaped: even a character reference to them is illegal.
+//
+// Already valid fragments are forwarded unchanged. Index and error are
+// preserved.
+func NewXMLEscape() ProcessorFunc[XmlCarrier] {
+	return NewProcessorFunc(func(_ context.Context, x XmlCarrier) XmlCarrier {
+		x.Value = escapeXMLFragment(x.Value)
+		return x
+	})
+}
+
+// xmlReference matches a character or entity reference at the start of a
+// string.
+var xmlReference = regexp.MustCompile(`^&(#[0-9]+|#x[0-9a-fA-F]+|[A-Za-z_:][A-Za-z0-9_:.-]*);`)
+
+// escapeXMLFragment implements NewXMLEscape.
+func escapeXMLFragment(s UTF8String) UTF8String {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	// closer is the end delimiter of the markup being copied ("" in text).
+	closer := ""
+	// quote is the quote of the attribute value being copied (0 outside).
+	var quote byte
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !isXMLChar(r) || (r == utf8.RuneError && size == 1) {
+			b.WriteRune('\uFFFD')
+			i += size
+			continue
+		}
+		rest := s[i:]
+
+		switch {
+		case closer == ">" && quote != 0:
+			// Attribute value.
+			switch {
+			case r == rune(quote):
+				quote = 0
+				b.WriteByte(s[i])
+			case r == '<':
+				b.WriteString("&lt;")
+			case r == '&' && !xmlReference.MatchString(rest):
+				b.WriteString("&amp;")
+			default:
+				b.WriteString(s[i : i+size])
+			}
+
+		case closer == ">":
+			// Inside a tag or a declaration.
+			if r == '"' || r == '\'' {
+				quote = s[i]
+			} else if r == '>' {
+				closer = ""
+			}
+			b.WriteString(s[i : i+size])
+
+		case closer != "":
+			// Comment, CDATA section or processing instruction: copy up to
+			// the closing delimiter.
+			if strings.HasPrefix(rest, closer) {
+				b.WriteString(closer)
+				i += len(closer)
+				closer = ""
+				continue
+			}
+			b.WriteString(s[i : i+size])
+
+		case r == '<':
+			switch {
+			case strings.HasPrefix(rest, "<!--"):
+				closer = "-->"
+				b.WriteString("<!--")
+				i += 4
+				continue
+			case strings.HasPrefix(rest, "<![CDATA["):
+				closer = "]]>"
+				b.WriteString("<![CDATA[")
+				i += 9
+				continue
+			case strings.HasPrefix(rest, "<?"):
+				closer = "?>"
+				b.WriteString("<?")
+				i += 2
+				continue
+			case strings.HasPrefix(rest, "<!") && opensXMLTag(rest):
+				if end := xmlDeclarationEnd(rest); end > 0 {
+					writeXMLChars(&b, rest[:end])
+					i += end
+					continue
+				}
+				// Unterminated: handle it like a tag.
+				closer = ">"
+				b.WriteByte('<')
+			case opensXMLTag(rest):
+				closer = ">"
+				b.WriteByte('<')
+			default:
+				b.WriteString("&lt;")
+			}
+
+		case strings.HasPrefix(rest, "]]>"):
+			b.WriteString("]]&gt;")
+			i += 3
+			continue
+
+		case r == '&' && !xmlReference.MatchString(rest):
+			b.WriteString("&amp;")
+
+		default:
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// xmlDeclarationEnd returns the length of the declaration (<!DOCTYPE ...>) at
+// the start of s, up to and including its closing '>', or -1 when it is not
+// terminated. Quoted literals, and the bracketed internal subset with its
+// comments and processing instructions, may contain '>'.
+func xmlDeclarationEnd(s string) int {
+	depth := 0
+	var quote byte
+	for i := 2; i < len(s); {
+		rest := s[i:]
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case depth > 0 && strings.HasPrefix(rest, "<!--"):
+			j := strings.Index(rest[4:], "-->")
+			if j < 0 {
+				return -1
+			}
+			i += 4 + j + 3
+			continue
+		case depth > 0 && strings.HasPrefix(rest, "<?"):
+			j := strings.Index(rest[2:], "?>")
+			if j < 0 {
+				return -1
+			}
+			i += 2 + j + 2
+			continue
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']' && depth > 0:
+			depth--
+		case c == '>' && depth == 0:
+			return i + 1
+		}
+		i++
+	}
+	return -1
+}
+
+// writeXMLChars writes s to b, replacing the characters that XML 1.0 forbids
+// and invalid UTF-8 with U+FFFD.
+func writeXMLChars(b *strings.Builder, s string) {
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !isXMLChar(r) || (r == utf8.RuneError && size == 1) {
+			b.WriteRune('\uFFFD')
+		} else {
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+}
+
+// opensXMLTag reports whether s, starting with '<', opens a start tag, an end
+// tag or a declaration (<!DOCTYPE ...>).
+func opensXMLTag(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+	next := s[1:]
+	if next[0] == '/' || next[0] == '!' {
+		next = next[1:]
+	}
+	r, _ := utf8.DecodeRuneInString(next)
+	return r == '_' || r == ':' || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= 0xC0 && r != utf8.RuneError)
+}
+
+// isXMLChar reports whether r is allowed in an XML 1.0 document.
+func isXMLChar(r rune) bool {
+	return r == '\t' || r == '\n' || r == '\r' ||
+		(r >= 0x20 && r <= 0xD7FF) ||
+		(r >= 0xE000 && r <= 0xFFFD) ||
+		(r >= 0x10000 && r <= 0x10FFFF)
+}
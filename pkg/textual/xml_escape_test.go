@@ -0,0 +1,64 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewXMLEscape(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{`<a>Tom & Jerry</a>`, `<a>Tom &amp; Jerry</a>`},
+		{`<a>1 < 2 > 0</a>`, `<a>1 &lt; 2 > 0</a>`},
+		{`<a>x]]>y</a>`, `<a>x]]&gt;y</a>`},
+		{`<!DOCTYPE a [<!ENTITY e "<b>"><!-- it's > --><?pi > ?>]><a>t</a>`, `<!DOCTYPE a [<!ENTITY e "<b>"><!-- it's > --><?pi > ?>]><a>t</a>`},
+		{`<a>&amp; &#233; &#x1F600; &nbsp;</a>`, `<a>&amp; &#233; &#x1F600; &nbsp;</a>`},
+		{"<a>bell\x07 nul\x00 tab\t</a>", "<a>bell\uFFFD nul\uFFFD tab\t</a>"},
+		{"<a>surrogate \xed\xa0\x80!</a>", "<a>surrogate \uFFFD\uFFFD\uFFFD!</a>"},
+		{`<a href="x?a=1&b=<2>">t</a>`, `<a href="x?a=1&amp;b=&lt;2>">t</a>`},
+		{`<a><!-- a < b & c --><![CDATA[x < y & z]]></a>`, `<a><!-- a < b & c --><![CDATA[x < y & z]]></a>`},
+		{`<a><b/>ok</a>`, `<a><b/>ok</a>`},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan XmlCarrier, len(cases))
+	for i, c := range cases {
+		in <- XmlCarrier{Value: c.in, Index: i}
+	}
+	close(in)
+	out, err := collectWithContext(ctx, NewXMLEscape().Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, c := range cases {
+		if out[i].Value != c.want {
+			t.Fatalf("unexpected escape of %q: got %q want %q", c.in, out[i].Value, c.want)
+		}
+		if strings.Contains(c.want, "&nbsp;") {
+			continue // not a predefined entity
+		}
+		if err := xml.Unmarshal([]byte(out[i].Value), new(struct{})); err != nil {
+			t.Fatalf("unexpected parse error for %q: %v", out[i].Value, err)
+		}
+	}
+}
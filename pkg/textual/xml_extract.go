@@ -0,0 +1,190 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrXMLPathNotFound reports that an XML fragment has nothing at the path given
+// to ExtractXML.
+var ErrXMLPathNotFound = errors.New("textual: XML path not found")
+
+// xmlPathStep is one element step of an ExtractXML path. pos is the 1-based
+// position among the same-named siblings, or 0 for any of them.
+type xmlPathStep struct {
+	name string
+	pos  int
+}
+
+// ExtractXML returns a Transcoder extracting a child element or an attribute
+// from each XML fragment, with a minimal XPath-like syntax:
+//
+//   - "/root/child" selects the first child element of the root reachable by
+//     that path: like XPath, when several "child" elements exist, the first one
+//     containing the rest of the path wins;
+//   - "/root/child[2]" selects the second "child" element of the root
+//     (positions are 1-based);
+//   - "/root/child/@attr" selects an attribute.
+//
+// Names are compared as written in the document, prefix included
+// ("/feed/atom:link"); namespaces are not resolved.
+//
+// A selected element is emitted verbatim, as a new fragment sliced from the
+// input (so its namespace declarations must be local to it to stay
+// self-contained). A selected attribute is emitted as its escaped text value.
+//
+// Index and error are preserved. When the path is missing, the output has an
+// empty value and an error wrapping ErrXMLPathNotFound; malformed XML yields an
+// empty value and the decoder error, matching ErrTranscode.
+//
+// ExtractXML panics if path is malformed.
+func ExtractXML(path string) TranscoderFunc[XmlCarrier, XmlCarrier] {
+	steps, attr, err := parseXMLPath(path)
+	if err != nil {
+		panic(fmt.Sprintf("textual: ExtractXML: %v", err))
+	}
+	return NewTranscoderFunc(func(_ context.Context, x XmlCarrier) XmlCarrier {
+		res := XmlCarrier{Index: x.Index, Container: x.Container}.WithError(x.Error)
+		value, err := extractXML(x.Value, steps, attr)
+		if err != nil {
+			if errors.Is(err, ErrXMLPathNotFound) {
+				return res.WithError(fmt.Errorf("extract xml (index %d): %w: %q", x.Index, err, path))
+			}
+			return res.WithError(transcodeErrorf("extract xml (index %d): %w", x.Index, err))
+		}
+		res.Value = value
+		return res
+	})
+}
+
+// parseXMLPath parses an ExtractXML path into its element steps and the
+// optional trailing attribute name.
+func parseXMLPath(path string) (steps []xmlPathStep, attr string, err error) {
+	rest, ok := strings.CutPrefix(path, "/")
+	if !ok {
+		return nil, "", fmt.Errorf("invalid path %q: must start with '/'", path)
+	}
+	parts := strings.Split(rest, "/")
+	for i, part := range parts {
+		if name, ok := strings.CutPrefix(part, "@"); ok {
+			if i != len(parts)-1 || i == 0 || !isXMLName(name) {
+				return nil, "", fmt.Errorf("invalid path %q: bad attribute step %q", path, part)
+			}
+			attr = name
+			continue
+		}
+		step := xmlPathStep{name: part}
+		if open := strings.IndexByte(part, '['); open >= 0 {
+			n, err := strconv.Atoi(strings.TrimSuffix(part[open+1:], "]"))
+			if !strings.HasSuffix(part, "]") || err != nil || n < 1 {
+				return nil, "", fmt.Errorf("invalid path %q: bad position in %q", path, part)
+			}
+			step = xmlPathStep{name: part[:open], pos: n}
+		}
+		if !isXMLName(step.name) {
+			return nil, "", fmt.Errorf("invalid path %q: bad element step %q", path, part)
+		}
+		steps = append(steps, step)
+	}
+	return steps, attr, nil
+}
+
+// xmlRawName renders n as written in the document (RawToken keeps prefixes).
+func xmlRawName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
+
+// extractXML finds the target of steps (and attr) in data.
+func extractXML(data string, steps []xmlPathStep, attr string) (string, error) {
+	dec := xml.NewDecoder(strings.NewReader(data))
+	counts := make([]int, len(steps)) // Same-named siblings seen, per step.
+	matched, depth := 0, 0            // Elements of the open chain matching steps; open elements.
+	for {
+		start := dec.InputOffset()
+		tok, err := dec.RawToken()
+		if errors.Is(err, io.EOF) {
+			return "", ErrXMLPathNotFound
+		}
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+			if depth != matched+1 || xmlRawName(t.Name) != steps[matched].name {
+				continue
+			}
+			counts[matched]++
+			if p := steps[matched].pos; p != 0 && counts[matched] != p {
+				continue
+			}
+			if matched+1 < len(steps) {
+				matched++
+				counts[matched] = 0
+				continue
+			}
+			if attr == "" {
+				if err := skipXMLElement(dec); err != nil {
+					return "", err
+				}
+				return data[start:dec.InputOffset()], nil
+			}
+			for _, a := range t.Attr {
+				if xmlRawName(a.Name) == attr {
+					var b strings.Builder
+					ignoreErr(xml.EscapeText(&b, []byte(a.Value)))
+					return b.String(), nil
+				}
+			}
+			// No such attribute: keep looking at the next siblings.
+		case xml.EndElement:
+			if depth == matched {
+				matched--
+			}
+			depth--
+		}
+	}
+}
+
+// skipXMLElement consumes the tokens up to the end of the element whose start
+// tag has just been read.
+func skipXMLElement(dec *xml.Decoder) error {
+	for depth := 1; depth > 0; {
+		tok, err := dec.RawToken()
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return err
+		}
+		switch tok.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+	return nil
+}
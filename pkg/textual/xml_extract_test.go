@@ -0,0 +1,123 @@
+// Copyright 2026 Benoit Pereira da Silva
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textual
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func runExtractXML(t *testing.T, path string, values ...string) []XmlCarrier {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan XmlCarrier, len(values))
+	for i, v := range values {
+		in <- XmlCarrier{Value: v, Index: i}
+	}
+	close(in)
+
+	items, err := collectWithContext(ctx, ExtractXML(path).Apply(ctx, in))
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(items) != len(values) {
+		t.Fatalf("unexpected output count: got %d want %d", len(items), len(values))
+	}
+	return items
+}
+
+const xmlExtractDoc = `<library name="city &amp; co">` +
+	`<book id="1"><title>Dune</title></book>` +
+	`<book id="2"><title>Solaris</title><isbn>978-0</isbn></book>` +
+	`<book id="3"/>` +
+	`</library>`
+
+func TestExtractXML_NestedElements(t *testing.T) {
+	cases := []struct{ path, want string }{
+		{"/library/book/title", "<title>Dune</title>"},
+		{"/library/book/isbn", "<isbn>978-0</isbn>"}, // Found in the second book.
+		{"/library", xmlExtractDoc},
+	}
+	for _, c := range cases {
+		items := runExtractXML(t, c.path, xmlExtractDoc)
+		if err := items[0].GetError(); err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.path, err)
+		}
+		if got := items[0].Value; got != c.want {
+			t.Fatalf("%s: unexpected fragment: got %q want %q", c.path, got, c.want)
+		}
+	}
+}
+
+func TestExtractXML_Attributes(t *testing.T) {
+	cases := []struct{ path, want string }{
+		{"/library/@name", "city &amp; co"},
+		{"/library/book/@id", "1"},
+		{"/library/book[3]/@id", "3"},
+	}
+	for _, c := range cases {
+		items := runExtractXML(t, c.path, xmlExtractDoc)
+		if err := items[0].GetError(); err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.path, err)
+		}
+		if got := items[0].Value; got != c.want {
+			t.Fatalf("%s: unexpected value: got %q want %q", c.path, got, c.want)
+		}
+	}
+}
+
+func TestExtractXML_PositionalSelection(t *testing.T) {
+	cases := []struct{ path, want string }{
+		{"/library/book[2]/title", "<title>Solaris</title>"},
+		{"/library/book[3]", `<book id="3"/>`},
+	}
+	for _, c := range cases {
+		items := runExtractXML(t, c.path, xmlExtractDoc)
+		if got := items[0].Value; got != c.want {
+			t.Fatalf("%s: unexpected fragment: got %q want %q (error %v)", c.path, got, c.want, items[0].GetError())
+		}
+	}
+}
+
+func TestExtractXML_NotFound(t *testing.T) {
+	for _, path := range []string{"/library/book[4]", "/library/book[1]/isbn", "/shelf/book", "/library/book/@lang"} {
+		items := runExtractXML(t, path, xmlExtractDoc)
+		if !errors.Is(items[0].GetError(), ErrXMLPathNotFound) || items[0].Value != "" {
+			t.Fatalf("%s: unexpected output: got %+v want an error wrapping %v", path, items[0], ErrXMLPathNotFound)
+		}
+	}
+
+	items := runExtractXML(t, "/a/b", "<a><b>")
+	if items[0].GetError() == nil || items[0].Value != "" {
+		t.Fatalf("unexpected output for malformed XML: got %+v", items[0])
+	}
+}
+
+func TestExtractXML_InvalidPathPanics(t *testing.T) {
+	for _, path := range []string{"", "a/b", "/", "/a//b", "/@id", "/a/@id/b", "/a[0]", "/a[x]", "/a[1"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic for path %q", path)
+				}
+			}()
+			ExtractXML(path)
+		}()
+	}
+}